@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/tui"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+const commandUsage = `usage: sspt [command]
+
+Without a command, sspt starts the interactive dashboard.
+
+Commands:
+  template list
+  template apply <name> [--workspace slug] [--sprint N] [--date YYYY-MM-DD]
+  template delete <name>
+  heatmap [--workspace slug,...] [--metric focus|goals] [--days N] [--width N]
+  time [--workspace slug,...] [--days N | --from YYYY-MM-DD --to YYYY-MM-DD]
+       [--by goal|workspace|tag|priority] [--format markdown|csv]
+  csv export [--dir DIR]
+  csv import <file> [--workspace slug] [--map field=column,...] [--dry-run]
+  ics [--workspace slug,...] [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--dir DIR]
+  calendar [--file path.ics | --clear] [--date YYYY-MM-DD]
+`
+
+// commandNames are the subcommands runCommand dispatches.
+var commandNames = map[string]bool{
+	"template": true, "templates": true, "heatmap": true, "time": true,
+	"csv": true, "ics": true, "calendar": true,
+}
+
+// runUsageCommand handles the invocations that need no database: help and
+// unknown commands. It reports whether args were handled, with the exit code,
+// so main can answer them before creating or unlocking the database.
+func runUsageCommand(args []string, stdout, stderr io.Writer) (int, bool) {
+	switch {
+	case len(args) == 0:
+		fmt.Fprint(stderr, commandUsage)
+		return 2, true
+	case args[0] == "help" || args[0] == "-h" || args[0] == "--help":
+		fmt.Fprint(stdout, commandUsage)
+		return 0, true
+	case !commandNames[args[0]]:
+		fmt.Fprintf(stderr, "Error: unknown command %q\n", args[0])
+		fmt.Fprint(stderr, commandUsage)
+		return 2, true
+	}
+	return 0, false
+}
+
+// runCommand executes a non-interactive subcommand and returns the process
+// exit code.
+func runCommand(ctx context.Context, db *database.Database, args []string, stdout, stderr io.Writer) int {
+	if code, ok := runUsageCommand(args, stdout, stderr); ok {
+		return code
+	}
+	var err error
+	switch args[0] {
+	case "template", "templates":
+		err = runTemplateCommand(ctx, db, args[1:], stdout)
+	case "heatmap":
+		err = runHeatmapCommand(ctx, db, args[1:], stdout)
+	case "time":
+		err = runTimeCommand(ctx, db, args[1:], stdout)
+	case "csv":
+		err = runCSVCommand(ctx, db, args[1:], stdout)
+	case "ics":
+		err = runICSCommand(ctx, db, args[1:], stdout)
+	case "calendar":
+		err = runCalendarCommand(ctx, db, args[1:], stdout)
+	}
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(stderr, commandUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+var errUsage = errors.New("invalid usage")
+
+func runTemplateCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		templates, err := db.GetGoalTemplates(ctx)
+		if err != nil {
+			return err
+		}
+		if len(templates) == 0 {
+			fmt.Fprintln(out, "No templates. Define them in the seed file with '& Name'.")
+			return nil
+		}
+		for _, tpl := range templates {
+			fmt.Fprintf(out, "%s\t%s (%d goals)\n", tpl.Name, tpl.Goal.Description, tpl.Goal.Count())
+		}
+		return nil
+	case "apply":
+		if len(args) < 2 {
+			return errUsage
+		}
+		fs := flag.NewFlagSet("template apply", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		workspace := fs.String("workspace", "", "workspace slug")
+		sprint := fs.Int("sprint", 0, "sprint number (0 = backlog)")
+		date := fs.String("date", time.Now().Format(util.DateLayout), "day used for the sprint and placeholders")
+		if err := fs.Parse(args[2:]); err != nil {
+			return errUsage
+		}
+		tpl, ok, err := db.GetGoalTemplate(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("template %q not found", args[1])
+		}
+		at, err := time.ParseInLocation(util.DateLayout, *date, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid date %q", *date)
+		}
+		wsID, err := resolveWorkspace(ctx, db, *workspace)
+		if err != nil {
+			return err
+		}
+		sprintID, err := resolveSprint(ctx, db, wsID, *date, *sprint)
+		if err != nil {
+			return err
+		}
+		if _, err := db.InstantiateGoalTemplate(ctx, tpl, wsID, sprintID, at); err != nil {
+			return err
+		}
+		target := "backlog"
+		if sprintID > 0 {
+			target = fmt.Sprintf("sprint %d on %s", *sprint, *date)
+		}
+		fmt.Fprintf(out, "Created %d goal(s) from %q in %s\n", tpl.Goal.Count(), tpl.Name, target)
+		return nil
+	case "delete":
+		if len(args) != 2 {
+			return errUsage
+		}
+		if _, ok, err := db.GetGoalTemplate(ctx, args[1]); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("template %q not found", args[1])
+		}
+		if err := db.DeleteGoalTemplate(ctx, args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Deleted template %q\n", args[1])
+		return nil
+	}
+	return errUsage
+}
+
+// runHeatmapCommand prints the activity heatmap of the given workspaces (all
+// by default) in the theme of the first one.
+func runHeatmapCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	workspaces := fs.String("workspace", "", "comma separated workspace slugs")
+	metric := fs.String("metric", "focus", "focus or goals")
+	days := fs.Int("days", tui.HeatmapDays, "number of days up to today")
+	width := fs.Int("width", 80, "maximum width in columns")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	var m tui.HeatmapMetric
+	switch *metric {
+	case "focus":
+		m = tui.HeatmapFocus
+	case "goals":
+		m = tui.HeatmapGoals
+	default:
+		return fmt.Errorf("unknown metric %q", *metric)
+	}
+	if *days < 1 {
+		return fmt.Errorf("days must be positive")
+	}
+	selected, err := resolveWorkspaces(ctx, db, *workspaces)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(out, "No workspaces yet.")
+		return nil
+	}
+	ids := make([]int64, len(selected))
+	names := make([]string, len(selected))
+	for i, ws := range selected {
+		ids[i], names[i] = ws.ID, ws.Name
+	}
+	now := time.Now()
+	heatmap, err := db.GetHeatmap(ctx, ids, now.AddDate(0, 0, -(*days-1)).Format(util.DateLayout), now.Format(util.DateLayout))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: %s\n", strings.Join(names, ", "), m)
+	fmt.Fprint(out, tui.RenderHeatmap(heatmap, m, tui.ResolveTheme(selected[0].Theme), *width))
+	return nil
+}
+
+func runTimeCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("time", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	workspaces := fs.String("workspace", "", "comma separated workspace slugs")
+	days := fs.Int("days", 7, "number of days up to today")
+	from := fs.String("from", "", "first date (YYYY-MM-DD)")
+	to := fs.String("to", "", "last date (YYYY-MM-DD), default today")
+	by := fs.String("by", "goal", "goal, workspace, tag or priority")
+	format := fs.String("format", "markdown", "markdown or csv")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	group, ok := database.ParseTimeGroup(*by)
+	if !ok {
+		return fmt.Errorf("unknown grouping %q", *by)
+	}
+	if *format != "markdown" && *format != "csv" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	now := time.Now()
+	if *to == "" {
+		*to = now.Format(util.DateLayout)
+	}
+	if *from == "" {
+		if *days < 1 {
+			return fmt.Errorf("days must be positive")
+		}
+		last, err := time.Parse(util.DateLayout, *to)
+		if err != nil {
+			return fmt.Errorf("invalid date %q", *to)
+		}
+		*from = last.AddDate(0, 0, -(*days - 1)).Format(util.DateLayout)
+	}
+	selected, err := resolveWorkspaces(ctx, db, *workspaces)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, len(selected))
+	for i, ws := range selected {
+		ids[i] = ws.ID
+	}
+	report, err := db.GetTimeReport(ctx, ids, *from, *to)
+	if err != nil {
+		return err
+	}
+	if *format == "csv" {
+		return tui.WriteTimeReportCSV(out, report, group)
+	}
+	_, err = fmt.Fprint(out, tui.FormatTimeReportMarkdown(report, group))
+	return err
+}
+
+func runCSVCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "export":
+		fs := flag.NewFlagSet("csv export", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		dir := fs.String("dir", "", "output folder, default a new folder in the exports directory")
+		if err := fs.Parse(args[1:]); err != nil {
+			return errUsage
+		}
+		var err error
+		if *dir == "" {
+			*dir, err = tui.ExportCSV(ctx, db)
+		} else {
+			err = tui.WriteCSVExport(ctx, db, *dir)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %s, %s and %s to %s\n", tui.CSVGoalsFile, tui.CSVSprintsFile, tui.CSVTimeFile, *dir)
+		return nil
+	case "import":
+		if len(args) < 2 {
+			return errUsage
+		}
+		fs := flag.NewFlagSet("csv import", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		workspace := fs.String("workspace", "", "workspace slug")
+		mapping := fs.String("map", "", "comma separated field=column pairs")
+		dryRun := fs.Bool("dry-run", false, "preview without importing")
+		if err := fs.Parse(args[2:]); err != nil {
+			return errUsage
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		c, err := tui.ParseCSVImport(f)
+		if err != nil {
+			return err
+		}
+		for _, pair := range strings.Split(*mapping, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			field, column, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("invalid mapping %q, use field=column", pair)
+			}
+			if err := c.Map(strings.TrimSpace(field), strings.TrimSpace(column)); err != nil {
+				return err
+			}
+		}
+		if c.Mapping["description"] < 0 {
+			return fmt.Errorf("no description column, map one with --map description=<column>")
+		}
+		wsID, err := resolveWorkspace(ctx, db, *workspace)
+		if err != nil {
+			return err
+		}
+		preview, err := tui.PreviewCSVImport(ctx, db, wsID, c)
+		if err != nil {
+			return err
+		}
+		for _, field := range tui.CSVImportFields {
+			fmt.Fprintf(out, "%-12s <- %s\n", field, c.ColumnName(field))
+		}
+		for _, p := range preview {
+			if p.Status != tui.CSVRowNew {
+				fmt.Fprintf(out, "line %d: %s, %s\n", p.Line, p.Status, p.Reason)
+			}
+		}
+		counts := tui.CountCSVRows(preview)
+		if *dryRun {
+			fmt.Fprintf(out, "Would import %d goal(s), skip %d duplicate(s) and %d invalid row(s)\n",
+				counts[tui.CSVRowNew], counts[tui.CSVRowDuplicate], counts[tui.CSVRowInvalid])
+			return nil
+		}
+		imported, err := tui.ImportCSVRows(ctx, db, wsID, preview)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Imported %d goal(s), skipped %d duplicate(s) and %d invalid row(s)\n",
+			imported, counts[tui.CSVRowDuplicate], counts[tui.CSVRowInvalid])
+		return nil
+	}
+	return errUsage
+}
+
+func runICSCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("ics", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	workspaces := fs.String("workspace", "", "comma separated workspace slugs")
+	from := fs.String("from", "", "first date (YYYY-MM-DD)")
+	to := fs.String("to", "", "last date (YYYY-MM-DD)")
+	dir := fs.String("dir", "", "output folder, default the calendar folder of the reports directory")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	for _, date := range []string{*from, *to} {
+		if _, err := time.Parse(util.DateLayout, date); date != "" && err != nil {
+			return fmt.Errorf("invalid date %q", date)
+		}
+	}
+	selected, err := resolveWorkspaces(ctx, db, *workspaces)
+	if err != nil {
+		return err
+	}
+	for _, ws := range selected {
+		opts := tui.ICSOptions{WorkspaceID: ws.ID, From: *from, To: *to}
+		path := filepath.Join(*dir, tui.ICSFileName(ws.Slug, opts))
+		if *dir == "" {
+			path, err = tui.ExportICS(ctx, db, opts, ws.Slug)
+		} else {
+			err = tui.WriteICS(ctx, db, opts, path)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %s\n", path)
+	}
+	return nil
+}
+
+func runCalendarCommand(ctx context.Context, db *database.Database, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("calendar", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	file := fs.String("file", "", "local .ics file to read meetings from")
+	clear := fs.Bool("clear", false, "stop reading meetings")
+	now := time.Now()
+	date := fs.String("date", now.Format(util.DateLayout), "day to list meetings for")
+	if err := fs.Parse(args); err != nil || (*clear && *file != "") {
+		return errUsage
+	}
+	if _, err := time.Parse(util.DateLayout, *date); err != nil {
+		return fmt.Errorf("invalid date %q", *date)
+	}
+	if *clear || *file != "" {
+		if err := tui.SetCalendarFile(ctx, db, *file); err != nil {
+			return err
+		}
+	}
+	path := tui.CalendarFile(ctx, db)
+	if path == "" {
+		fmt.Fprintln(out, "No calendar file set. Use --file path.ics to read meetings from one.")
+		return nil
+	}
+	meetings, err := tui.LoadMeetings(ctx, db, *date)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Calendar file: %s\n%d meeting(s) on %s\n", path, len(meetings), *date)
+	for _, meeting := range meetings {
+		fmt.Fprintf(out, "  %s\n", tui.FormatMeeting(meeting))
+	}
+	if n := tui.SuggestSprintCount(*date, meetings, now); n > 0 {
+		fmt.Fprintf(out, "%d sprint(s) fit around them\n", n)
+	}
+	return nil
+}
+
+// resolveWorkspaces maps a comma separated list of slugs to workspaces; an
+// empty list selects them all.
+func resolveWorkspaces(ctx context.Context, db *database.Database, slugs string) ([]models.Workspace, error) {
+	all, err := db.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var selected []models.Workspace
+	for _, slug := range strings.Split(slugs, ",") {
+		slug = strings.TrimSpace(slug)
+		found := false
+		for _, ws := range all {
+			if slug == "" || strings.EqualFold(ws.Slug, slug) {
+				selected = append(selected, ws)
+				found = true
+			}
+		}
+		if slug != "" && !found {
+			return nil, fmt.Errorf("workspace %q not found", slug)
+		}
+	}
+	return selected, nil
+}
+
+func resolveWorkspace(ctx context.Context, db *database.Database, slug string) (int64, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return db.EnsureDefaultWorkspace(ctx)
+	}
+	id, ok, err := db.GetWorkspaceIDBySlug(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("workspace %q not found", slug)
+	}
+	return id, nil
+}
+
+// resolveSprint maps a sprint number on a day to its ID; 0 is the backlog.
+func resolveSprint(ctx context.Context, db *database.Database, workspaceID int64, date string, number int) (int64, error) {
+	if number <= 0 {
+		return 0, nil
+	}
+	dayID, ok, err := db.GetDayIDByDate(ctx, date)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("no sprints planned for %s", date)
+	}
+	sprints, err := db.GetSprints(ctx, dayID, workspaceID)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range sprints {
+		if s.SprintNumber == number {
+			return s.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("sprint %d not found on %s", number, date)
+}
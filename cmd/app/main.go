@@ -18,6 +18,11 @@ import (
 
 func main() {
 	ctx := context.Background()
+	if len(os.Args) > 1 {
+		if code, ok := runUsageCommand(os.Args[1:], os.Stdout, os.Stderr); ok {
+			os.Exit(code)
+		}
+	}
 	// 1. Initialize Database
 	dbRoot := util.DataDir(config.AppName)
 	if err := os.MkdirAll(dbRoot, 0o755); err != nil {
@@ -118,6 +123,11 @@ func main() {
 			util.LogError("store passphrase hash", err)
 		}
 	}
+	if len(os.Args) > 1 {
+		code := runCommand(ctx, db, os.Args[1:], os.Stdout, os.Stderr)
+		closeDB(db)
+		os.Exit(code)
+	}
 	defer closeDB(db)
 
 	// 2. Initialize the Main Model
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
 )
 
 func TestCleanupStaleDBArtifacts(t *testing.T) {
@@ -25,3 +30,278 @@ func TestCleanupStaleDBArtifacts(t *testing.T) {
 		}
 	}
 }
+
+func TestRunUsageCommand(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code, ok := runUsageCommand([]string{"help"}, &out, &errOut); !ok || code != 0 || !strings.Contains(out.String(), "usage: sspt") {
+		t.Fatalf("expected help without a database, got ok %v code %d", ok, code)
+	}
+	if code, ok := runUsageCommand([]string{"tempalte", "list"}, &out, &errOut); !ok || code != 2 || !strings.Contains(errOut.String(), `unknown command "tempalte"`) {
+		t.Fatalf("expected unknown command usage, got ok %v code %d %q", ok, code, errOut.String())
+	}
+	if _, ok := runUsageCommand([]string{"template", "list"}, &out, &errOut); ok {
+		t.Fatalf("expected known commands to need the database")
+	}
+}
+
+func TestRunTemplateCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 2); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	goal := database.TemplateGoal{
+		GoalSeed: database.GoalSeed{Description: "Weekly review {{date}}"},
+		Subtasks: []database.TemplateGoal{{GoalSeed: database.GoalSeed{Description: "Clear inbox"}}},
+	}
+	if err := db.SaveGoalTemplate(ctx, "Weekly review", goal); err != nil {
+		t.Fatalf("SaveGoalTemplate failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := runCommand(ctx, db, []string{"template", "list"}, &out, &errOut); code != 0 || !strings.Contains(out.String(), "Weekly review") {
+		t.Fatalf("list: code %d, out %q, err %q", code, out.String(), errOut.String())
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, []string{"template", "apply", "weekly review", "--sprint", "2"}, &out, &errOut); code != 0 {
+		t.Fatalf("apply: code %d, err %q", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "Created 2 goal(s)") {
+		t.Fatalf("unexpected apply output %q", out.String())
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	goals, err := db.GetGoalsForSprint(ctx, sprints[1].ID)
+	if err != nil {
+		t.Fatalf("GetGoalsForSprint failed: %v", err)
+	}
+	roots := 0
+	for _, g := range goals {
+		if g.ParentID == nil && strings.HasPrefix(g.Description, "Weekly review 2") {
+			roots++
+		}
+	}
+	if len(goals) != 2 || roots != 1 {
+		t.Fatalf("expected template goal in sprint 2, got %+v", goals)
+	}
+
+	errOut.Reset()
+	if code := runCommand(ctx, db, []string{"template", "apply", "missing"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "not found") {
+		t.Fatalf("expected missing template error, got code %d err %q", code, errOut.String())
+	}
+	if code := runCommand(ctx, db, []string{"bogus"}, &out, &errOut); code != 2 || !strings.Contains(errOut.String(), "usage: sspt") {
+		t.Fatalf("expected unknown command to print the usage, got %d", code)
+	}
+	if code := runCommand(ctx, db, []string{"template", "apply", "weekly review", "--sprint", "two"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected a bad flag to print the usage, got %d", code)
+	}
+	if code := runCommand(ctx, db, []string{"template"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected usage exit code, got %d", code)
+	}
+	if code := runCommand(ctx, db, []string{"template", "delete", "Weekly review"}, &out, &errOut); code != 0 {
+		t.Fatalf("delete: code %d, err %q", code, errOut.String())
+	}
+}
+
+func TestRunHeatmapCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+	if _, err := db.EnsureDefaultWorkspace(ctx); err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := runCommand(ctx, db, []string{"heatmap", "--metric", "goals", "--days", "30"}, &out, &errOut); code != 0 {
+		t.Fatalf("heatmap: code %d, err %q", code, errOut.String())
+	}
+	if text := out.String(); !strings.Contains(text, "completed goals") || !strings.Contains(text, "Mon") || !strings.Contains(text, "Less") {
+		t.Fatalf("unexpected heatmap output:\n%s", text)
+	}
+	errOut.Reset()
+	if code := runCommand(ctx, db, []string{"heatmap", "--workspace", "missing"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "not found") {
+		t.Fatalf("expected unknown workspace error, got code %d %q", code, errOut.String())
+	}
+	errOut.Reset()
+	if code := runCommand(ctx, db, []string{"heatmap", "--metric", "lines"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected unknown metric error, got code %d", code)
+	}
+}
+
+func TestRunTimeCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.AddGoalDetailed(ctx, wsID, 0, database.GoalSeed{Description: "Invoice, client", Tags: []string{"billing"}}); err != nil {
+		t.Fatalf("AddGoalDetailed failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.StartTaskTimer(ctx, goalID); err != nil {
+		t.Fatalf("StartTaskTimer failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, "UPDATE goals SET task_started_at = datetime('now', '-90 minutes') WHERE id = ?", goalID); err != nil {
+		t.Fatalf("backdating timer failed: %v", err)
+	}
+	if err := db.PauseTaskTimer(ctx, goalID); err != nil {
+		t.Fatalf("PauseTaskTimer failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := runCommand(ctx, db, []string{"time", "--format", "csv"}, &out, &errOut); code != 0 {
+		t.Fatalf("time: code %d, err %q", code, errOut.String())
+	}
+	if text := out.String(); !strings.HasPrefix(text, "goal_id,goal,workspace,tags,priority,hours,seconds\n") || !strings.Contains(text, `"Invoice, client",Personal,billing,3,1.50,5400`) {
+		t.Fatalf("unexpected csv output:\n%s", text)
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, []string{"time", "--by", "tag", "--days", "30"}, &out, &errOut); code != 0 {
+		t.Fatalf("time: code %d, err %q", code, errOut.String())
+	}
+	if text := out.String(); !strings.Contains(text, "# Time Report") || !strings.Contains(text, "| #billing | 1 | 1h 30m | 1.50 |") {
+		t.Fatalf("unexpected markdown output:\n%s", text)
+	}
+	if code := runCommand(ctx, db, []string{"time", "--by", "client"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected unknown grouping error, got code %d", code)
+	}
+	if code := runCommand(ctx, db, []string{"time", "--from", "2026-02-01", "--to", "2026-01-01"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected reversed range error, got code %d", code)
+	}
+}
+
+func TestRunCSVCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.AddGoal(ctx, wsID, "Exported goal", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "csv")
+	var out, errOut bytes.Buffer
+	if code := runCommand(ctx, db, []string{"csv", "export", "--dir", dir}, &out, &errOut); code != 0 {
+		t.Fatalf("csv export: code %d, err %q", code, errOut.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "time_entries.csv")); err != nil {
+		t.Fatalf("expected time entries export: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sheet.csv")
+	if err := os.WriteFile(path, []byte("What,Prio\nExported goal,2\nFrom sheet,1\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, []string{"csv", "import", path, "--dry-run"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "no description column") {
+		t.Fatalf("expected missing description error, got code %d %q", code, errOut.String())
+	}
+	args := []string{"csv", "import", path, "--map", "description=What,priority=2", "--dry-run"}
+	if code := runCommand(ctx, db, args, &out, &errOut); code != 0 {
+		t.Fatalf("csv import: code %d, err %q", code, errOut.String())
+	}
+	if text := out.String(); !strings.Contains(text, "line 2: duplicate") || !strings.Contains(text, "Would import 1 goal(s), skip 1 duplicate(s)") {
+		t.Fatalf("unexpected dry run output:\n%s", text)
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, args[:len(args)-1], &out, &errOut); code != 0 || !strings.Contains(out.String(), "Imported 1 goal(s)") {
+		t.Fatalf("csv import: code %d, out %q, err %q", code, out.String(), errOut.String())
+	}
+	goals, err := db.GetBacklogGoals(ctx, wsID)
+	if err != nil || len(goals) != 2 {
+		t.Fatalf("expected 2 backlog goals, got %d (%v)", len(goals), err)
+	}
+}
+
+func TestRunICSCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if _, err := db.BackfillDay(ctx, wsID, "2024-03-01", 2); err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	var out, errOut bytes.Buffer
+	args := []string{"ics", "--workspace", "personal", "--from", "2024-03-01", "--to", "2024-03-01", "--dir", dir}
+	if code := runCommand(ctx, db, args, &out, &errOut); code != 0 {
+		t.Fatalf("ics: code %d, err %q", code, errOut.String())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "personal_2024-03-01_2024-03-01.ics"))
+	if err != nil {
+		t.Fatalf("expected calendar file: %v", err)
+	}
+	if n := strings.Count(string(data), "BEGIN:VEVENT"); n != 2 {
+		t.Fatalf("expected 2 sprint events, got %d", n)
+	}
+	if code := runCommand(ctx, db, []string{"ics", "--from", "March"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "invalid date") {
+		t.Fatalf("expected invalid date error, got code %d %q", code, errOut.String())
+	}
+}
+
+func TestRunCalendarCommand(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, filepath.Join(t.TempDir(), "sprints.db"), "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer closeDB(db)
+
+	var out, errOut bytes.Buffer
+	if code := runCommand(ctx, db, []string{"calendar"}, &out, &errOut); code != 0 || !strings.Contains(out.String(), "No calendar file set") {
+		t.Fatalf("calendar: code %d, out %q, err %q", code, out.String(), errOut.String())
+	}
+	path := filepath.Join(t.TempDir(), "work.ics")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nSUMMARY:Planning\r\nDTSTART:20240301T100000\r\nDTEND:20240301T110000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, []string{"calendar", "--file", path, "--date", "2024-03-01"}, &out, &errOut); code != 0 {
+		t.Fatalf("calendar --file: code %d, err %q", code, errOut.String())
+	}
+	if text := out.String(); !strings.Contains(text, "1 meeting(s) on 2024-03-01") || !strings.Contains(text, "10:00-11:00 Planning") || !strings.Contains(text, "3 sprint(s) fit") {
+		t.Fatalf("unexpected calendar output:\n%s", text)
+	}
+	if code := runCommand(ctx, db, []string{"calendar", "--file", filepath.Join(t.TempDir(), "missing.ics")}, &out, &errOut); code != 1 {
+		t.Fatalf("expected a missing file to be refused, got code %d", code)
+	}
+	out.Reset()
+	if code := runCommand(ctx, db, []string{"calendar", "--clear"}, &out, &errOut); code != 0 || !strings.Contains(out.String(), "No calendar file set") {
+		t.Fatalf("calendar --clear: code %d, out %q", code, out.String())
+	}
+}
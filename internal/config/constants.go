@@ -18,6 +18,12 @@ const (
 	ViewModeMinimal
 )
 
+// Board modes.
+const (
+	BoardModeSprints = iota
+	BoardModeKanban
+)
+
 // Goal IDs.
 const (
 	GoalIDNone int64 = 0
@@ -51,6 +57,28 @@ const (
 	LongOperationTimeout = 30 * time.Second
 )
 
+// Auto-plan settings. Capacity is in effort points (S=1, M=2, L=3, XL=5).
+const (
+	AutoPlanSprintCapacity = 3
+	AutoPlanMaxCapacity    = 20
+)
+
+// Forecast settings. History is the number of past days sampled for
+// velocity; forecasts beyond MaxDays are reported as open-ended.
+const (
+	ForecastHistoryDays = 42
+	ForecastTrials      = 1000
+	ForecastMaxDays     = 730
+)
+
+// Calendar settings. Sprints without recorded times are planned from
+// PlannedDayStart on, one sprint and break after another; the sprint count
+// suggestion fits them before PlannedDayEnd.
+const (
+	PlannedDayStart = 9 * time.Hour
+	PlannedDayEnd   = 18 * time.Hour
+)
+
 // Display settings.
 const (
 	MinDisplayColumns      = 3
@@ -58,6 +86,8 @@ const (
 	AnalyticsChartPadding  = 24
 	AnalyticsChartMaxWidth = 48
 	AnalyticsChartMinWidth = 10
+	FocusTrendDays         = 14
 	MinTerminalWidth       = 80
 	MinTerminalHeight      = 24
+	FooterHelpLines        = 2 // Key help lines shown until ? expands them
 )
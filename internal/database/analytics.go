@@ -0,0 +1,241 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// AnalyticsDay holds the totals of one day of an analytics period.
+type AnalyticsDay struct {
+	Date             string
+	Focus            time.Duration
+	SprintsPlanned   int
+	SprintsCompleted int
+	GoalsTotal       int
+	GoalsCompleted   int
+	CarriedOver      int
+}
+
+// CompletionRate is the share of the day's sprint goals that were completed,
+// or -1 when the day had none.
+func (a AnalyticsDay) CompletionRate() float64 {
+	if a.GoalsTotal == 0 {
+		return -1
+	}
+	return float64(a.GoalsCompleted) / float64(a.GoalsTotal)
+}
+
+// Analytics summarises a workspace over the days From to To (inclusive).
+// Days lists every date of the period, including days without records.
+type Analytics struct {
+	From             string
+	To               string
+	Days             []AnalyticsDay
+	CorrectedSprints int
+	CycleTime        time.Duration // Average created→completed of goals completed in the period
+	CycleSamples     int
+	TaskTime         time.Duration // Task timer time of goals completed in the period
+	CurrentStreak    int           // Days in a row with a completed sprint, ending at To
+	LongestStreak    int
+}
+
+// Totals sums the days of the period.
+func (a Analytics) Totals() AnalyticsDay {
+	var t AnalyticsDay
+	for _, day := range a.Days {
+		t.Focus += day.Focus
+		t.SprintsPlanned += day.SprintsPlanned
+		t.SprintsCompleted += day.SprintsCompleted
+		t.GoalsTotal += day.GoalsTotal
+		t.GoalsCompleted += day.GoalsCompleted
+		t.CarriedOver += day.CarriedOver
+	}
+	return t
+}
+
+// CarryOverRate is the share of the period's sprint goals that were left
+// unfinished and carried to a later day, or -1 when there were none.
+func (a Analytics) CarryOverRate() float64 {
+	t := a.Totals()
+	if t.GoalsTotal == 0 {
+		return -1
+	}
+	return float64(t.CarriedOver) / float64(t.GoalsTotal)
+}
+
+// periodDates lists every date from from to to (YYYY-MM-DD) along with the
+// index of each date in the list.
+func periodDates(from, to string) ([]string, map[string]int, error) {
+	first, err := time.Parse(util.DateLayout, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	last, err := time.Parse(util.DateLayout, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if last.Before(first) {
+		return nil, nil, fmt.Errorf("%s is before %s", to, from)
+	}
+	var dates []string
+	index := make(map[string]int)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		index[day.Format(util.DateLayout)] = len(dates)
+		dates = append(dates, day.Format(util.DateLayout))
+	}
+	return dates, index, nil
+}
+
+// inWorkspaces returns the placeholders of an IN list of workspace IDs and
+// the query arguments: the IDs followed by rest.
+func inWorkspaces(workspaceIDs []int64, rest ...any) (string, []any) {
+	placeholders := strings.TrimRight(strings.Repeat("?,", len(workspaceIDs)), ",")
+	args := make([]any, 0, len(workspaceIDs)+len(rest))
+	for _, id := range workspaceIDs {
+		args = append(args, id)
+	}
+	return placeholders, append(args, rest...)
+}
+
+// scanPeriodSprints calls fn with the date and the times of every numbered
+// sprint of the workspaces from from to to.
+func (d *Database) scanPeriodSprints(ctx context.Context, workspaceIDs []int64, from, to string, fn func(date string, s models.Sprint)) error {
+	if len(workspaceIDs) == 0 {
+		return nil
+	}
+	placeholders, args := inWorkspaces(workspaceIDs, from, to)
+	rows, err := d.DB.QueryContext(ctx, `
+		SELECT dy.date, s.status, s.start_time, s.end_time, s.elapsed_seconds, COALESCE(s.corrected, 0)
+		FROM sprints s
+		JOIN days dy ON dy.id = s.day_id
+		WHERE s.workspace_id IN (`+placeholders+`) AND s.sprint_number > 0 AND dy.date BETWEEN ? AND ?`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var date string
+		var s models.Sprint
+		if err := rows.Scan(&date, &s.Status, &s.StartTime, &s.EndTime, &s.ElapsedSeconds, &s.Corrected); err != nil {
+			return err
+		}
+		fn(date, s)
+	}
+	return rows.Err()
+}
+
+// GetAnalytics gathers focus time, sprint and goal completion, cycle time,
+// carry-overs and streaks of a workspace between from and to (YYYY-MM-DD).
+// A day's goals are those in its sprints, along with those its sprints left
+// unfinished for a later day.
+func (d *Database) GetAnalytics(ctx context.Context, workspaceID int64, from, to string) (Analytics, error) {
+	dates, index, err := periodDates(from, to)
+	if err != nil {
+		return Analytics{}, wrapErr(EntitySprint, "analytics", 0, err)
+	}
+	a := Analytics{From: from, To: to}
+	for _, date := range dates {
+		a.Days = append(a.Days, AnalyticsDay{Date: date})
+	}
+
+	err = d.withDBContext(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		err := d.scanPeriodSprints(ctx, []int64{workspaceID}, from, to, func(date string, s models.Sprint) {
+			day := &a.Days[index[date]]
+			day.Focus += s.FocusedDuration(now)
+			day.SprintsPlanned++
+			if s.Status == models.StatusCompleted {
+				day.SprintsCompleted++
+			}
+			if s.Corrected {
+				a.CorrectedSprints++
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := d.DB.QueryContext(ctx, `
+			WITH day_goals AS (
+				SELECT g.id AS goal_id, s.day_id, 0 AS left_over
+				FROM goals g JOIN sprints s ON s.id = g.sprint_id
+				UNION
+				SELECT c.goal_id, s.day_id, 1
+				FROM carry_overs c JOIN sprints s ON s.id = c.sprint_id
+			)
+			SELECT dy.date, COUNT(DISTINCT g.id),
+				COUNT(DISTINCT CASE WHEN g.status = 'completed' AND dg.left_over = 0 THEN g.id END),
+				COUNT(DISTINCT CASE WHEN dg.left_over = 1 THEN g.id END)
+			FROM day_goals dg
+			JOIN goals g ON g.id = dg.goal_id
+			JOIN days dy ON dy.id = dg.day_id
+			WHERE g.workspace_id = ? AND g.parent_id IS NULL AND g.status != 'archived' AND dy.date BETWEEN ? AND ?
+			GROUP BY dy.date`, workspaceID, from, to)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var date string
+			var total, completed, carried int
+			if err := rows.Scan(&date, &total, &completed, &carried); err != nil {
+				return err
+			}
+			day := &a.Days[index[date]]
+			day.GoalsTotal, day.GoalsCompleted, day.CarriedOver = total, completed, carried
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		rows, err = d.DB.QueryContext(ctx, `
+			SELECT created_at, completed_at, task_elapsed_seconds
+			FROM goals
+			WHERE workspace_id = ? AND completed_at IS NOT NULL
+				AND date(completed_at, 'localtime') BETWEEN ? AND ?`, workspaceID, from, to)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		var cycle time.Duration
+		for rows.Next() {
+			var created, completed time.Time
+			var taskSeconds int
+			if err := rows.Scan(&created, &completed, &taskSeconds); err != nil {
+				return err
+			}
+			a.TaskTime += time.Duration(taskSeconds) * time.Second
+			if completed.After(created) {
+				cycle += completed.Sub(created)
+				a.CycleSamples++
+			}
+		}
+		if a.CycleSamples > 0 {
+			a.CycleTime = cycle / time.Duration(a.CycleSamples)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return Analytics{}, wrapErr(EntitySprint, "analytics", 0, err)
+	}
+
+	run := 0
+	for i, day := range a.Days {
+		if day.SprintsCompleted > 0 {
+			run++
+		} else if i < len(a.Days)-1 || day.Date != time.Now().Format(util.DateLayout) {
+			// Today does not break the streak until it is over.
+			run = 0
+		}
+		if run > a.LongestStreak {
+			a.LongestStreak = run
+		}
+	}
+	a.CurrentStreak = run
+	return a, nil
+}
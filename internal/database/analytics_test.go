@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+func TestGetAnalytics(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	now := time.Now()
+	past := now.AddDate(0, 0, -2)
+	pastDate, today := past.Format("2006-01-02"), now.Format("2006-01-02")
+
+	pastID, err := db.BackfillDay(ctx, wsID, pastDate, 2)
+	if err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, pastID, wsID)
+	if err != nil || len(sprints) != 2 {
+		t.Fatalf("GetSprints failed: %d (%v)", len(sprints), err)
+	}
+	start := time.Date(past.Year(), past.Month(), past.Day(), 9, 0, 0, 0, time.Local)
+	if err := db.CorrectSprint(ctx, sprints[0].ID, SprintCorrection{Start: start, End: start.Add(time.Hour), Focused: 50 * time.Minute}); err != nil {
+		t.Fatalf("CorrectSprint failed: %v", err)
+	}
+	for _, desc := range []string{"Done", "Carried"} {
+		if err := db.AddGoal(ctx, wsID, desc, sprints[0].ID); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+	}
+	carriedID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	doneID := carriedID - 1
+	if _, err := db.DB.ExecContext(ctx, "UPDATE goals SET created_at = ? WHERE id = ?", start.Add(-24*time.Hour).UTC().Format(sqlTimeLayout), doneID); err != nil {
+		t.Fatalf("backdating goal failed: %v", err)
+	}
+	if err := db.CompleteGoalAt(ctx, doneID, start.Add(30*time.Minute)); err != nil {
+		t.Fatalf("CompleteGoalAt failed: %v", err)
+	}
+	// Completing the sprint sends the unfinished goal to the backlog; it
+	// still counts for the day it was planned on.
+	if err := db.MovePendingToBacklog(ctx, sprints[0].ID); err != nil {
+		t.Fatalf("MovePendingToBacklog failed: %v", err)
+	}
+	if err := db.CarryOverGoal(ctx, carriedID, 0); err != nil {
+		t.Fatalf("CarryOverGoal failed: %v", err)
+	}
+
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	todaySprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(todaySprints) != 1 {
+		t.Fatalf("GetSprints failed: %d (%v)", len(todaySprints), err)
+	}
+	if err := db.StartSprint(ctx, todaySprints[0].ID); err != nil {
+		t.Fatalf("StartSprint failed: %v", err)
+	}
+	if err := db.CompleteSprint(ctx, todaySprints[0].ID); err != nil {
+		t.Fatalf("CompleteSprint failed: %v", err)
+	}
+
+	a, err := db.GetAnalytics(ctx, wsID, pastDate, today)
+	if err != nil {
+		t.Fatalf("GetAnalytics failed: %v", err)
+	}
+	if len(a.Days) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(a.Days))
+	}
+	day := a.Days[0]
+	if day.Focus != 50*time.Minute || day.SprintsPlanned != 2 || day.SprintsCompleted != 1 {
+		t.Fatalf("unexpected sprint totals %+v", day)
+	}
+	if day.GoalsTotal != 2 || day.GoalsCompleted != 1 || day.CompletionRate() != 0.5 || a.CarryOverRate() != 0.5 {
+		t.Fatalf("unexpected goal totals %+v", day)
+	}
+	if a.Days[1].CompletionRate() != -1 {
+		t.Fatalf("expected no completion rate without goals")
+	}
+	if a.CorrectedSprints != 1 || a.CycleSamples != 1 || a.CycleTime != 24*time.Hour+30*time.Minute {
+		t.Fatalf("unexpected cycle time %s over %d goals, %d corrected", a.CycleTime, a.CycleSamples, a.CorrectedSprints)
+	}
+	if a.CurrentStreak != 1 || a.LongestStreak != 1 {
+		t.Fatalf("expected streaks 1/1, got %d/%d", a.CurrentStreak, a.LongestStreak)
+	}
+	if totals := a.Totals(); totals.SprintsPlanned != 3 || totals.SprintsCompleted != 2 {
+		t.Fatalf("unexpected totals %+v", totals)
+	}
+
+	if _, err := db.GetAnalytics(ctx, wsID, today, pastDate); err == nil {
+		t.Fatalf("expected reversed range to be refused")
+	}
+}
+
+func TestGetHeatmap(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	otherID, err := db.CreateWorkspace(ctx, "Other", "other")
+	if err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	yesterday := time.Now().AddDate(0, 0, -1)
+	date, today := yesterday.Format("2006-01-02"), time.Now().Format("2006-01-02")
+	for _, id := range []int64{wsID, otherID} {
+		dayID, err := db.BackfillDay(ctx, id, date, 1)
+		if err != nil {
+			t.Fatalf("BackfillDay failed: %v", err)
+		}
+		sprints, err := db.GetSprints(ctx, dayID, id)
+		if err != nil || len(sprints) != 1 {
+			t.Fatalf("GetSprints failed: %d (%v)", len(sprints), err)
+		}
+		start := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 9, 0, 0, 0, time.Local)
+		if err := db.CorrectSprint(ctx, sprints[0].ID, SprintCorrection{Start: start, End: start.Add(time.Hour)}); err != nil {
+			t.Fatalf("CorrectSprint failed: %v", err)
+		}
+	}
+	if err := db.AddGoal(ctx, wsID, "Done today", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.UpdateGoalStatus(ctx, goalID, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+
+	days, err := db.GetHeatmap(ctx, []int64{wsID, otherID}, date, today)
+	if err != nil {
+		t.Fatalf("GetHeatmap failed: %v", err)
+	}
+	if len(days) != 2 || days[0].Focus != 2*time.Hour || days[1].Completed != 1 {
+		t.Fatalf("unexpected heatmap %+v", days)
+	}
+	days, err = db.GetHeatmap(ctx, []int64{otherID}, date, today)
+	if err != nil || days[0].Focus != time.Hour || days[1].Completed != 0 {
+		t.Fatalf("unexpected heatmap for one workspace %+v (%v)", days, err)
+	}
+	if days, err := db.GetHeatmap(ctx, nil, date, today); err != nil || len(days) != 2 || days[0].Focus != 0 {
+		t.Fatalf("expected empty heatmap without workspaces, got %+v (%v)", days, err)
+	}
+}
+
+func TestGetVelocity(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	yesterday := time.Now().AddDate(0, 0, -1)
+	date := yesterday.Format("2006-01-02")
+	if _, err := db.BackfillDay(ctx, wsID, date, 3); err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	if err := db.AddGoalDetailed(ctx, wsID, 0, GoalSeed{Description: "Large", Effort: "L"}); err != nil {
+		t.Fatalf("AddGoalDetailed failed: %v", err)
+	}
+	parentID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.AddSubtask(ctx, "Part", parentID); err != nil {
+		t.Fatalf("AddSubtask failed: %v", err)
+	}
+	subtaskID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	at := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 12, 0, 0, 0, time.Local)
+	for _, id := range []int64{subtaskID, parentID} {
+		if err := db.CompleteGoalAt(ctx, id, at); err != nil {
+			t.Fatalf("CompleteGoalAt failed: %v", err)
+		}
+	}
+
+	days, err := db.GetVelocity(ctx, wsID, yesterday.AddDate(0, 0, -1).Format("2006-01-02"), date)
+	if err != nil {
+		t.Fatalf("GetVelocity failed: %v", err)
+	}
+	if len(days) != 2 || days[0].Sprints != 0 || days[0].Points != 0 {
+		t.Fatalf("expected an empty day off first, got %+v", days)
+	}
+	if days[1].Sprints != 3 || days[1].Points != 3 {
+		t.Fatalf("expected 3 sprints and 3 points without the subtask, got %+v", days[1])
+	}
+}
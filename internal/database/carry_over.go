@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// GetCarryOverGoals lists the unfinished top-level goals up for review on
+// date: those left in the sprints of the last day before date that had
+// sprints in the workspace, those left over from an earlier sprint and not
+// yet resolved, and those whose deferral date has arrived.
+func (d *Database) GetCarryOverGoals(ctx context.Context, workspaceID int64, date string) ([]models.Goal, error) {
+	previousDay, err := withDBContextResult(d, ctx, func(ctx context.Context) (int64, error) {
+		var id int64
+		err := d.DB.QueryRowContext(ctx, `
+			SELECT d.id FROM days d
+			WHERE d.date < ? AND EXISTS (SELECT 1 FROM sprints s WHERE s.day_id = d.id AND s.workspace_id = ?)
+			ORDER BY d.date DESC LIMIT 1`, date, workspaceID).Scan(&id)
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return id, wrapErr(EntityGoal, "list carry-over", 0, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Leftovers count once the goal is in the backlog or still sits in a
+	// past sprint; goals planned into a sprint since then are resolved.
+	query := fmt.Sprintf(`
+		WITH leftover AS (
+			SELECT c.goal_id, MIN(s.sprint_number) AS sprint_number
+			FROM carry_overs c
+			JOIN sprints s ON s.id = c.sprint_id
+			JOIN days dy ON dy.id = s.day_id
+			WHERE c.reviewed_at IS NULL AND dy.date < ?
+			GROUP BY c.goal_id
+		)
+		SELECT %s FROM (
+			SELECT g.*, CASE
+				WHEN s.day_id = ? THEN s.sprint_number
+				WHEN l.goal_id IS NOT NULL THEN l.sprint_number
+				ELSE 99 END AS carry_order
+			FROM goals g
+			LEFT JOIN sprints s ON s.id = g.sprint_id
+			LEFT JOIN days sd ON sd.id = s.day_id
+			LEFT JOIN leftover l ON l.goal_id = g.id
+			WHERE g.workspace_id = ? AND g.parent_id IS NULL
+			  AND g.status NOT IN ('completed', 'archived')
+			  AND (s.day_id = ?
+				OR (l.goal_id IS NOT NULL AND (g.sprint_id IS NULL OR sd.date < ?))
+				OR (g.deferred_until IS NOT NULL AND g.deferred_until <= ?))
+		)
+		ORDER BY carry_order ASC, rank ASC, id ASC`, goalColumnsWithSprint)
+	return d.queryGoals(ctx, "list carry-over", query, date, previousDay, workspaceID, previousDay, date, date)
+}
+
+// SkipCarryOver leaves a goal unresolved so the review offers it again, even
+// once the day it was left on is no longer the previous one.
+func (d *Database) SkipCarryOver(ctx context.Context, goalID int64) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, `
+			INSERT OR IGNORE INTO carry_overs (goal_id, sprint_id)
+			SELECT id, sprint_id FROM goals WHERE id = ? AND sprint_id IS NOT NULL`, goalID)
+		return wrapErr(EntityGoal, "skip carry-over", goalID, err)
+	})
+}
+
+// CarryOverGoal moves an unfinished goal and its subtasks to a sprint
+// (0 = backlog), clears any deferral and counts the carry-over.
+func (d *Database) CarryOverGoal(ctx context.Context, goalID int64, sprintID int64) error {
+	return wrapErr(EntityGoal, "carry over", goalID, d.carryOver(ctx, goalID, nullableInt64(sprintID), nil))
+}
+
+// DeferGoal sends an unfinished goal and its subtasks to the backlog until
+// date (YYYY-MM-DD), when it is offered for review again, and counts the
+// carry-over.
+func (d *Database) DeferGoal(ctx context.Context, goalID int64, date string) error {
+	if _, err := time.Parse(util.DateLayout, date); err != nil {
+		return wrapErr(EntityGoal, "defer", goalID, fmt.Errorf("invalid date %q", date))
+	}
+	return wrapErr(EntityGoal, "defer", goalID, d.carryOver(ctx, goalID, nil, date))
+}
+
+func (d *Database) carryOver(ctx context.Context, goalID int64, sprintArg interface{}, deferredUntil interface{}) error {
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		// A goal still in a past sprint leaves it unfinished here.
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO carry_overs (goal_id, sprint_id)
+			SELECT id, sprint_id FROM goals WHERE id = ? AND sprint_id IS NOT NULL`, goalID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			WITH RECURSIVE tree(id) AS (
+				SELECT id FROM goals WHERE id = ?
+				UNION ALL
+				SELECT g.id FROM goals g JOIN tree t ON g.parent_id = t.id
+			)
+			UPDATE goals SET sprint_id = ? WHERE id IN (SELECT id FROM tree)`, goalID, sprintArg); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE goals SET carry_over_count = COALESCE(carry_over_count, 0) + 1, deferred_until = ? WHERE id = ?",
+			deferredUntil, goalID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"UPDATE carry_overs SET reviewed_at = CURRENT_TIMESTAMP WHERE goal_id = ? AND reviewed_at IS NULL", goalID)
+		return err
+	})
+}
+
+// GetChronicCarryOvers lists unfinished goals in the workspace that were
+// carried over at least minCount times, most carried first.
+func (d *Database) GetChronicCarryOvers(ctx context.Context, workspaceID int64, minCount int) ([]models.Goal, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM goals
+		WHERE workspace_id = ? AND carry_over_count >= ? AND status NOT IN ('completed', 'archived')
+		ORDER BY carry_over_count DESC, id ASC`, goalColumnsWithSprint)
+	return d.queryGoals(ctx, "list chronic carry-over", query, workspaceID, minCount)
+}
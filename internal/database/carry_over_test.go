@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+func TestCarryOverGoals(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	res, err := db.DB.ExecContext(ctx, "INSERT INTO days (date) VALUES ('2026-10-15')")
+	if err != nil {
+		t.Fatalf("insert day failed: %v", err)
+	}
+	dayID, _ := res.LastInsertId()
+	res, err = db.DB.ExecContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, 1)", dayID, wsID)
+	if err != nil {
+		t.Fatalf("insert sprint failed: %v", err)
+	}
+	sprintID, _ := res.LastInsertId()
+
+	addGoal := func(desc string) int64 {
+		t.Helper()
+		if err := db.AddGoal(ctx, wsID, desc, sprintID); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+		id, err := db.GetLastGoalID(ctx)
+		if err != nil {
+			t.Fatalf("GetLastGoalID failed: %v", err)
+		}
+		return id
+	}
+	openID := addGoal("Unfinished")
+	doneID := addGoal("Finished")
+	laterID := addGoal("Later")
+	if err := db.UpdateGoalStatus(ctx, doneID, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if err := db.AddSubtask(ctx, "Step", openID); err != nil {
+		t.Fatalf("AddSubtask failed: %v", err)
+	}
+	subID, _ := db.GetLastGoalID(ctx)
+
+	goals, err := db.GetCarryOverGoals(ctx, wsID, "2026-10-16")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 2 || goals[0].ID != openID || goals[1].ID != laterID {
+		t.Fatalf("unexpected carry-over goals: %+v", goals)
+	}
+
+	if err := db.CarryOverGoal(ctx, openID, 0); err != nil {
+		t.Fatalf("CarryOverGoal failed: %v", err)
+	}
+	moved, err := db.GetGoalByID(ctx, openID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if moved.SprintID != nil || moved.CarryOverCount != 1 {
+		t.Fatalf("expected goal in backlog with count 1, got %+v", moved)
+	}
+	sub, err := db.GetGoalByID(ctx, subID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if sub.SprintID != nil {
+		t.Fatalf("expected subtask to move with its parent, got sprint %v", *sub.SprintID)
+	}
+
+	if err := db.DeferGoal(ctx, laterID, "not-a-date"); err == nil {
+		t.Fatalf("expected invalid defer date to fail")
+	}
+	if err := db.DeferGoal(ctx, laterID, "2026-10-20"); err != nil {
+		t.Fatalf("DeferGoal failed: %v", err)
+	}
+	goals, err = db.GetCarryOverGoals(ctx, wsID, "2026-10-19")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 0 {
+		t.Fatalf("expected nothing before the deferral date, got %+v", goals)
+	}
+	goals, err = db.GetCarryOverGoals(ctx, wsID, "2026-10-20")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 1 || goals[0].ID != laterID || goals[0].DeferredUntil == nil {
+		t.Fatalf("expected deferred goal to come back, got %+v", goals)
+	}
+
+	if err := db.CarryOverGoal(ctx, laterID, 0); err != nil {
+		t.Fatalf("CarryOverGoal failed: %v", err)
+	}
+	chronic, err := db.GetChronicCarryOvers(ctx, wsID, 2)
+	if err != nil {
+		t.Fatalf("GetChronicCarryOvers failed: %v", err)
+	}
+	if len(chronic) != 1 || chronic[0].ID != laterID || chronic[0].CarryOverCount != 2 || chronic[0].DeferredUntil != nil {
+		t.Fatalf("unexpected chronic carry-overs: %+v", chronic)
+	}
+}
+
+func TestCarryOverIncludesGoalsFromCompletedSprints(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	addSprint := func(date string) int64 {
+		t.Helper()
+		res, err := db.DB.ExecContext(ctx, "INSERT INTO days (date) VALUES (?)", date)
+		if err != nil {
+			t.Fatalf("insert day failed: %v", err)
+		}
+		dayID, _ := res.LastInsertId()
+		res, err = db.DB.ExecContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, 1)", dayID, wsID)
+		if err != nil {
+			t.Fatalf("insert sprint failed: %v", err)
+		}
+		id, _ := res.LastInsertId()
+		return id
+	}
+	addGoal := func(desc string, sprintID int64) int64 {
+		t.Helper()
+		if err := db.AddGoal(ctx, wsID, desc, sprintID); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+		id, err := db.GetLastGoalID(ctx)
+		if err != nil {
+			t.Fatalf("GetLastGoalID failed: %v", err)
+		}
+		return id
+	}
+	completed := addSprint("2026-10-15")
+	skippedID := addGoal("Skipped", completed)
+	movedID := addGoal("Moved", completed)
+	if err := db.CompleteSprint(ctx, completed); err != nil {
+		t.Fatalf("CompleteSprint failed: %v", err)
+	}
+	if err := db.MovePendingToBacklog(ctx, completed); err != nil {
+		t.Fatalf("MovePendingToBacklog failed: %v", err)
+	}
+	open := addSprint("2026-10-16")
+	lingeringID := addGoal("Lingering", open)
+
+	goals, err := db.GetCarryOverGoals(ctx, wsID, "2026-10-16")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 2 || goals[0].ID != skippedID || goals[1].ID != movedID {
+		t.Fatalf("expected goals from the completed sprint, got %+v", goals)
+	}
+	if err := db.SkipCarryOver(ctx, skippedID); err != nil {
+		t.Fatalf("SkipCarryOver failed: %v", err)
+	}
+	if err := db.CarryOverGoal(ctx, movedID, 0); err != nil {
+		t.Fatalf("CarryOverGoal failed: %v", err)
+	}
+
+	goals, err = db.GetCarryOverGoals(ctx, wsID, "2026-10-17")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 2 || goals[0].ID != skippedID || goals[1].ID != lingeringID {
+		t.Fatalf("expected skipped and lingering goals, got %+v", goals)
+	}
+	if err := db.SkipCarryOver(ctx, lingeringID); err != nil {
+		t.Fatalf("SkipCarryOver failed: %v", err)
+	}
+	addSprint("2026-10-17")
+
+	goals, err = db.GetCarryOverGoals(ctx, wsID, "2026-10-18")
+	if err != nil {
+		t.Fatalf("GetCarryOverGoals failed: %v", err)
+	}
+	if len(goals) != 2 || goals[0].ID != skippedID || goals[1].ID != lingeringID {
+		t.Fatalf("expected skipped goals to be offered again, got %+v", goals)
+	}
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// sqlTimeLayout matches the UTC timestamps written by CURRENT_TIMESTAMP.
+const sqlTimeLayout = "2006-01-02 15:04:05"
+
+// Correction is a manual change to past data. Sprints and goals carry a
+// record of every field that was backfilled or corrected, so analytics can
+// tell entered values from tracked ones.
+type Correction struct {
+	ID        int64
+	Entity    string
+	EntityID  int64
+	Field     string
+	OldValue  string
+	NewValue  string
+	CreatedAt time.Time
+}
+
+// SprintCorrection holds the times of a sprint entered after the fact.
+// A zero Focused means the whole span from Start to End.
+type SprintCorrection struct {
+	Start   time.Time
+	End     time.Time
+	Focused time.Duration
+}
+
+func recordCorrection(ctx context.Context, tx *sql.Tx, entity string, id int64, field, oldValue, newValue string) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO corrections (entity, entity_id, field, old_value, new_value) VALUES (?, ?, ?, ?, ?)",
+		entity, id, field, nullableString(oldValue), nullableString(newValue))
+	return err
+}
+
+func formatCorrectionTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(sqlTimeLayout)
+}
+
+// BackfillDay records a past day (YYYY-MM-DD) with numSprints sprints for
+// the workspace. Sprints that already exist are kept.
+func (d *Database) BackfillDay(ctx context.Context, workspaceID int64, date string, numSprints int) (int64, error) {
+	if _, err := time.Parse(util.DateLayout, date); err != nil {
+		return 0, wrapErr(EntityDay, "backfill", 0, err)
+	}
+	if date >= time.Now().Format(util.DateLayout) {
+		return 0, wrapErr(EntityDay, "backfill", 0, fmt.Errorf("%s is not in the past", date))
+	}
+	if numSprints < 1 || numSprints > maxSprintsPerDay {
+		return 0, wrapErr(EntityDay, "backfill", 0, fmt.Errorf("sprint count must be between 1 and %d", maxSprintsPerDay))
+	}
+	var dayID int64
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		var before int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(1) FROM sprints s JOIN days dy ON dy.id = s.day_id
+			WHERE dy.date = ? AND s.workspace_id = ?`, date, workspaceID).Scan(&before); err != nil {
+			return err
+		}
+		var err error
+		dayID, err = ensureDayTx(ctx, tx, workspaceID, date, numSprints, false)
+		if err != nil || numSprints <= before {
+			return err
+		}
+		return recordCorrection(ctx, tx, EntityDay, dayID, "sprints", strconv.Itoa(before), strconv.Itoa(numSprints))
+	})
+	return dayID, wrapErr(EntityDay, "backfill", dayID, err)
+}
+
+// CorrectSprint sets the start, end and focused time of a sprint that is not
+// running and marks it completed.
+func (d *Database) CorrectSprint(ctx context.Context, sprintID int64, c SprintCorrection) error {
+	span := c.End.Sub(c.Start)
+	if span <= 0 {
+		return wrapErr(EntitySprint, "correct", sprintID, fmt.Errorf("end must be after start"))
+	}
+	if c.End.After(time.Now()) {
+		return wrapErr(EntitySprint, "correct", sprintID, fmt.Errorf("end is in the future"))
+	}
+	focused := c.Focused
+	if focused == 0 {
+		focused = span
+	}
+	if focused < 0 || focused > span {
+		return wrapErr(EntitySprint, "correct", sprintID, fmt.Errorf("focused time must be between 0 and %s", span))
+	}
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		var status string
+		var start, end *time.Time
+		var elapsed int
+		if err := tx.QueryRowContext(ctx,
+			"SELECT status, start_time, end_time, elapsed_seconds FROM sprints WHERE id = ?", sprintID,
+		).Scan(&status, &start, &end, &elapsed); err != nil {
+			return err
+		}
+		if status == string(models.StatusActive) || status == string(models.StatusPaused) {
+			return fmt.Errorf("stop the running sprint first")
+		}
+		newStart, newEnd := c.Start.UTC().Format(sqlTimeLayout), c.End.UTC().Format(sqlTimeLayout)
+		seconds := int(focused / time.Second)
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE sprints
+			SET status = 'completed', start_time = ?, end_time = ?, elapsed_seconds = ?, last_paused_at = NULL, corrected = 1
+			WHERE id = ?`, newStart, newEnd, seconds, sprintID); err != nil {
+			return err
+		}
+		for _, f := range []struct{ field, old, new string }{
+			{"status", status, string(models.StatusCompleted)},
+			{"start_time", formatCorrectionTime(start), newStart},
+			{"end_time", formatCorrectionTime(end), newEnd},
+			{"elapsed_seconds", strconv.Itoa(elapsed), strconv.Itoa(seconds)},
+		} {
+			if f.old == f.new {
+				continue
+			}
+			if err := recordCorrection(ctx, tx, EntitySprint, sprintID, f.field, f.old, f.new); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return wrapErr(EntitySprint, "correct", sprintID, err)
+}
+
+// CompleteGoalAt marks a goal completed at a past time. Like completing it
+// now, this is refused while a dependency or, for an open goal, a subtask is
+// unfinished.
+func (d *Database) CompleteGoalAt(ctx context.Context, goalID int64, at time.Time) error {
+	if at.After(time.Now()) {
+		return wrapErr(EntityGoal, "complete at", goalID, fmt.Errorf("completion time is in the future"))
+	}
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		var active, deps, subtasks int
+		var status string
+		if err := d.DB.QueryRowContext(ctx, `
+			SELECT g.task_active, g.status,
+				(SELECT COUNT(1) `+unfinishedDepsSQL+`),
+				(SELECT COUNT(1) FROM goals s WHERE s.parent_id = g.id AND s.status != 'completed')
+			FROM goals g WHERE g.id = ?`, goalID).Scan(&active, &status, &deps, &subtasks); err != nil {
+			return wrapErr(EntityGoal, "complete at", goalID, err)
+		}
+		if deps > 0 {
+			return wrapErr(EntityGoal, "complete at", goalID, fmt.Errorf("blocked by unfinished dependencies"))
+		}
+		if status != string(models.GoalStatusCompleted) && subtasks > 0 {
+			return wrapErr(EntityGoal, "complete at", goalID, fmt.Errorf("subtasks are still pending"))
+		}
+		if active == 1 {
+			if err := d.PauseTaskTimer(ctx, goalID); err != nil {
+				return wrapErr(EntityGoal, "complete at", goalID, err)
+			}
+		}
+		err := d.WithTx(ctx, func(tx *sql.Tx) error {
+			var status string
+			var completedAt *time.Time
+			if err := tx.QueryRowContext(ctx, "SELECT status, completed_at FROM goals WHERE id = ?", goalID).Scan(&status, &completedAt); err != nil {
+				return err
+			}
+			newAt := at.UTC().Format(sqlTimeLayout)
+			if _, err := tx.ExecContext(ctx, "UPDATE goals SET status = 'completed', completed_at = ? WHERE id = ?", newAt, goalID); err != nil {
+				return err
+			}
+			if status != string(models.GoalStatusCompleted) {
+				if err := recordCorrection(ctx, tx, EntityGoal, goalID, "status", status, string(models.GoalStatusCompleted)); err != nil {
+					return err
+				}
+			}
+			if err := recordCorrection(ctx, tx, EntityGoal, goalID, "completed_at", formatCorrectionTime(completedAt), newAt); err != nil {
+				return err
+			}
+			return syncBlockedStatus(ctx, tx, goalID, UnblockedByCompletion)
+		})
+		return wrapErr(EntityGoal, "complete at", goalID, err)
+	})
+}
+
+// GetCorrections returns the corrections recorded for an entity, oldest
+// first.
+func (d *Database) GetCorrections(ctx context.Context, entity string, entityID int64) ([]Correction, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]Correction, error) {
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT id, entity, entity_id, field, COALESCE(old_value, ''), COALESCE(new_value, ''), created_at
+			FROM corrections
+			WHERE entity = ? AND entity_id = ?
+			ORDER BY id ASC`, entity, entityID)
+		if err != nil {
+			return nil, wrapErr(entity, "list corrections", entityID, err)
+		}
+		defer rows.Close()
+		var out []Correction
+		for rows.Next() {
+			var c Correction
+			if err := rows.Scan(&c.ID, &c.Entity, &c.EntityID, &c.Field, &c.OldValue, &c.NewValue, &c.CreatedAt); err != nil {
+				return nil, wrapErr(entity, "list corrections", entityID, err)
+			}
+			out = append(out, c)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapErr(entity, "list corrections", entityID, err)
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+func TestBackfillDay(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	yesterday := time.Now().AddDate(0, 0, -1).Format(util.DateLayout)
+
+	if _, err := db.BackfillDay(ctx, wsID, time.Now().Format(util.DateLayout), 2); err == nil {
+		t.Fatalf("expected today to be refused")
+	}
+	if _, err := db.BackfillDay(ctx, wsID, yesterday, 0); err == nil {
+		t.Fatalf("expected sprint count to be validated")
+	}
+	dayID, err := db.BackfillDay(ctx, wsID, yesterday, 2)
+	if err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	if _, err := db.BackfillDay(ctx, wsID, yesterday, 1); err != nil {
+		t.Fatalf("BackfillDay with fewer sprints failed: %v", err)
+	}
+	if sprints, err := db.GetSprints(ctx, dayID, wsID); err != nil || len(sprints) != 2 {
+		t.Fatalf("expected 2 backfilled sprints, got %d (%v)", len(sprints), err)
+	}
+	if day, err := db.GetDay(ctx, dayID); err != nil || day.Planned || day.Date != yesterday {
+		t.Fatalf("unexpected backfilled day %+v (%v)", day, err)
+	}
+	corrections, err := db.GetCorrections(ctx, EntityDay, dayID)
+	if err != nil {
+		t.Fatalf("GetCorrections failed: %v", err)
+	}
+	if len(corrections) != 1 || corrections[0].Field != "sprints" || corrections[0].OldValue != "0" || corrections[0].NewValue != "2" {
+		t.Fatalf("unexpected corrections %+v", corrections)
+	}
+
+	// A day whose correction cannot be recorded is not created either.
+	if _, err := db.DB.ExecContext(ctx, `CREATE TRIGGER refuse_correction BEFORE INSERT ON corrections
+		BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	earlier := time.Now().AddDate(0, 0, -2).Format(util.DateLayout)
+	if _, err := db.BackfillDay(ctx, wsID, earlier, 1); err == nil {
+		t.Fatalf("expected backfill to fail")
+	}
+	var days int
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(1) FROM days WHERE date = ?", earlier).Scan(&days); err != nil {
+		t.Fatalf("count days failed: %v", err)
+	}
+	if days != 0 {
+		t.Fatalf("expected no day left behind, got %d", days)
+	}
+}
+
+func TestCorrectSprintAndCompleteGoalAt(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	yesterday := time.Now().AddDate(0, 0, -1)
+	dayID, err := db.BackfillDay(ctx, wsID, yesterday.Format(util.DateLayout), 1)
+	if err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, dayID, wsID)
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("GetSprints failed: %d (%v)", len(sprints), err)
+	}
+	sprintID := sprints[0].ID
+
+	start := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 9, 0, 0, 0, time.Local)
+	if err := db.CorrectSprint(ctx, sprintID, SprintCorrection{Start: start, End: start.Add(-time.Minute)}); err == nil {
+		t.Fatalf("expected end before start to be refused")
+	}
+	if err := db.CorrectSprint(ctx, sprintID, SprintCorrection{Start: start, End: start.Add(time.Hour), Focused: 2 * time.Hour}); err == nil {
+		t.Fatalf("expected focused time longer than the sprint to be refused")
+	}
+	if err := db.CorrectSprint(ctx, sprintID, SprintCorrection{Start: start, End: start.Add(time.Hour), Focused: 50 * time.Minute}); err != nil {
+		t.Fatalf("CorrectSprint failed: %v", err)
+	}
+	sprints, err = db.GetSprints(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	s := sprints[0]
+	if s.Status != models.StatusCompleted || !s.Corrected || s.StartTime == nil || !s.StartTime.Equal(start) {
+		t.Fatalf("unexpected corrected sprint %+v", s)
+	}
+	if got := s.FocusedDuration(time.Now()); got != 50*time.Minute {
+		t.Fatalf("expected 50m focused, got %s", got)
+	}
+	corrections, err := db.GetCorrections(ctx, EntitySprint, sprintID)
+	if err != nil {
+		t.Fatalf("GetCorrections failed: %v", err)
+	}
+	fields := make(map[string]Correction)
+	for _, c := range corrections {
+		fields[c.Field] = c
+	}
+	if fields["status"].OldValue != "pending" || fields["elapsed_seconds"].NewValue != "3000" || fields["start_time"].OldValue != "" {
+		t.Fatalf("unexpected sprint corrections %+v", corrections)
+	}
+
+	if err := db.AddGoal(ctx, wsID, "Forgot to tick", sprintID); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.CompleteGoalAt(ctx, goalID, time.Now().Add(time.Hour)); err == nil {
+		t.Fatalf("expected future completion to be refused")
+	}
+	doneAt := start.Add(30 * time.Minute)
+	if err := db.CompleteGoalAt(ctx, goalID, doneAt); err != nil {
+		t.Fatalf("CompleteGoalAt failed: %v", err)
+	}
+	goal, err := db.GetGoalByID(ctx, goalID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Status != models.GoalStatusCompleted || goal.CompletedAt == nil || !goal.CompletedAt.Equal(doneAt) {
+		t.Fatalf("unexpected completed goal %+v", goal)
+	}
+	corrections, err = db.GetCorrections(ctx, EntityGoal, goalID)
+	if err != nil || len(corrections) != 2 {
+		t.Fatalf("expected status and completed_at corrections, got %+v (%v)", corrections, err)
+	}
+}
+
+func TestCorrectSprintRefusesRunningSprint(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("GetSprints failed: %d (%v)", len(sprints), err)
+	}
+	if err := db.StartSprint(ctx, sprints[0].ID); err != nil {
+		t.Fatalf("StartSprint failed: %v", err)
+	}
+	end := time.Now().Add(-time.Minute)
+	if err := db.CorrectSprint(ctx, sprints[0].ID, SprintCorrection{Start: end.Add(-time.Hour), End: end}); err == nil {
+		t.Fatalf("expected running sprint to be refused")
+	}
+	if corrections, err := db.GetCorrections(ctx, EntitySprint, sprints[0].ID); err != nil || len(corrections) != 0 {
+		t.Fatalf("expected no corrections, got %+v (%v)", corrections, err)
+	}
+}
+
+func TestCompleteGoalAtRefusesBlockedAndPendingSubtasks(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("GetSprints failed: %d (%v)", len(sprints), err)
+	}
+	sprintID := sprints[0].ID
+	goalA := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal A")
+	goalB := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal B")
+	if err := db.SetGoalDependencies(ctx, goalB, []int64{goalA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	if err := db.AddSubtask(ctx, "Step", goalA); err != nil {
+		t.Fatalf("AddSubtask failed: %v", err)
+	}
+	at := time.Now().Add(-time.Hour)
+
+	if err := db.CompleteGoalAt(ctx, goalB, at); err == nil {
+		t.Fatalf("expected blocked goal to be refused")
+	}
+	if err := db.CompleteGoalAt(ctx, goalA, at); err == nil {
+		t.Fatalf("expected goal with pending subtasks to be refused")
+	}
+	for _, id := range []int64{goalA, goalB} {
+		if corrections, err := db.GetCorrections(ctx, EntityGoal, id); err != nil || len(corrections) != 0 {
+			t.Fatalf("expected no corrections for goal %d, got %+v (%v)", id, corrections, err)
+		}
+	}
+}
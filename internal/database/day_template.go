@@ -0,0 +1,257 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// maxSprintsPerDay is the most sprints a day can hold.
+const maxSprintsPerDay = 8
+
+// DaySprintPlan describes how one sprint of a day template is prepared.
+type DaySprintPlan struct {
+	Number    int            `json:"number"`
+	Label     string         `json:"label,omitempty"`
+	Templates []string       `json:"templates,omitempty"` // goal template names
+	Goals     []TemplateGoal `json:"goals,omitempty"`
+}
+
+// DayTemplate is a reusable plan for a day: how many sprints to create,
+// their labels and the goals they start with. Weekdays ("mon".."sun") select
+// the template for matching days; Auto applies it without asking.
+type DayTemplate struct {
+	ID       int64           `json:"-"`
+	Name     string          `json:"name"`
+	Weekdays []string        `json:"weekdays,omitempty"`
+	Sprints  int             `json:"sprints"`
+	Auto     bool            `json:"auto,omitempty"`
+	Plans    []DaySprintPlan `json:"sprint_plans,omitempty"`
+}
+
+// Matches reports whether the template is keyed to the weekday of day.
+func (t DayTemplate) Matches(day time.Time) bool {
+	for _, name := range t.Weekdays {
+		if wd, ok := util.ParseWeekday(name); ok && wd == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the sprint count and that every plan targets a sprint the
+// template creates.
+func (t DayTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("day template name required")
+	}
+	if t.Sprints < 1 || t.Sprints > maxSprintsPerDay {
+		return fmt.Errorf("day template %q: sprints must be between 1 and %d", t.Name, maxSprintsPerDay)
+	}
+	for _, name := range t.Weekdays {
+		if _, ok := util.ParseWeekday(name); !ok {
+			return fmt.Errorf("day template %q: unknown weekday %q", t.Name, name)
+		}
+	}
+	for _, plan := range t.Plans {
+		if plan.Number < 1 || plan.Number > t.Sprints {
+			return fmt.Errorf("day template %q: sprint %d is outside 1-%d", t.Name, plan.Number, t.Sprints)
+		}
+	}
+	return nil
+}
+
+// SaveDayTemplate creates or replaces the day template with the same name.
+func (d *Database) SaveDayTemplate(ctx context.Context, tpl DayTemplate) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		tpl.Name = strings.TrimSpace(tpl.Name)
+		if err := tpl.Validate(); err != nil {
+			return wrapErr(EntityTemplate, "save day", 0, err)
+		}
+		for i, name := range tpl.Weekdays {
+			tpl.Weekdays[i] = strings.ToLower(strings.TrimSpace(name))
+		}
+		payload, err := json.Marshal(tpl)
+		if err != nil {
+			return wrapErr(EntityTemplate, "save day", 0, err)
+		}
+		_, err = d.DB.ExecContext(ctx, `INSERT INTO day_templates (name, payload) VALUES (?, ?)
+			ON CONFLICT(name) DO UPDATE SET payload = excluded.payload`, tpl.Name, string(payload))
+		return wrapErr(EntityTemplate, "save day", 0, err)
+	})
+}
+
+// GetDayTemplates lists all day templates ordered by name.
+func (d *Database) GetDayTemplates(ctx context.Context) ([]DayTemplate, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]DayTemplate, error) {
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, name, payload FROM day_templates ORDER BY name COLLATE NOCASE ASC")
+		if err != nil {
+			return nil, wrapErr(EntityTemplate, "list day", 0, err)
+		}
+		defer rows.Close()
+		var out []DayTemplate
+		for rows.Next() {
+			tpl, err := scanDayTemplate(rows)
+			if err != nil {
+				return nil, wrapErr(EntityTemplate, "list day", 0, err)
+			}
+			out = append(out, tpl)
+		}
+		return out, wrapErr(EntityTemplate, "list day", 0, rows.Err())
+	})
+}
+
+// GetDayTemplate looks up a day template by name, case-insensitively.
+func (d *Database) GetDayTemplate(ctx context.Context, name string) (DayTemplate, bool, error) {
+	type templateResult struct {
+		tpl DayTemplate
+		ok  bool
+	}
+	result, err := withDBContextResult(d, ctx, func(ctx context.Context) (templateResult, error) {
+		row := d.DB.QueryRowContext(ctx, "SELECT id, name, payload FROM day_templates WHERE name = ? COLLATE NOCASE", strings.TrimSpace(name))
+		tpl, err := scanDayTemplate(row)
+		if err == sql.ErrNoRows {
+			return templateResult{}, nil
+		}
+		if err != nil {
+			return templateResult{}, wrapErr(EntityTemplate, "get day", 0, err)
+		}
+		return templateResult{tpl: tpl, ok: true}, nil
+	})
+	return result.tpl, result.ok, err
+}
+
+// DeleteDayTemplate removes a day template by name.
+func (d *Database) DeleteDayTemplate(ctx context.Context, name string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "DELETE FROM day_templates WHERE name = ? COLLATE NOCASE", strings.TrimSpace(name))
+		return wrapErr(EntityTemplate, "delete day", 0, err)
+	})
+}
+
+// DayTemplateFor returns the first day template, by name, keyed to the
+// weekday of day.
+func (d *Database) DayTemplateFor(ctx context.Context, day time.Time) (DayTemplate, bool, error) {
+	templates, err := d.GetDayTemplates(ctx)
+	if err != nil {
+		return DayTemplate{}, false, err
+	}
+	for _, tpl := range templates {
+		if tpl.Matches(day) {
+			return tpl, true, nil
+		}
+	}
+	return DayTemplate{}, false, nil
+}
+
+// ApplyDayTemplate bootstraps today for the workspace with the template's
+// sprints, then labels them and fills them with the planned goals, all in one
+// transaction. Goal templates that no longer exist are logged and skipped.
+func (d *Database) ApplyDayTemplate(ctx context.Context, tpl DayTemplate, workspaceID int64) error {
+	if err := tpl.Validate(); err != nil {
+		return wrapErr(EntityTemplate, "apply day", tpl.ID, err)
+	}
+	goalTemplates := make(map[string]GoalTemplate)
+	for _, plan := range tpl.Plans {
+		for _, name := range plan.Templates {
+			goalTpl, found, err := d.GetGoalTemplate(ctx, name)
+			if err != nil {
+				return wrapErr(EntityTemplate, "apply day", tpl.ID, err)
+			}
+			if !found {
+				util.LogError("apply day template", fmt.Errorf("goal template %q not found", name))
+				continue
+			}
+			goalTemplates[name] = goalTpl
+		}
+	}
+
+	now := time.Now()
+	date := now.Format(util.DateLayout)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		dayID, err := ensureDayTx(ctx, tx, workspaceID, date, tpl.Sprints, false)
+		if err != nil {
+			return err
+		}
+		if len(tpl.Plans) == 0 {
+			return nil
+		}
+		sprintIDs, err := sprintIDsByNumberTx(ctx, tx, dayID, workspaceID)
+		if err != nil {
+			return err
+		}
+		for _, plan := range tpl.Plans {
+			sprintID, ok := sprintIDs[plan.Number]
+			if !ok {
+				continue
+			}
+			if strings.TrimSpace(plan.Label) != "" {
+				if _, err := tx.ExecContext(ctx, "UPDATE sprints SET label = ? WHERE id = ?", plan.Label, sprintID); err != nil {
+					return err
+				}
+			}
+			for _, name := range plan.Templates {
+				goalTpl, found := goalTemplates[name]
+				if !found {
+					continue
+				}
+				if _, err := instantiateGoalTemplateTx(ctx, tx, goalTpl, workspaceID, sprintID, now); err != nil {
+					return err
+				}
+			}
+			for _, goal := range plan.Goals {
+				if _, err := instantiateGoalTemplateTx(ctx, tx, GoalTemplate{Goal: goal}, workspaceID, sprintID, now); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return wrapErr(EntityTemplate, "apply day", tpl.ID, err)
+	}
+	if _, err := d.MaterializeRecurringGoals(ctx, date); err != nil {
+		util.LogError("materialize recurring goals", err)
+	}
+	return nil
+}
+
+// sprintIDsByNumberTx maps the sprint numbers of a day's workspace sprints to
+// their IDs.
+func sprintIDsByNumberTx(ctx context.Context, tx *sql.Tx, dayID, workspaceID int64) (map[int]int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, sprint_number FROM sprints WHERE day_id = ? AND workspace_id = ?", dayID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make(map[int]int64)
+	for rows.Next() {
+		var id int64
+		var number int
+		if err := rows.Scan(&id, &number); err != nil {
+			return nil, err
+		}
+		ids[number] = id
+	}
+	return ids, rows.Err()
+}
+
+func scanDayTemplate(row interface{ Scan(...interface{}) error }) (DayTemplate, error) {
+	var tpl DayTemplate
+	var id int64
+	var name, payload string
+	if err := row.Scan(&id, &name, &payload); err != nil {
+		return DayTemplate{}, err
+	}
+	if err := json.Unmarshal([]byte(payload), &tpl); err != nil {
+		return DayTemplate{}, fmt.Errorf("day template %q: %w", name, err)
+	}
+	tpl.ID = id
+	tpl.Name = name
+	return tpl, nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDayTemplateValidateAndMatch(t *testing.T) {
+	tpl := DayTemplate{Name: "Monday", Weekdays: []string{"mon"}, Sprints: 4, Plans: []DaySprintPlan{{Number: 4, Label: "Admin"}}}
+	if err := tpl.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	monday := time.Date(2026, time.October, 19, 9, 0, 0, 0, time.Local)
+	if !tpl.Matches(monday) || tpl.Matches(monday.AddDate(0, 0, 1)) {
+		t.Fatalf("expected template to match Mondays only")
+	}
+	for _, bad := range []DayTemplate{
+		{Name: "Zero", Sprints: 0},
+		{Name: "Many", Sprints: 9},
+		{Name: "Weekday", Sprints: 2, Weekdays: []string{"funday"}},
+		{Name: "Plan", Sprints: 2, Plans: []DaySprintPlan{{Number: 3}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("expected %q to be invalid", bad.Name)
+		}
+	}
+}
+
+func TestApplyDayTemplate(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	review := TemplateGoal{
+		GoalSeed: GoalSeed{Description: "Plan {{date}}"},
+		Subtasks: []TemplateGoal{{GoalSeed: GoalSeed{Description: "Check calendar"}}},
+	}
+	if err := db.SaveGoalTemplate(ctx, "Planning", review); err != nil {
+		t.Fatalf("SaveGoalTemplate failed: %v", err)
+	}
+	today := strings.ToLower(time.Now().Weekday().String()[:3])
+	tpl := DayTemplate{
+		Name:     "Today",
+		Weekdays: []string{today},
+		Sprints:  3,
+		Plans: []DaySprintPlan{
+			{Number: 1, Label: "Planning", Templates: []string{"Planning", "Missing"}},
+			{Number: 3, Label: "Admin", Goals: []TemplateGoal{{GoalSeed: GoalSeed{Description: "Expenses"}}}},
+		},
+	}
+	if err := db.SaveDayTemplate(ctx, tpl); err != nil {
+		t.Fatalf("SaveDayTemplate failed: %v", err)
+	}
+	found, ok, err := db.DayTemplateFor(ctx, time.Now())
+	if err != nil || !ok || found.Name != "Today" || len(found.Plans) != 2 {
+		t.Fatalf("DayTemplateFor = %+v, %v, %v", found, ok, err)
+	}
+	if err := db.ApplyDayTemplate(ctx, found, wsID); err != nil {
+		t.Fatalf("ApplyDayTemplate failed: %v", err)
+	}
+
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	if len(sprints) != 3 {
+		t.Fatalf("expected 3 sprints, got %d", len(sprints))
+	}
+	if sprints[0].Label == nil || *sprints[0].Label != "Planning" || sprints[1].Label != nil || sprints[2].Label == nil || *sprints[2].Label != "Admin" {
+		t.Fatalf("unexpected labels: %v %v %v", sprints[0].Label, sprints[1].Label, sprints[2].Label)
+	}
+	first, err := db.GetGoalsForSprint(ctx, sprints[0].ID)
+	if err != nil {
+		t.Fatalf("GetGoalsForSprint failed: %v", err)
+	}
+	planned := false
+	for _, g := range first {
+		if g.ParentID == nil && g.Description == "Plan "+time.Now().Format("2006-01-02") {
+			planned = true
+		}
+	}
+	if len(first) != 2 || !planned {
+		t.Fatalf("expected planning template in sprint 1, got %+v", first)
+	}
+	last, err := db.GetGoalsForSprint(ctx, sprints[2].ID)
+	if err != nil {
+		t.Fatalf("GetGoalsForSprint failed: %v", err)
+	}
+	if len(last) != 1 || last[0].Description != "Expenses" {
+		t.Fatalf("expected admin goal in sprint 3, got %+v", last)
+	}
+}
+
+func TestApplyDayTemplateIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	tpl := DayTemplate{
+		Name:    "Today",
+		Sprints: 2,
+		Plans: []DaySprintPlan{
+			{Number: 1, Label: "Planning", Goals: []TemplateGoal{{GoalSeed: GoalSeed{Description: "Inbox"}}}},
+			{Number: 2, Goals: []TemplateGoal{{GoalSeed: GoalSeed{Description: "Expenses"}}}},
+		},
+	}
+	if _, err := db.DB.ExecContext(ctx, `CREATE TRIGGER refuse_expenses BEFORE INSERT ON goals
+		WHEN NEW.description = 'Expenses' BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	if err := db.ApplyDayTemplate(ctx, tpl, wsID); err == nil {
+		t.Fatalf("expected the refused goal to fail the template")
+	}
+	if id := db.CheckCurrentDay(ctx); id != 0 {
+		t.Fatalf("expected no day after a failed template, got day %d", id)
+	}
+	var goals int
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM goals").Scan(&goals); err != nil {
+		t.Fatalf("count goals failed: %v", err)
+	}
+	if goals != 0 {
+		t.Fatalf("expected no goals after a failed template, got %d", goals)
+	}
+
+	if _, err := db.DB.ExecContext(ctx, "DROP TRIGGER refuse_expenses"); err != nil {
+		t.Fatalf("drop trigger failed: %v", err)
+	}
+	if err := db.ApplyDayTemplate(ctx, tpl, wsID); err != nil {
+		t.Fatalf("ApplyDayTemplate failed: %v", err)
+	}
+	if db.CheckCurrentDay(ctx) == 0 {
+		t.Fatalf("expected the day to be created on retry")
+	}
+}
@@ -103,12 +103,16 @@ func (d *Database) createTables(ctx context.Context) error {
 				theme TEXT DEFAULT 'default',
 				show_backlog INTEGER DEFAULT 1,
 				show_completed INTEGER DEFAULT 1,
-				show_archived INTEGER DEFAULT 0
+				show_archived INTEGER DEFAULT 0,
+				workflow TEXT,
+				board_mode INTEGER DEFAULT 0,
+				wip_limits TEXT
 			);`,
 			`CREATE TABLE IF NOT EXISTS days (
 				id INTEGER PRIMARY KEY AUTOINCREMENT,
 				date TEXT NOT NULL UNIQUE,
-				started_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				planned INTEGER DEFAULT 0
 			);`,
 			`CREATE TABLE IF NOT EXISTS sprints (
 				id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -119,7 +123,14 @@ func (d *Database) createTables(ctx context.Context) error {
 				start_time DATETIME,
 				end_time DATETIME,
 				last_paused_at DATETIME,
-				elapsed_seconds INTEGER DEFAULT 0
+				elapsed_seconds INTEGER DEFAULT 0,
+				label TEXT,
+				retro_focus INTEGER,
+				retro_energy INTEGER,
+				retro_went_well TEXT,
+				retro_blockers TEXT,
+				retro_journal_id INTEGER,
+				corrected INTEGER DEFAULT 0
 			);`,
 			`CREATE TABLE IF NOT EXISTS goals (
 				id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -135,6 +146,10 @@ func (d *Database) createTables(ctx context.Context) error {
 				tags TEXT,
 				links TEXT,
 				recurrence_rule TEXT,
+				recurrence_next TEXT,
+				due_date TEXT,
+				carry_over_count INTEGER DEFAULT 0,
+				deferred_until TEXT,
 				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 				completed_at DATETIME,
 				archived_at DATETIME,
@@ -191,9 +206,19 @@ func (d *Database) migrate(ctx context.Context) error {
 		"ALTER TABLE goals ADD COLUMN task_started_at DATETIME",
 		"ALTER TABLE goals ADD COLUMN task_elapsed_seconds INTEGER DEFAULT 0",
 		"ALTER TABLE goals ADD COLUMN task_active INTEGER DEFAULT 0",
+		"ALTER TABLE goals ADD COLUMN recurrence_next TEXT",
+		"ALTER TABLE goals ADD COLUMN due_date TEXT",
+		"ALTER TABLE goals ADD COLUMN carry_over_count INTEGER DEFAULT 0",
+		"ALTER TABLE goals ADD COLUMN deferred_until TEXT",
 
 		// Sprints
 		"ALTER TABLE sprints ADD COLUMN workspace_id INTEGER",
+		"ALTER TABLE sprints ADD COLUMN label TEXT",
+		"ALTER TABLE sprints ADD COLUMN retro_focus INTEGER",
+		"ALTER TABLE sprints ADD COLUMN retro_energy INTEGER",
+		"ALTER TABLE sprints ADD COLUMN retro_went_well TEXT",
+		"ALTER TABLE sprints ADD COLUMN retro_blockers TEXT",
+		"ALTER TABLE sprints ADD COLUMN retro_journal_id INTEGER",
 		// Backfill legacy sprints to default workspace (1)
 		"UPDATE sprints SET workspace_id = (SELECT id FROM workspaces WHERE slug = 'personal') WHERE workspace_id IS NULL",
 
@@ -213,6 +238,13 @@ func (d *Database) migrate(ctx context.Context) error {
 		"ALTER TABLE workspaces ADD COLUMN show_backlog INTEGER DEFAULT 1",
 		"ALTER TABLE workspaces ADD COLUMN show_completed INTEGER DEFAULT 1",
 		"ALTER TABLE workspaces ADD COLUMN show_archived INTEGER DEFAULT 0",
+		"ALTER TABLE workspaces ADD COLUMN workflow TEXT",
+		"ALTER TABLE workspaces ADD COLUMN board_mode INTEGER DEFAULT 0",
+		"ALTER TABLE workspaces ADD COLUMN wip_limits TEXT",
+		// Days created ahead of time
+		"ALTER TABLE days ADD COLUMN planned INTEGER DEFAULT 0",
+		// Sprints whose times were entered after the fact
+		"ALTER TABLE sprints ADD COLUMN corrected INTEGER DEFAULT 0",
 
 		// Task dependencies
 		`CREATE TABLE IF NOT EXISTS task_deps (
@@ -228,6 +260,68 @@ func (d *Database) migrate(ctx context.Context) error {
 		key TEXT PRIMARY KEY,
 		value TEXT
 	);`,
+
+		// Goal templates
+		`CREATE TABLE IF NOT EXISTS goal_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		payload TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+
+		// Day templates
+		`CREATE TABLE IF NOT EXISTS day_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		payload TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+
+		// Automatic goal status transitions
+		`CREATE TABLE IF NOT EXISTS goal_transitions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		goal_id INTEGER NOT NULL,
+		from_status TEXT,
+		to_status TEXT NOT NULL,
+		reason TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+
+		// Manual corrections of past days, sprints and goals
+		`CREATE TABLE IF NOT EXISTS corrections (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		entity TEXT NOT NULL,
+		entity_id INTEGER NOT NULL,
+		field TEXT NOT NULL,
+		old_value TEXT,
+		new_value TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+
+		// Unfinished goals sent to the backlog when their sprint completed,
+		// kept until the carry-over review resolves them
+		`CREATE TABLE IF NOT EXISTS carry_overs (
+		goal_id INTEGER NOT NULL,
+		sprint_id INTEGER NOT NULL,
+		reviewed_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (goal_id, sprint_id)
+	);`,
+
+		// Task timer sessions, for time reports by date
+		`CREATE TABLE IF NOT EXISTS task_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		goal_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME,
+		seconds INTEGER NOT NULL DEFAULT 0
+	);`,
+		// Timer totals tracked before sessions were logged count as one
+		// session on the day the goal was completed, or else created.
+		`INSERT INTO task_sessions (goal_id, started_at, ended_at, seconds)
+		SELECT id, COALESCE(completed_at, created_at), COALESCE(completed_at, created_at), task_elapsed_seconds
+		FROM goals
+		WHERE task_elapsed_seconds > 0 AND id NOT IN (SELECT goal_id FROM task_sessions)`,
 	}
 
 	for _, query := range migrations {
@@ -244,6 +338,12 @@ func (d *Database) migrate(ctx context.Context) error {
 			return fmt.Errorf("migration failed: %w (%s)", err, query)
 		}
 	}
+	if err := d.scheduleLegacyRecurrence(ctx); err != nil {
+		return fmt.Errorf("migration failed: schedule recurrence: %w", err)
+	}
+	if err := d.syncLegacyBlockedStatus(ctx); err != nil {
+		return fmt.Errorf("migration failed: sync blocked status: %w", err)
+	}
 
 	indexStatements := []string{
 		`CREATE INDEX IF NOT EXISTS idx_goals_workspace_status
@@ -262,6 +362,12 @@ func (d *Database) migrate(ctx context.Context) error {
 		ON task_deps(goal_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_task_deps_depends_on_id
 		ON task_deps(depends_on_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_goal_transitions_goal_id
+		ON goal_transitions(goal_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_corrections_entity
+		ON corrections(entity, entity_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_sessions_goal_id
+		ON task_sessions(goal_id)`,
 	}
 	for _, stmt := range indexStatements {
 		if _, err := d.DB.ExecContext(ctx, stmt); err != nil {
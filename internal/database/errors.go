@@ -26,10 +26,12 @@ const (
 const (
 	EntityGoal      = "goal"
 	EntitySprint    = "sprint"
+	EntityDay       = "day"
 	EntityWorkspace = "workspace"
 	EntityTag       = "tag"
 	EntityJournal   = "journal"
 	EntitySetting   = "setting"
+	EntityTemplate  = "template"
 )
 
 type OpError struct {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/akyairhashvil/SSPT/internal/models"
 	"github.com/akyairhashvil/SSPT/internal/util"
 )
 
@@ -14,6 +15,7 @@ type ExportDay struct {
 	ID        int64  `json:"id"`
 	Date      string `json:"date"`
 	StartedAt string `json:"started_at"`
+	Planned   bool   `json:"planned,omitempty"`
 }
 
 type ExportWorkspace struct {
@@ -25,6 +27,9 @@ type ExportWorkspace struct {
 	ShowBacklog   bool   `json:"show_backlog"`
 	ShowCompleted bool   `json:"show_completed"`
 	ShowArchived  bool   `json:"show_archived"`
+	Workflow      string `json:"workflow,omitempty"`
+	BoardMode     int    `json:"board_mode,omitempty"`
+	WIPLimits     string `json:"wip_limits,omitempty"`
 }
 
 type ExportSprint struct {
@@ -37,6 +42,13 @@ type ExportSprint struct {
 	EndTime        *string `json:"end_time,omitempty"`
 	LastPausedAt   *string `json:"last_paused_at,omitempty"`
 	ElapsedSeconds int     `json:"elapsed_seconds"`
+	Label          *string `json:"label,omitempty"`
+	RetroFocus     *int64  `json:"retro_focus,omitempty"`
+	RetroEnergy    *int64  `json:"retro_energy,omitempty"`
+	RetroWentWell  *string `json:"retro_went_well,omitempty"`
+	RetroBlockers  *string `json:"retro_blockers,omitempty"`
+	RetroJournalID *int64  `json:"retro_journal_id,omitempty"`
+	Corrected      bool    `json:"corrected,omitempty"`
 }
 
 type ExportGoal struct {
@@ -51,6 +63,11 @@ type ExportGoal struct {
 	Effort         *string  `json:"effort,omitempty"`
 	Tags           []string `json:"tags,omitempty"`
 	RecurrenceRule *string  `json:"recurrence_rule,omitempty"`
+	RRule          *string  `json:"rrule,omitempty"`
+	RecurrenceNext *string  `json:"recurrence_next,omitempty"`
+	DueDate        *string  `json:"due_date,omitempty"`
+	CarryOverCount int      `json:"carry_over_count,omitempty"`
+	DeferredUntil  *string  `json:"deferred_until,omitempty"`
 	Links          []string `json:"links,omitempty"`
 	Rank           int      `json:"rank"`
 	CreatedAt      string   `json:"created_at"`
@@ -77,23 +94,38 @@ type ExportTaskDep struct {
 	DependsOnID int64 `json:"depends_on_id"`
 }
 
+type ExportTaskSession struct {
+	ID        int64   `json:"id"`
+	GoalID    int64   `json:"goal_id"`
+	StartedAt string  `json:"started_at"`
+	EndedAt   *string `json:"ended_at,omitempty"`
+	Seconds   int     `json:"seconds"`
+}
+
 type ExportOptions struct {
 	EncryptOutput bool
 	Passphrase    string
 }
 
 type VaultExport struct {
-	Workspaces []ExportWorkspace    `json:"workspaces"`
-	Days       []ExportDay          `json:"days"`
-	Sprints    []ExportSprint       `json:"sprints"`
-	Goals      []ExportGoal         `json:"goals"`
-	Journal    []ExportJournalEntry `json:"journal_entries"`
-	TaskDeps   []ExportTaskDep      `json:"task_deps"`
+	Workspaces   []ExportWorkspace    `json:"workspaces"`
+	Days         []ExportDay          `json:"days"`
+	Sprints      []ExportSprint       `json:"sprints"`
+	Goals        []ExportGoal         `json:"goals"`
+	Journal      []ExportJournalEntry `json:"journal_entries"`
+	TaskDeps     []ExportTaskDep      `json:"task_deps"`
+	Templates    []ExportTemplate     `json:"templates,omitempty"`
+	DayTemplates []DayTemplate        `json:"day_templates,omitempty"`
+}
+
+type ExportTemplate struct {
+	Name string       `json:"name"`
+	Goal TemplateGoal `json:"goal"`
 }
 
 func (d *Database) GetAllDays(ctx context.Context) ([]ExportDay, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]ExportDay, error) {
-		rows, err := d.DB.QueryContext(ctx, "SELECT id, date, started_at FROM days ORDER BY id ASC")
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, date, started_at, COALESCE(planned, 0) FROM days ORDER BY id ASC")
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +135,7 @@ func (d *Database) GetAllDays(ctx context.Context) ([]ExportDay, error) {
 		for rows.Next() {
 			var d ExportDay
 			var startedAt string
-			if err := rows.Scan(&d.ID, &d.Date, &startedAt); err != nil {
+			if err := rows.Scan(&d.ID, &d.Date, &startedAt, &d.Planned); err != nil {
 				return nil, err
 			}
 			d.StartedAt = startedAt
@@ -116,7 +148,8 @@ func (d *Database) GetAllDays(ctx context.Context) ([]ExportDay, error) {
 func (d *Database) GetAllSprintsFlat(ctx context.Context) ([]ExportSprint, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]ExportSprint, error) {
 		rows, err := d.DB.QueryContext(ctx, `
-			SELECT id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds
+			SELECT id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds, label,
+				retro_focus, retro_energy, retro_went_well, retro_blockers, retro_journal_id, COALESCE(corrected, 0)
 			FROM sprints ORDER BY id ASC`)
 		if err != nil {
 			return nil, err
@@ -128,7 +161,8 @@ func (d *Database) GetAllSprintsFlat(ctx context.Context) ([]ExportSprint, error
 			var s ExportSprint
 			var wsID *int64
 			var start, end, last *time.Time
-			if err := rows.Scan(&s.ID, &s.DayID, &wsID, &s.SprintNumber, &s.Status, &start, &end, &last, &s.ElapsedSeconds); err != nil {
+			if err := rows.Scan(&s.ID, &s.DayID, &wsID, &s.SprintNumber, &s.Status, &start, &end, &last, &s.ElapsedSeconds, &s.Label,
+				&s.RetroFocus, &s.RetroEnergy, &s.RetroWentWell, &s.RetroBlockers, &s.RetroJournalID, &s.Corrected); err != nil {
 				return nil, err
 			}
 			if wsID != nil {
@@ -153,10 +187,41 @@ func (d *Database) GetAllSprintsFlat(ctx context.Context) ([]ExportSprint, error
 	})
 }
 
+// GetAllTaskSessions returns every logged task timer session.
+func (d *Database) GetAllTaskSessions(ctx context.Context) ([]ExportTaskSession, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]ExportTaskSession, error) {
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, goal_id, started_at, ended_at, seconds FROM task_sessions ORDER BY started_at ASC, id ASC")
+		if err != nil {
+			return nil, wrapErr(EntityGoal, "list task sessions", 0, err)
+		}
+		defer rows.Close()
+
+		var out []ExportTaskSession
+		for rows.Next() {
+			var s ExportTaskSession
+			var started time.Time
+			var ended *time.Time
+			if err := rows.Scan(&s.ID, &s.GoalID, &started, &ended, &s.Seconds); err != nil {
+				return nil, wrapErr(EntityGoal, "list task sessions", 0, err)
+			}
+			s.StartedAt = started.Format(time.RFC3339)
+			if ended != nil {
+				val := ended.Format(time.RFC3339)
+				s.EndedAt = &val
+			}
+			out = append(out, s)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapErr(EntityGoal, "list task sessions", 0, err)
+		}
+		return out, nil
+	})
+}
+
 func (d *Database) GetAllGoalsExport(ctx context.Context) ([]ExportGoal, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]ExportGoal, error) {
 		rows, err := d.DB.QueryContext(ctx, `
-			SELECT id, parent_id, workspace_id, sprint_id, description, notes, status, priority, effort, tags, recurrence_rule, links, rank, created_at, completed_at, archived_at, task_started_at, task_elapsed_seconds, task_active
+			SELECT id, parent_id, workspace_id, sprint_id, description, notes, status, priority, effort, tags, recurrence_rule, links, rank, created_at, completed_at, archived_at, task_started_at, task_elapsed_seconds, task_active, recurrence_next, due_date, carry_over_count, deferred_until
 			FROM goals ORDER BY id ASC`)
 		if err != nil {
 			return nil, err
@@ -170,7 +235,7 @@ func (d *Database) GetAllGoalsExport(ctx context.Context) ([]ExportGoal, error)
 			var notes, effort, recurrence, tags, links *string
 			var completedAt, archivedAt, taskStarted *time.Time
 			var taskActive int
-			if err := rows.Scan(&g.ID, &parentID, &workspaceID, &sprintID, &g.Description, &notes, &g.Status, &g.Priority, &effort, &tags, &recurrence, &links, &g.Rank, &g.CreatedAt, &completedAt, &archivedAt, &taskStarted, &g.TaskElapsedSec, &taskActive); err != nil {
+			if err := rows.Scan(&g.ID, &parentID, &workspaceID, &sprintID, &g.Description, &notes, &g.Status, &g.Priority, &effort, &tags, &recurrence, &links, &g.Rank, &g.CreatedAt, &completedAt, &archivedAt, &taskStarted, &g.TaskElapsedSec, &taskActive, &g.RecurrenceNext, &g.DueDate, &g.CarryOverCount, &g.DeferredUntil); err != nil {
 				return nil, err
 			}
 			if parentID != nil {
@@ -196,6 +261,10 @@ func (d *Database) GetAllGoalsExport(ctx context.Context) ([]ExportGoal, error)
 			if recurrence != nil {
 				val := *recurrence
 				g.RecurrenceRule = &val
+				if rule, err := util.ParseRecurrence(val); err == nil {
+					rrule := rule.RRULE()
+					g.RRule = &rrule
+				}
 			}
 			if tags != nil && *tags != "" && *tags != "[]" {
 				g.Tags = util.JSONToTags(*tags)
@@ -299,6 +368,9 @@ func (d *Database) ExportVault(ctx context.Context, opts ExportOptions) ([]byte,
 			ShowBacklog:   ws.ShowBacklog,
 			ShowCompleted: ws.ShowCompleted,
 			ShowArchived:  ws.ShowArchived,
+			Workflow:      ws.Workflow.String(),
+			BoardMode:     ws.BoardMode,
+			WIPLimits:     models.FormatWIPLimits(ws.WIPLimits),
 		})
 	}
 	days, err := d.GetAllDays(ctx)
@@ -321,14 +393,28 @@ func (d *Database) ExportVault(ctx context.Context, opts ExportOptions) ([]byte,
 	if err != nil {
 		return nil, err
 	}
+	templates, err := d.GetGoalTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exportTemplates := make([]ExportTemplate, 0, len(templates))
+	for _, tpl := range templates {
+		exportTemplates = append(exportTemplates, ExportTemplate{Name: tpl.Name, Goal: tpl.Goal})
+	}
+	dayTemplates, err := d.GetDayTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	export := VaultExport{
-		Workspaces: exportWorkspaces,
-		Days:       days,
-		Sprints:    sprints,
-		Goals:      goals,
-		Journal:    journal,
-		TaskDeps:   deps,
+		Workspaces:   exportWorkspaces,
+		Days:         days,
+		Sprints:      sprints,
+		Goals:        goals,
+		Journal:      journal,
+		TaskDeps:     deps,
+		Templates:    exportTemplates,
+		DayTemplates: dayTemplates,
 	}
 	jsonData, err := json.Marshal(export)
 	if err != nil {
@@ -362,10 +448,11 @@ func (d *Database) ImportVault(ctx context.Context, payload []byte) error {
 		for _, ws := range export.Workspaces {
 			if _, err := tx.ExecContext(ctx, `
 				INSERT OR REPLACE INTO workspaces
-				(id, name, slug, view_mode, theme, show_backlog, show_completed, show_archived)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				(id, name, slug, view_mode, theme, show_backlog, show_completed, show_archived, workflow, board_mode, wip_limits)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				ws.ID, ws.Name, ws.Slug, ws.ViewMode, ws.Theme,
 				util.BoolToInt(ws.ShowBacklog), util.BoolToInt(ws.ShowCompleted), util.BoolToInt(ws.ShowArchived),
+				nullableStringIf(ws.Workflow), ws.BoardMode, nullableStringIf(ws.WIPLimits),
 			); err != nil {
 				return fmt.Errorf("import workspace %d: %w", ws.ID, err)
 			}
@@ -377,9 +464,9 @@ func (d *Database) ImportVault(ctx context.Context, payload []byte) error {
 				startedAt = ""
 			}
 			if _, err := tx.ExecContext(ctx, `
-				INSERT OR REPLACE INTO days (id, date, started_at)
-				VALUES (?, ?, ?)`,
-				day.ID, day.Date, startedAt,
+				INSERT OR REPLACE INTO days (id, date, started_at, planned)
+				VALUES (?, ?, ?, ?)`,
+				day.ID, day.Date, startedAt, day.Planned,
 			); err != nil {
 				return fmt.Errorf("import day %d: %w", day.ID, err)
 			}
@@ -392,16 +479,25 @@ func (d *Database) ImportVault(ctx context.Context, payload []byte) error {
 			}
 			if _, err := tx.ExecContext(ctx, `
 				INSERT OR REPLACE INTO sprints
-				(id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				(id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds, label,
+				 retro_focus, retro_energy, retro_went_well, retro_blockers, retro_journal_id, corrected)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				sprint.ID, sprint.DayID, sprint.WorkspaceID, sprint.SprintNumber, status,
-				sprint.StartTime, sprint.EndTime, sprint.LastPausedAt, sprint.ElapsedSeconds,
+				sprint.StartTime, sprint.EndTime, sprint.LastPausedAt, sprint.ElapsedSeconds, sprint.Label,
+				sprint.RetroFocus, sprint.RetroEnergy, sprint.RetroWentWell, sprint.RetroBlockers, sprint.RetroJournalID, sprint.Corrected,
 			); err != nil {
 				return fmt.Errorf("import sprint %d: %w", sprint.ID, err)
 			}
 		}
 
 		for _, goal := range export.Goals {
+			recurrence := toNullableString(goal.RecurrenceRule)
+			if recurrence == "" {
+				recurrence = toNullableString(goal.RRule)
+			}
+			if normalized, err := util.NormalizeRecurrence(recurrence); err == nil {
+				recurrence = normalized
+			}
 			status := goal.Status
 			if strings.TrimSpace(status) == "" {
 				status = "pending"
@@ -421,12 +517,14 @@ func (d *Database) ImportVault(ctx context.Context, payload []byte) error {
 			if _, err := tx.ExecContext(ctx, `
 				INSERT OR REPLACE INTO goals
 				(id, parent_id, workspace_id, sprint_id, description, notes, status, priority, effort, tags, recurrence_rule, links, rank,
-				 created_at, completed_at, archived_at, task_started_at, task_elapsed_seconds, task_active)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				 created_at, completed_at, archived_at, task_started_at, task_elapsed_seconds, task_active, recurrence_next, due_date,
+				 carry_over_count, deferred_until)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				goal.ID, goal.ParentID, goal.WorkspaceID, goal.SprintID, goal.Description, goal.Notes, status,
-				goal.Priority, goal.Effort, nullableStringIf(tags), nullableStringIf(toNullableString(goal.RecurrenceRule)), nullableStringIf(links),
+				goal.Priority, goal.Effort, nullableStringIf(tags), nullableStringIf(recurrence), nullableStringIf(links),
 				goal.Rank, goal.CreatedAt, goal.CompletedAt, goal.ArchivedAt, goal.TaskStartedAt,
-				goal.TaskElapsedSec, util.BoolToInt(goal.TaskActive),
+				goal.TaskElapsedSec, util.BoolToInt(goal.TaskActive), goal.RecurrenceNext, goal.DueDate,
+				goal.CarryOverCount, goal.DeferredUntil,
 			); err != nil {
 				return fmt.Errorf("import goal %d: %w", goal.ID, err)
 			}
@@ -458,6 +556,33 @@ func (d *Database) ImportVault(ctx context.Context, payload []byte) error {
 			}
 		}
 
+		for _, tpl := range export.Templates {
+			payload, err := json.Marshal(tpl.Goal)
+			if err != nil {
+				return fmt.Errorf("import template %q: %w", tpl.Name, err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO goal_templates (name, payload) VALUES (?, ?)
+				ON CONFLICT(name) DO UPDATE SET payload = excluded.payload`,
+				tpl.Name, string(payload),
+			); err != nil {
+				return fmt.Errorf("import template %q: %w", tpl.Name, err)
+			}
+		}
+		for _, tpl := range export.DayTemplates {
+			payload, err := json.Marshal(tpl)
+			if err != nil {
+				return fmt.Errorf("import day template %q: %w", tpl.Name, err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO day_templates (name, payload) VALUES (?, ?)
+				ON CONFLICT(name) DO UPDATE SET payload = excluded.payload`,
+				tpl.Name, string(payload),
+			); err != nil {
+				return fmt.Errorf("import day template %q: %w", tpl.Name, err)
+			}
+		}
+
 		if err := tx.Commit(); err != nil {
 			return fmt.Errorf("import vault commit: %w", err)
 		}
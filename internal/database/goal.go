@@ -14,7 +14,7 @@ import (
 	"github.com/akyairhashvil/SSPT/internal/util"
 )
 
-const goalColumnsWithSprint = `id, parent_id, sprint_id, description, status, rank, priority, effort, tags, recurrence_rule, created_at, archived_at, task_started_at, task_elapsed_seconds, task_active`
+const goalColumnsWithSprint = `id, parent_id, sprint_id, description, status, rank, priority, effort, tags, recurrence_rule, created_at, archived_at, task_started_at, task_elapsed_seconds, task_active, due_date, carry_over_count, deferred_until, links, notes, completed_at`
 
 // scanGoalWithSprint scans a database row into a Goal struct.
 // The row parameter accepts any type with a Scan method (sql.Row or sql.Rows).
@@ -23,7 +23,8 @@ const goalColumnsWithSprint = `id, parent_id, sprint_id, description, status, ra
 //
 //	id, parent_id, sprint_id, description, status, rank, priority, effort, tags,
 //	recurrence_rule, created_at, archived_at, task_started_at, task_elapsed_seconds,
-//	task_active
+//	task_active, due_date, carry_over_count, deferred_until, links, notes,
+//	completed_at
 //
 // Returns ErrNoRows if the row is empty.
 func scanGoalWithSprint(row interface{ Scan(...interface{}) error }) (models.Goal, error) {
@@ -45,6 +46,12 @@ func scanGoalWithSprint(row interface{ Scan(...interface{}) error }) (models.Goa
 		&g.TaskStartedAt,
 		&g.TaskElapsedSec,
 		&active,
+		&g.DueDate,
+		&g.CarryOverCount,
+		&g.DeferredUntil,
+		&g.Links,
+		&g.Notes,
+		&g.CompletedAt,
 	); err != nil {
 		return models.Goal{}, err
 	}
@@ -67,6 +74,9 @@ func normalizeSeed(seed GoalSeed) NormalizedGoal {
 	priority := normalizePriority(seed.Priority)
 	effort := normalizeEffort(seed.Effort)
 	recurrence := strings.TrimSpace(seed.Recurrence)
+	if normalized, err := util.NormalizeRecurrence(recurrence); err == nil {
+		recurrence = normalized
+	}
 	notes := strings.TrimSpace(seed.Notes)
 	tags := seed.Tags
 	if len(tags) == 0 && desc != "" {
@@ -211,44 +221,58 @@ func (d *Database) UpdateGoalPriority(ctx context.Context, goalID int64, priorit
 }
 
 func (d *Database) AddGoalDetailed(ctx context.Context, workspaceID int64, sprintID int64, seed GoalSeed) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		seed.Description = strings.TrimSpace(seed.Description)
-		if seed.Description == "" {
-			return nil
-		}
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := addGoalDetailedTx(ctx, tx, workspaceID, sprintID, seed)
+		return err
+	})
+}
 
-		var maxRank int
-		var err error
-		if sprintID > 0 {
-			maxRank, err = d.getMaxGoalRank(ctx, sprintID)
-		} else {
-			maxRank, err = d.getMaxBacklogRank(ctx, workspaceID)
-		}
-		if err != nil {
-			return wrapErr(EntityGoal, "add detailed", 0, err)
-		}
+// addGoalDetailedTx inserts a goal from a seed and returns its ID, or 0 when
+// the seed has no description.
+func addGoalDetailedTx(ctx context.Context, tx *sql.Tx, workspaceID int64, sprintID int64, seed GoalSeed) (int64, error) {
+	seed.Description = strings.TrimSpace(seed.Description)
+	if seed.Description == "" {
+		return 0, nil
+	}
 
-		tags := seed.Tags
-		if len(tags) == 0 {
-			tags = util.ExtractTags(seed.Description)
-		}
-		priority := normalizePriority(seed.Priority)
-		effort := normalizeEffort(seed.Effort)
-		tagsJSON := util.TagsToJSON(normalizeTagsFromSlice(tags))
-		linksJSON, err := json.Marshal(seed.Links)
-		if err != nil {
-			return wrapErr(EntityGoal, "add detailed", 0, err)
-		}
+	var maxRank int
+	var err error
+	if sprintID > 0 {
+		err = tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(rank), 0) FROM goals WHERE sprint_id = ? AND parent_id IS NULL", sprintID).Scan(&maxRank)
+	} else {
+		err = tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(rank), 0) FROM goals WHERE sprint_id IS NULL AND workspace_id = ? AND parent_id IS NULL", workspaceID).Scan(&maxRank)
+	}
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add detailed", 0, err)
+	}
 
-		sprintIDArg := nullableInt64(sprintID)
-		notesArg := nullableStringIf(seed.Notes)
-		recurrenceArg := nullableStringIf(seed.Recurrence)
+	tags := seed.Tags
+	if len(tags) == 0 {
+		tags = util.ExtractTags(seed.Description)
+	}
+	priority := normalizePriority(seed.Priority)
+	effort := normalizeEffort(seed.Effort)
+	tagsJSON := util.TagsToJSON(normalizeTagsFromSlice(tags))
+	linksJSON, err := json.Marshal(seed.Links)
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add detailed", 0, err)
+	}
 
-		_, err = d.DB.ExecContext(ctx, `INSERT INTO goals (workspace_id, description, sprint_id, status, rank, tags, priority, effort, notes, recurrence_rule, links)
-			VALUES (?, ?, ?, 'pending', ?, ?, ?, ?, ?, ?, ?)`,
-			workspaceID, seed.Description, sprintIDArg, maxRank+1, tagsJSON, priority, effort, notesArg, recurrenceArg, string(linksJSON))
-		return wrapErr(EntityGoal, "add detailed", 0, err)
-	})
+	sprintIDArg := nullableInt64(sprintID)
+	notesArg := nullableStringIf(seed.Notes)
+	recurrenceArg := nullableStringIf(normalizeSeedRecurrence(seed.Recurrence))
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO goals (workspace_id, description, sprint_id, status, rank, tags, priority, effort, notes, recurrence_rule, links)
+		VALUES (?, ?, ?, 'pending', ?, ?, ?, ?, ?, ?, ?)`,
+		workspaceID, seed.Description, sprintIDArg, maxRank+1, tagsJSON, priority, effort, notesArg, recurrenceArg, string(linksJSON))
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add detailed", 0, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add detailed", 0, err)
+	}
+	return id, wrapErr(EntityGoal, "add detailed", id, scheduleInsertedRecurrence(ctx, tx, id, recurrenceArg))
 }
 
 // AddSubtask inserts a new subtask linked to a parent goal.
@@ -275,39 +299,53 @@ func (d *Database) AddSubtask(ctx context.Context, description string, parentID
 }
 
 func (d *Database) AddSubtaskDetailed(ctx context.Context, parentID int64, seed GoalSeed) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		var sprintID *int64
-		var workspaceID *int64
-		err := d.DB.QueryRowContext(ctx, "SELECT sprint_id, workspace_id FROM goals WHERE id = ?", parentID).Scan(&sprintID, &workspaceID)
-		if err != nil {
-			return wrapErr(EntityGoal, "add subtask detailed", parentID, err)
-		}
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := addSubtaskDetailedTx(ctx, tx, parentID, seed)
+		return err
+	})
+}
 
-		var maxRank int
-		if maxRank, err = d.getMaxSubtaskRank(ctx, parentID); err != nil {
-			return wrapErr(EntityGoal, "add subtask detailed", parentID, err)
-		}
+// addSubtaskDetailedTx inserts a subtask from a seed under parentID, in the
+// parent's sprint and workspace, and returns its ID.
+func addSubtaskDetailedTx(ctx context.Context, tx *sql.Tx, parentID int64, seed GoalSeed) (int64, error) {
+	var sprintID *int64
+	var workspaceID *int64
+	err := tx.QueryRowContext(ctx, "SELECT sprint_id, workspace_id FROM goals WHERE id = ?", parentID).Scan(&sprintID, &workspaceID)
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add subtask detailed", parentID, err)
+	}
 
-		priority := normalizePriority(seed.Priority)
-		effort := normalizeEffort(seed.Effort)
-		tags := seed.Tags
-		if len(tags) == 0 {
-			tags = util.ExtractTags(seed.Description)
-		}
-		tagsJSON := util.TagsToJSON(normalizeTagsFromSlice(tags))
-		linksJSON, err := json.Marshal(seed.Links)
-		if err != nil {
-			return wrapErr(EntityGoal, "add subtask detailed", parentID, err)
-		}
+	var maxRank int
+	if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(rank), 0) FROM goals WHERE parent_id = ?", parentID).Scan(&maxRank); err != nil {
+		return 0, wrapErr(EntityGoal, "add subtask detailed", parentID, err)
+	}
+
+	priority := normalizePriority(seed.Priority)
+	effort := normalizeEffort(seed.Effort)
+	tags := seed.Tags
+	if len(tags) == 0 {
+		tags = util.ExtractTags(seed.Description)
+	}
+	tagsJSON := util.TagsToJSON(normalizeTagsFromSlice(tags))
+	linksJSON, err := json.Marshal(seed.Links)
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add subtask detailed", parentID, err)
+	}
 
-		notesArg := nullableStringIf(seed.Notes)
-		recurrenceArg := nullableStringIf(seed.Recurrence)
+	notesArg := nullableStringIf(seed.Notes)
+	recurrenceArg := nullableStringIf(normalizeSeedRecurrence(seed.Recurrence))
 
-		_, err = d.DB.ExecContext(ctx, `INSERT INTO goals (description, parent_id, sprint_id, workspace_id, status, rank, tags, priority, effort, notes, recurrence_rule, links)
-			VALUES (?, ?, ?, ?, 'pending', ?, ?, ?, ?, ?, ?, ?)`,
-			seed.Description, parentID, sprintID, workspaceID, maxRank+1, tagsJSON, priority, effort, notesArg, recurrenceArg, string(linksJSON))
-		return wrapErr(EntityGoal, "add subtask detailed", parentID, err)
-	})
+	res, err := tx.ExecContext(ctx, `INSERT INTO goals (description, parent_id, sprint_id, workspace_id, status, rank, tags, priority, effort, notes, recurrence_rule, links)
+		VALUES (?, ?, ?, ?, 'pending', ?, ?, ?, ?, ?, ?, ?)`,
+		seed.Description, parentID, sprintID, workspaceID, maxRank+1, tagsJSON, priority, effort, notesArg, recurrenceArg, string(linksJSON))
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add subtask detailed", parentID, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "add subtask detailed", parentID, err)
+	}
+	return id, wrapErr(EntityGoal, "add subtask detailed", parentID, scheduleInsertedRecurrence(ctx, tx, id, recurrenceArg))
 }
 
 func (d *Database) UpdateGoalStatus(ctx context.Context, goalID int64, status models.GoalStatus) error {
@@ -323,20 +361,20 @@ func (d *Database) UpdateGoalStatus(ctx context.Context, goalID int64, status mo
 				}
 			}
 		}
-		var err error
-		statusValue := string(status)
-		if status == models.GoalStatusCompleted {
-			_, err = d.DB.ExecContext(ctx, "UPDATE goals SET status = ?, completed_at = CURRENT_TIMESTAMP WHERE id = ?", statusValue, goalID)
-		} else {
-			_, err = d.DB.ExecContext(ctx, "UPDATE goals SET status = ?, completed_at = NULL WHERE id = ?", statusValue, goalID)
-		}
-		if err != nil {
-			return wrapErr(EntityGoal, "update status", goalID, err)
-		}
-		if status == models.GoalStatusCompleted {
-			return wrapErr(EntityGoal, "regenerate", goalID, d.regenerateRecurringGoal(ctx, goalID))
-		}
-		return nil
+		err := d.WithTx(ctx, func(tx *sql.Tx) error {
+			var err error
+			statusValue := string(status)
+			if status == models.GoalStatusCompleted {
+				_, err = tx.ExecContext(ctx, "UPDATE goals SET status = ?, completed_at = CURRENT_TIMESTAMP WHERE id = ?", statusValue, goalID)
+			} else {
+				_, err = tx.ExecContext(ctx, "UPDATE goals SET status = ?, completed_at = NULL WHERE id = ?", statusValue, goalID)
+			}
+			if err != nil {
+				return err
+			}
+			return syncBlockedStatus(ctx, tx, goalID, UnblockedByCompletion)
+		})
+		return wrapErr(EntityGoal, "update status", goalID, err)
 	})
 }
 
@@ -398,7 +436,11 @@ func (d *Database) StartTaskTimer(ctx context.Context, goalID int64) error {
 				return err
 			}
 			if started != nil {
-				elapsed += int(time.Since(*started).Seconds())
+				seconds := int(time.Since(*started).Seconds())
+				elapsed += seconds
+				if err := recordTaskSession(ctx, tx, id, *started, seconds); err != nil {
+					return err
+				}
 			}
 			if _, err := tx.ExecContext(ctx, "UPDATE goals SET task_active = 0, task_started_at = NULL, task_elapsed_seconds = ? WHERE id = ?", elapsed, id); err != nil {
 				return err
@@ -410,28 +452,44 @@ func (d *Database) StartTaskTimer(ctx context.Context, goalID int64) error {
 		if err := rows.Err(); err != nil {
 			return err
 		}
+		// Working on a pending goal moves it to in_progress when the
+		// workspace workflow has that status.
+		var workflow sql.NullString
+		if err := tx.QueryRowContext(ctx, "SELECT workflow FROM workspaces WHERE id = ?", wsID).Scan(&workflow); err != nil {
+			return err
+		}
+		if models.WorkflowOrDefault(workflow.String).Contains(models.GoalStatusInProgress) {
+			if _, err := tx.ExecContext(ctx, "UPDATE goals SET status = ? WHERE id = ? AND status = ?", models.GoalStatusInProgress, goalID, models.GoalStatusPending); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	return wrapErr(EntityGoal, "start task timer", goalID, err)
 }
 
 func (d *Database) PauseTaskTimer(ctx context.Context, goalID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
 		var started *time.Time
 		var elapsed int
 		var active int
-		if err := d.DB.QueryRowContext(ctx, "SELECT task_active, task_started_at, task_elapsed_seconds FROM goals WHERE id = ?", goalID).Scan(&active, &started, &elapsed); err != nil {
-			return wrapErr(EntityGoal, "pause task timer", goalID, err)
+		if err := tx.QueryRowContext(ctx, "SELECT task_active, task_started_at, task_elapsed_seconds FROM goals WHERE id = ?", goalID).Scan(&active, &started, &elapsed); err != nil {
+			return err
 		}
 		if active == 0 {
 			return nil
 		}
 		if started != nil {
-			elapsed += int(time.Since(*started).Seconds())
+			seconds := int(time.Since(*started).Seconds())
+			elapsed += seconds
+			if err := recordTaskSession(ctx, tx, goalID, *started, seconds); err != nil {
+				return err
+			}
 		}
-		_, err := d.DB.ExecContext(ctx, "UPDATE goals SET task_active = 0, task_started_at = NULL, task_elapsed_seconds = ? WHERE id = ?", elapsed, goalID)
-		return wrapErr(EntityGoal, "pause task timer", goalID, err)
+		_, err := tx.ExecContext(ctx, "UPDATE goals SET task_active = 0, task_started_at = NULL, task_elapsed_seconds = ? WHERE id = ?", elapsed, goalID)
+		return err
 	})
+	return wrapErr(EntityGoal, "pause task timer", goalID, err)
 }
 
 func (d *Database) MoveGoal(ctx context.Context, goalID int64, targetSprintID int64) error {
@@ -442,6 +500,25 @@ func (d *Database) MoveGoal(ctx context.Context, goalID int64, targetSprintID in
 	})
 }
 
+// GoalMove places a goal in a sprint (0 = backlog).
+type GoalMove struct {
+	GoalID   int64
+	SprintID int64
+}
+
+// MoveGoals applies a batch of moves in one transaction, so either every
+// goal moves or none does.
+func (d *Database) MoveGoals(ctx context.Context, moves []GoalMove) error {
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, mv := range moves {
+			if _, err := tx.ExecContext(ctx, "UPDATE goals SET sprint_id = ? WHERE id = ?", nullableInt64(mv.SprintID), mv.GoalID); err != nil {
+				return wrapErr(EntityGoal, "move", mv.GoalID, err)
+			}
+		}
+		return nil
+	})
+}
+
 func (d *Database) EditGoal(ctx context.Context, goalID int64, newDescription string) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
 		tags := util.TagsToJSON(util.ExtractTags(newDescription))
@@ -451,24 +528,45 @@ func (d *Database) EditGoal(ctx context.Context, goalID int64, newDescription st
 }
 
 func (d *Database) DeleteGoal(ctx context.Context, goalID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "DELETE FROM goals WHERE id = ?", goalID)
-		return wrapErr(EntityGoal, "delete", goalID, err)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		dependents, err := dependentGoalIDsTx(ctx, tx, goalID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM goals WHERE id = ?", goalID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_deps WHERE goal_id = ? OR depends_on_id = ?", goalID, goalID); err != nil {
+			return err
+		}
+		for _, id := range dependents {
+			if err := syncBlockedStatus(ctx, tx, id, UnblockedByRemoval); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
+	return wrapErr(EntityGoal, "delete", goalID, err)
 }
 
 func (d *Database) ArchiveGoal(ctx context.Context, goalID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "UPDATE goals SET status = 'archived', archived_at = CURRENT_TIMESTAMP WHERE id = ?", goalID)
-		return wrapErr(EntityGoal, "archive", goalID, err)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "UPDATE goals SET status = 'archived', archived_at = CURRENT_TIMESTAMP WHERE id = ?", goalID); err != nil {
+			return err
+		}
+		return syncBlockedStatus(ctx, tx, goalID, UnblockedByCompletion)
 	})
+	return wrapErr(EntityGoal, "archive", goalID, err)
 }
 
 func (d *Database) UnarchiveGoal(ctx context.Context, goalID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "UPDATE goals SET status = 'pending', archived_at = NULL, sprint_id = NULL WHERE id = ?", goalID)
-		return wrapErr(EntityGoal, "unarchive", goalID, err)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "UPDATE goals SET status = 'pending', archived_at = NULL, sprint_id = NULL WHERE id = ?", goalID); err != nil {
+			return err
+		}
+		return syncBlockedStatus(ctx, tx, goalID, UnblockedByCompletion)
 	})
+	return wrapErr(EntityGoal, "unarchive", goalID, err)
 }
 
 func (d *Database) AddTagsToGoal(ctx context.Context, goalID int64, tagsToAdd []string) error {
@@ -506,6 +604,26 @@ func (d *Database) updateGoalTags(ctx context.Context, goalID int64, tags []stri
 	})
 }
 
+// SetGoalLinks replaces the URLs and file paths attached to a goal.
+func (d *Database) SetGoalLinks(ctx context.Context, goalID int64, links []string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		linksJSON, err := json.Marshal(normalizeLinksFromSlice(links))
+		if err != nil {
+			return wrapErr(EntityGoal, "marshal_links", goalID, err)
+		}
+		_, err = d.DB.ExecContext(ctx, `UPDATE goals SET links = ? WHERE id = ?`, string(linksJSON), goalID)
+		return wrapErr(EntityGoal, "update_links", goalID, err)
+	})
+}
+
+// UpdateGoalNotes replaces a goal's markdown notes; empty notes are cleared.
+func (d *Database) UpdateGoalNotes(ctx context.Context, goalID int64, notes string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, `UPDATE goals SET notes = ? WHERE id = ?`, nullableStringIf(strings.TrimSpace(notes)), goalID)
+		return wrapErr(EntityGoal, "update_notes", goalID, err)
+	})
+}
+
 // GetBacklogGoals retrieves goals that are not assigned to any sprint and belong to the workspace.
 func (d *Database) GetBacklogGoals(ctx context.Context, workspaceID int64) ([]models.Goal, error) {
 	query, args := NewGoalQuery().
@@ -716,7 +834,7 @@ func (d *Database) Search(ctx context.Context, query util.SearchQuery, workspace
 		placeholders := strings.TrimRight(strings.Repeat("?,", len(query.Status)), ",")
 		statusArgs := make([]interface{}, 0, len(query.Status))
 		for _, status := range query.Status {
-			statusArgs = append(statusArgs, status)
+			statusArgs = append(statusArgs, strings.ToLower(status))
 		}
 		builder.Where("status IN ("+placeholders+")", statusArgs...)
 	}
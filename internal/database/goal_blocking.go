@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+// GoalTransition is a recorded automatic status change of a goal.
+type GoalTransition struct {
+	ID          int64
+	GoalID      int64
+	Description string
+	From        models.GoalStatus
+	To          models.GoalStatus
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// Reasons recorded when a goal leaves blocked: its last unfinished
+// dependency was completed, or the dependencies holding it were removed or
+// deleted.
+const (
+	UnblockedByCompletion = "dependencies completed"
+	UnblockedByRemoval    = "dependencies removed"
+)
+
+// unfinishedDepsSQL selects from the unfinished dependencies of goal g.
+const unfinishedDepsSQL = `
+	FROM task_deps td
+	JOIN goals dg ON dg.id = td.depends_on_id
+	WHERE td.goal_id = g.id AND dg.status != 'completed'`
+
+// syncBlockedStatus moves open goals to blocked while any of their
+// dependencies is unfinished, and returns blocked goals to the status they
+// had before once none remain, recording unblockReason. Only goalID and the
+// goals depending on it are checked, or every goal when goalID is 0. Each
+// transition is recorded.
+func syncBlockedStatus(ctx context.Context, tx *sql.Tx, goalID int64, unblockReason string) error {
+	type change struct {
+		id     int64
+		from   string
+		to     string
+		reason string
+	}
+	scope := ""
+	var args []interface{}
+	if goalID > 0 {
+		scope = " AND (g.id = ? OR g.id IN (SELECT goal_id FROM task_deps WHERE depends_on_id = ?))"
+		args = []interface{}{goalID, goalID}
+	}
+
+	var changes []change
+	rows, err := tx.QueryContext(ctx, `
+		SELECT g.id, g.status, (SELECT group_concat('#' || td.depends_on_id, ', ') `+unfinishedDepsSQL+`)
+		FROM goals g
+		WHERE g.status NOT IN ('completed', 'archived', 'blocked') AND EXISTS (SELECT 1 `+unfinishedDepsSQL+`)`+scope, args...)
+	if err != nil {
+		return fmt.Errorf("sync blocked status: %w", err)
+	}
+	for rows.Next() {
+		var c change
+		var deps sql.NullString
+		if err := rows.Scan(&c.id, &c.from, &deps); err != nil {
+			rows.Close()
+			return fmt.Errorf("sync blocked status: %w", err)
+		}
+		c.to = string(models.GoalStatusBlocked)
+		c.reason = "waiting on " + deps.String
+		changes = append(changes, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sync blocked status: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT g.id, COALESCE((
+			SELECT t.from_status FROM goal_transitions t
+			WHERE t.goal_id = g.id AND t.to_status = 'blocked'
+			ORDER BY t.id DESC LIMIT 1), 'pending')
+		FROM goals g
+		WHERE g.status = 'blocked' AND NOT EXISTS (SELECT 1 `+unfinishedDepsSQL+`)`+scope, args...)
+	if err != nil {
+		return fmt.Errorf("sync blocked status: %w", err)
+	}
+	for rows.Next() {
+		c := change{from: string(models.GoalStatusBlocked), reason: unblockReason}
+		if err := rows.Scan(&c.id, &c.to); err != nil {
+			rows.Close()
+			return fmt.Errorf("sync blocked status: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sync blocked status: %w", err)
+	}
+
+	for _, c := range changes {
+		if _, err := tx.ExecContext(ctx, "UPDATE goals SET status = ? WHERE id = ?", c.to, c.id); err != nil {
+			return fmt.Errorf("sync blocked status: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO goal_transitions (goal_id, from_status, to_status, reason) VALUES (?, ?, ?, ?)",
+			c.id, c.from, c.to, c.reason); err != nil {
+			return fmt.Errorf("sync blocked status: %w", err)
+		}
+	}
+	return nil
+}
+
+// blockedMigratedSetting marks databases whose goals have had their blocked
+// status synced once by syncLegacyBlockedStatus.
+const blockedMigratedSetting = "blocked_status_migrated"
+
+// syncLegacyBlockedStatus runs once per database, bringing goals whose
+// dependencies predate automatic blocking in line. Afterwards each write
+// syncs only the goals it affects.
+func (d *Database) syncLegacyBlockedStatus(ctx context.Context) error {
+	if _, ok := d.GetSetting(ctx, blockedMigratedSetting); ok {
+		return nil
+	}
+	if err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		return syncBlockedStatus(ctx, tx, 0, UnblockedByCompletion)
+	}); err != nil {
+		return err
+	}
+	return d.SetSetting(ctx, blockedMigratedSetting, "1")
+}
+
+// GetGoalTransitions returns the recorded status transitions with an ID
+// greater than afterID, oldest first.
+func (d *Database) GetGoalTransitions(ctx context.Context, afterID int64) ([]GoalTransition, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]GoalTransition, error) {
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT t.id, t.goal_id, COALESCE(g.description, ''), t.from_status, t.to_status, COALESCE(t.reason, ''), t.created_at
+			FROM goal_transitions t
+			LEFT JOIN goals g ON g.id = t.goal_id
+			WHERE t.id > ?
+			ORDER BY t.id ASC`, afterID)
+		if err != nil {
+			return nil, wrapErr(EntityGoal, "list transitions", 0, err)
+		}
+		defer rows.Close()
+
+		var out []GoalTransition
+		for rows.Next() {
+			var t GoalTransition
+			var from sql.NullString
+			if err := rows.Scan(&t.ID, &t.GoalID, &t.Description, &from, &t.To, &t.Reason, &t.CreatedAt); err != nil {
+				return nil, wrapErr(EntityGoal, "list transitions", 0, err)
+			}
+			t.From = models.GoalStatus(from.String)
+			out = append(out, t)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapErr(EntityGoal, "list transitions", 0, err)
+		}
+		return out, nil
+	})
+}
+
+// GetLastGoalTransitionID returns the newest transition ID, or 0.
+func (d *Database) GetLastGoalTransitionID(ctx context.Context) (int64, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) (int64, error) {
+		var id sql.NullInt64
+		err := d.DB.QueryRowContext(ctx, "SELECT MAX(id) FROM goal_transitions").Scan(&id)
+		return id.Int64, wrapErr(EntityGoal, "last transition", 0, err)
+	})
+}
+
+// FormatTransitionGoals lists the goals of transitions as "#ID description".
+func FormatTransitionGoals(transitions []GoalTransition) string {
+	parts := make([]string, 0, len(transitions))
+	for _, t := range transitions {
+		parts = append(parts, fmt.Sprintf("#%d %s", t.GoalID, t.Description))
+	}
+	return strings.Join(parts, ", ")
+}
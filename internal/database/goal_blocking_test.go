@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+func TestBlockedStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) == 0 {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	sprintID := sprints[0].ID
+	goalA := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal A")
+	goalB := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal B")
+	goalC := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal C")
+
+	status := func(id int64) models.GoalStatus {
+		t.Helper()
+		goal, err := db.GetGoalByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetGoalByID failed: %v", err)
+		}
+		return goal.Status
+	}
+
+	if err := db.UpdateGoalStatus(ctx, goalC, models.GoalStatusInProgress); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if err := db.SetGoalDependencies(ctx, goalC, []int64{goalA, goalB}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	if got := status(goalC); got != models.GoalStatusBlocked {
+		t.Fatalf("expected goal C blocked, got %s", got)
+	}
+
+	if err := db.UpdateGoalStatus(ctx, goalA, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if got := status(goalC); got != models.GoalStatusBlocked {
+		t.Fatalf("expected goal C still blocked by B, got %s", got)
+	}
+	last, err := db.GetLastGoalTransitionID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalTransitionID failed: %v", err)
+	}
+
+	if err := db.UpdateGoalStatus(ctx, goalB, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if got := status(goalC); got != models.GoalStatusInProgress {
+		t.Fatalf("expected goal C back in progress, got %s", got)
+	}
+	transitions, err := db.GetGoalTransitions(ctx, last)
+	if err != nil {
+		t.Fatalf("GetGoalTransitions failed: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].GoalID != goalC || transitions[0].From != models.GoalStatusBlocked || transitions[0].To != models.GoalStatusInProgress || transitions[0].Reason != UnblockedByCompletion {
+		t.Fatalf("expected unblock transition for goal C, got %+v", transitions)
+	}
+
+	// Reopening a prerequisite blocks the dependent goal again.
+	if err := db.UpdateGoalStatus(ctx, goalB, models.GoalStatusPending); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if got := status(goalC); got != models.GoalStatusBlocked {
+		t.Fatalf("expected goal C blocked after reopening B, got %s", got)
+	}
+	all, err := db.GetGoalTransitions(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetGoalTransitions failed: %v", err)
+	}
+	if got := all[len(all)-1].Reason; got != fmt.Sprintf("waiting on #%d", goalB) {
+		t.Fatalf("unexpected block reason %q", got)
+	}
+
+	if err := db.RemoveGoalDependency(ctx, goalC, goalB); err != nil {
+		t.Fatalf("RemoveGoalDependency failed: %v", err)
+	}
+	if got := status(goalC); got != models.GoalStatusInProgress {
+		t.Fatalf("expected goal C unblocked after removing dependency, got %s", got)
+	}
+	all, err = db.GetGoalTransitions(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetGoalTransitions failed: %v", err)
+	}
+	if got := all[len(all)-1].Reason; got != UnblockedByRemoval {
+		t.Fatalf("unexpected unblock reason %q", got)
+	}
+}
+
+func TestDeletingBlockerUnblocksDependents(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) == 0 {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	sprintID := sprints[0].ID
+	goalA := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal A")
+	goalB := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal B")
+	if err := db.SetGoalDependencies(ctx, goalB, []int64{goalA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	last, err := db.GetLastGoalTransitionID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalTransitionID failed: %v", err)
+	}
+
+	if err := db.DeleteGoal(ctx, goalA); err != nil {
+		t.Fatalf("DeleteGoal failed: %v", err)
+	}
+	goal, err := db.GetGoalByID(ctx, goalB)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Status != models.GoalStatusPending {
+		t.Fatalf("expected goal B pending after deleting A, got %s", goal.Status)
+	}
+	transitions, err := db.GetGoalTransitions(ctx, last)
+	if err != nil {
+		t.Fatalf("GetGoalTransitions failed: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].GoalID != goalB || transitions[0].Reason != UnblockedByRemoval {
+		t.Fatalf("expected removal unblock for goal B, got %+v", transitions)
+	}
+	var deps int
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM task_deps WHERE goal_id = ? OR depends_on_id = ?", goalA, goalA).Scan(&deps); err != nil {
+		t.Fatalf("count dependencies failed: %v", err)
+	}
+	if deps != 0 {
+		t.Fatalf("expected dependencies on deleted goal removed, got %d", deps)
+	}
+}
+
+func TestBlockedStatusSyncScope(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) == 0 {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	sprintID := sprints[0].ID
+	goalA := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal A")
+	goalB := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal B")
+	goalC := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal C")
+
+	status := func(id int64) models.GoalStatus {
+		t.Helper()
+		goal, err := db.GetGoalByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetGoalByID failed: %v", err)
+		}
+		return goal.Status
+	}
+
+	// A dependency stored directly, as before automatic blocking, is only
+	// picked up by the migration; unrelated writes leave goal B alone.
+	if _, err := db.DB.ExecContext(ctx, "INSERT INTO task_deps (goal_id, depends_on_id) VALUES (?, ?)", goalB, goalA); err != nil {
+		t.Fatalf("seed dependency failed: %v", err)
+	}
+	if err := db.UpdateGoalStatus(ctx, goalC, models.GoalStatusInProgress); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if got := status(goalB); got != models.GoalStatusPending {
+		t.Fatalf("expected unrelated write to leave goal B pending, got %s", got)
+	}
+
+	if _, err := db.DB.ExecContext(ctx, "DELETE FROM settings WHERE key = ?", blockedMigratedSetting); err != nil {
+		t.Fatalf("reset setting failed: %v", err)
+	}
+	if err := db.migrate(ctx); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	if got := status(goalB); got != models.GoalStatusBlocked {
+		t.Fatalf("expected migration to block goal B, got %s", got)
+	}
+
+	// Once migrated, later opens do not sync every goal again.
+	if _, err := db.DB.ExecContext(ctx, "UPDATE goals SET status = 'pending' WHERE id = ?", goalB); err != nil {
+		t.Fatalf("reset status failed: %v", err)
+	}
+	if err := db.migrate(ctx); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	if got := status(goalB); got != models.GoalStatusPending {
+		t.Fatalf("expected no second migration sync, got %s", got)
+	}
+
+	// Completing the prerequisite syncs its dependents.
+	if err := db.UpdateGoalStatus(ctx, goalA, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if err := db.UpdateGoalStatus(ctx, goalA, models.GoalStatusPending); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if got := status(goalB); got != models.GoalStatusBlocked {
+		t.Fatalf("expected reopening goal A to block goal B, got %s", got)
+	}
+}
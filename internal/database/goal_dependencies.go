@@ -3,6 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
 )
 
 // AddGoalDependency creates a blocking relationship between two goals.
@@ -31,16 +34,24 @@ func (d *Database) AddGoalDependency(ctx context.Context, goalID, dependsOnID in
 		if createsCycle {
 			return ErrCircularDependency
 		}
-		_, err = d.DB.ExecContext(ctx, "INSERT OR IGNORE INTO task_deps (goal_id, depends_on_id) VALUES (?, ?)", goalID, dependsOnID)
+		err = d.WithTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO task_deps (goal_id, depends_on_id) VALUES (?, ?)", goalID, dependsOnID); err != nil {
+				return err
+			}
+			return syncBlockedStatus(ctx, tx, goalID, UnblockedByCompletion)
+		})
 		return wrapErr(EntityGoal, "add dependency", goalID, err)
 	})
 }
 
 func (d *Database) RemoveGoalDependency(ctx context.Context, goalID, dependsOnID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "DELETE FROM task_deps WHERE goal_id = ? AND depends_on_id = ?", goalID, dependsOnID)
-		return wrapErr(EntityGoal, "remove dependency", goalID, err)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_deps WHERE goal_id = ? AND depends_on_id = ?", goalID, dependsOnID); err != nil {
+			return err
+		}
+		return syncBlockedStatus(ctx, tx, goalID, UnblockedByRemoval)
 	})
+	return wrapErr(EntityGoal, "remove dependency", goalID, err)
 }
 
 func (d *Database) GetGoalDependencies(ctx context.Context, goalID int64) (map[int64]bool, error) {
@@ -93,7 +104,7 @@ func (d *Database) SetGoalDependencies(ctx context.Context, goalID int64, deps [
 				return err
 			}
 		}
-		return nil
+		return syncBlockedStatus(ctx, tx, goalID, UnblockedByRemoval)
 	})
 	return wrapErr(EntityGoal, "set dependencies", goalID, err)
 }
@@ -130,6 +141,24 @@ func (d *Database) getGoalWorkspaceID(ctx context.Context, goalID int64) (int64,
 	return *wsID, true
 }
 
+// dependentGoalIDsTx lists the goals that depend on goalID.
+func dependentGoalIDsTx(ctx context.Context, tx *sql.Tx, goalID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT goal_id FROM task_deps WHERE depends_on_id = ?", goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func getGoalWorkspaceIDTx(ctx context.Context, tx *sql.Tx, goalID int64) (int64, bool, error) {
 	var wsID *int64
 	if err := tx.QueryRowContext(ctx, "SELECT workspace_id FROM goals WHERE id = ?", goalID).Scan(&wsID); err != nil {
@@ -190,3 +219,43 @@ func (d *Database) GetBlockedGoalIDs(ctx context.Context, workspaceID int64) (ma
 		return blocked, nil
 	})
 }
+
+// GetDependencyEdges maps each goal in the workspace to the goals it depends on.
+func (d *Database) GetDependencyEdges(ctx context.Context, workspaceID int64) (map[int64][]int64, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) (map[int64][]int64, error) {
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT td.goal_id, td.depends_on_id
+			FROM task_deps td
+			JOIN goals g ON td.goal_id = g.id
+			WHERE g.workspace_id = ?
+			ORDER BY td.goal_id ASC, td.depends_on_id ASC`, workspaceID)
+		if err != nil {
+			return nil, wrapErr(EntityGoal, "list dependency edges", 0, err)
+		}
+		defer rows.Close()
+
+		edges := make(map[int64][]int64)
+		for rows.Next() {
+			var goalID, dependsOnID int64
+			if err := rows.Scan(&goalID, &dependsOnID); err != nil {
+				return nil, wrapErr(EntityGoal, "list dependency edges", 0, err)
+			}
+			edges[goalID] = append(edges[goalID], dependsOnID)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapErr(EntityGoal, "list dependency edges", 0, err)
+		}
+		return edges, nil
+	})
+}
+
+// GetDependencyGoals returns the goals in the workspace that take part in a
+// dependency, on either side.
+func (d *Database) GetDependencyGoals(ctx context.Context, workspaceID int64) ([]models.Goal, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM goals
+		WHERE workspace_id = ? AND id IN (SELECT goal_id FROM task_deps UNION SELECT depends_on_id FROM task_deps)
+		ORDER BY id ASC`, goalColumnsWithSprint)
+	return d.queryGoals(ctx, "list dependency goals", query, workspaceID)
+}
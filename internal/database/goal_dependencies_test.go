@@ -69,3 +69,49 @@ func addGoalForDependencyTest(t *testing.T, db *Database, ctx context.Context, w
 	t.Fatalf("expected to find goal %q", name)
 	return 0
 }
+
+func TestDependencyGraphQueries(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) == 0 {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	sprintID := sprints[0].ID
+	goalA := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal A")
+	goalB := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal B")
+	goalC := addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Goal C")
+	addGoalForDependencyTest(t, db, ctx, wsID, sprintID, "Unrelated")
+	if err := db.SetGoalDependencies(ctx, goalC, []int64{goalA, goalB}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+
+	edges, err := db.GetDependencyEdges(ctx, wsID)
+	if err != nil {
+		t.Fatalf("GetDependencyEdges failed: %v", err)
+	}
+	if len(edges) != 1 || len(edges[goalC]) != 2 || edges[goalC][0] != goalA || edges[goalC][1] != goalB {
+		t.Fatalf("unexpected edges %v", edges)
+	}
+	goals, err := db.GetDependencyGoals(ctx, wsID)
+	if err != nil {
+		t.Fatalf("GetDependencyGoals failed: %v", err)
+	}
+	if len(goals) != 3 {
+		t.Fatalf("expected the 3 linked goals, got %d", len(goals))
+	}
+	other, err := db.CreateWorkspace(ctx, "Other", "other")
+	if err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	if edges, err := db.GetDependencyEdges(ctx, other); err != nil || len(edges) != 0 {
+		t.Fatalf("expected no edges in another workspace, got %v, %v", edges, err)
+	}
+}
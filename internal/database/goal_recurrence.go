@@ -2,53 +2,330 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
 	"github.com/akyairhashvil/SSPT/internal/util"
 )
 
-func (d *Database) regenerateRecurringGoal(ctx context.Context, goalID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		var g models.Goal
-		err := d.DB.QueryRowContext(ctx, `
-			SELECT id, description, workspace_id, sprint_id, notes, priority, effort, tags, recurrence_rule
-			FROM goals WHERE id = ?`, goalID).Scan(
-			&g.ID, &g.Description, &g.WorkspaceID, &g.SprintID, &g.Notes, &g.Priority, &g.Effort, &g.Tags, &g.RecurrenceRule,
-		)
+// maxCatchUpInstances caps how many missed occurrences a single series can
+// create in one pass under the catch-up policy.
+const maxCatchUpInstances = 366
+
+// scheduleRecurrence stores the next occurrence of a recurring goal in
+// recurrence_next, counted from the later of its due date and today. The goal
+// holding recurrence_next is the tip of its series; MaterializeRecurringGoals
+// creates the next instance once that day arrives.
+func (d *Database) scheduleRecurrence(ctx context.Context, goalID int64) error {
+	return d.WithTx(ctx, func(tx *sql.Tx) error {
+		return scheduleRecurrenceTx(ctx, tx, goalID)
+	})
+}
+
+func scheduleRecurrenceTx(ctx context.Context, tx *sql.Tx, goalID int64) error {
+	var g models.Goal
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, recurrence_rule, due_date, created_at
+		FROM goals WHERE id = ?`, goalID).Scan(
+		&g.ID, &g.RecurrenceRule, &g.DueDate, &g.CreatedAt,
+	)
+	if err != nil {
+		return wrapErr(EntityGoal, "recurrence", goalID, err)
+	}
+	var next interface{}
+	if g.RecurrenceRule != nil && strings.TrimSpace(*g.RecurrenceRule) != "" {
+		rule, err := util.ParseRecurrence(*g.RecurrenceRule)
 		if err != nil {
-			return wrapErr(EntityGoal, "recurrence", goalID, err)
+			util.LogError("recurring goal has an invalid rule, not scheduling", err)
+		} else {
+			anchor := recurrenceAnchor(g)
+			after := time.Now()
+			if anchor.After(after) {
+				after = anchor
+			}
+			if date, ok := rule.Next(anchor, after); ok {
+				next = date.Format(util.DateLayout)
+			}
+		}
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE goals SET recurrence_next = ? WHERE id = ?", next, goalID)
+	return wrapErr(EntityGoal, "recurrence", goalID, err)
+}
+
+// recurrenceMigratedSetting marks databases whose recurring goals have been
+// given a recurrence_next by scheduleLegacyRecurrence.
+const recurrenceMigratedSetting = "recurrence_next_migrated"
+
+// scheduleLegacyRecurrence runs once per database. Before recurrence_next was
+// filled in on creation, every instance of a series copied its rule and the
+// next one was only scheduled on completion. The newest goal of each such
+// series becomes its tip and is scheduled; older copies lose their rule.
+func (d *Database) scheduleLegacyRecurrence(ctx context.Context) error {
+	if _, ok := d.GetSetting(ctx, recurrenceMigratedSetting); ok {
+		return nil
+	}
+	if _, err := d.DB.ExecContext(ctx, `
+		UPDATE goals SET recurrence_rule = NULL
+		WHERE recurrence_rule IS NOT NULL AND recurrence_next IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM goals newer
+			WHERE newer.recurrence_rule = goals.recurrence_rule
+			  AND newer.description = goals.description
+			  AND newer.workspace_id IS goals.workspace_id
+			  AND newer.id > goals.id)`); err != nil {
+		return err
+	}
+	rows, err := d.DB.QueryContext(ctx, `
+		SELECT id FROM goals
+		WHERE TRIM(COALESCE(recurrence_rule, '')) != '' AND recurrence_next IS NULL
+		  AND status != 'archived'`)
+	if err != nil {
+		return err
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := d.scheduleRecurrence(ctx, id); err != nil {
+			return err
+		}
+	}
+	return d.SetSetting(ctx, recurrenceMigratedSetting, "1")
+}
+
+// recurrenceAnchor is the first day of a goal's own period: its due date for
+// generated instances, or its creation day otherwise.
+func recurrenceAnchor(g models.Goal) time.Time {
+	if g.DueDate != nil {
+		if due, err := time.ParseInLocation(util.DateLayout, *g.DueDate, time.Local); err == nil {
+			return due
+		}
+	}
+	created := g.CreatedAt.Local()
+	return time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// MaterializeRecurringGoals evaluates every recurring series whose next
+// occurrence falls on or before date (YYYY-MM-DD) and creates the due
+// instances, whether or not the previous instance was completed. Occurrences
+// missed while the app was closed are handled by the rule's policy: catch-up
+// creates all of them, skip only creates one due on date, and collapse (the
+// default) creates a single instance for the latest. Instances go to the
+// rule's sprint on that day when it exists and is not completed, otherwise to
+// the backlog. It returns the number of instances created.
+func (d *Database) MaterializeRecurringGoals(ctx context.Context, date string) (int, error) {
+	day, err := time.ParseInLocation(util.DateLayout, date, time.Local)
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "materialize recurrence", 0, err)
+	}
+	created := 0
+	err = d.WithTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, description, workspace_id, notes, priority, effort, tags, links, recurrence_rule, due_date, created_at, recurrence_next
+			FROM goals
+			WHERE recurrence_next IS NOT NULL AND recurrence_next <= ?
+			  AND status != 'archived'
+			ORDER BY recurrence_next, id`, date)
+		if err != nil {
+			return err
+		}
+		type seriesTip struct {
+			goal models.Goal
+			next string
+		}
+		var tips []seriesTip
+		for rows.Next() {
+			var t seriesTip
+			g := &t.goal
+			if err := rows.Scan(&g.ID, &g.Description, &g.WorkspaceID, &g.Notes, &g.Priority, &g.Effort, &g.Tags, &g.Links, &g.RecurrenceRule, &g.DueDate, &g.CreatedAt, &t.next); err != nil {
+				rows.Close()
+				return err
+			}
+			tips = append(tips, t)
 		}
-		if g.RecurrenceRule == nil || strings.TrimSpace(*g.RecurrenceRule) == "" {
-			return nil
+		if err := rows.Close(); err != nil {
+			return err
 		}
-		rule := strings.ToLower(strings.TrimSpace(*g.RecurrenceRule))
-		if rule != "daily" && !strings.HasPrefix(rule, "weekly:") && !strings.HasPrefix(rule, "monthly:") {
-			return nil
+		if err := rows.Err(); err != nil {
+			return err
 		}
 
-		var maxRank int
-		if g.WorkspaceID != nil {
-			var err error
-			maxRank, err = d.getMaxBacklogRank(ctx, *g.WorkspaceID)
+		for _, t := range tips {
+			tip := t.goal
+			rule, err := util.ParseRecurrence(util.Deref(tip.RecurrenceRule))
 			if err != nil {
-				util.LogError("recurring goal rank lookup failed, defaulting to 0", err)
-				maxRank = 0
+				util.LogError("recurring goal has an invalid rule, clearing schedule", err)
+				if _, err := tx.ExecContext(ctx, "UPDATE goals SET recurrence_next = NULL WHERE id = ?", tip.ID); err != nil {
+					return err
+				}
+				continue
+			}
+			anchor := recurrenceAnchor(tip)
+			first, err := time.ParseInLocation(util.DateLayout, t.next, time.Local)
+			if err != nil {
+				first = anchor.AddDate(0, 0, 1)
+			}
+			due := rule.Between(anchor, first.AddDate(0, 0, -1), day)
+			switch rule.Policy() {
+			case util.MissedSkip:
+				var today []time.Time
+				for _, occ := range due {
+					if occ.Format(util.DateLayout) == date {
+						today = append(today, occ)
+					}
+				}
+				due = today
+			case util.MissedCollapse:
+				if len(due) > 1 {
+					due = due[len(due)-1:]
+				}
+			case util.MissedCatchUp:
+				if len(due) > maxCatchUpInstances {
+					due = due[len(due)-maxCatchUpInstances:]
+				}
+			}
+
+			sprintID, err := recurrenceSprint(ctx, tx, tip.WorkspaceID, date, rule.Sprint)
+			if err != nil {
+				return err
+			}
+			tipID := tip.ID
+			tipAnchor := anchor
+			for _, occ := range due {
+				var maxRank int
+				if sprintID != nil {
+					err = tx.QueryRowContext(ctx,
+						"SELECT COALESCE(MAX(rank), 0) FROM goals WHERE sprint_id = ? AND parent_id IS NULL", *sprintID).Scan(&maxRank)
+				} else if tip.WorkspaceID != nil {
+					err = tx.QueryRowContext(ctx,
+						"SELECT COALESCE(MAX(rank), 0) FROM goals WHERE sprint_id IS NULL AND workspace_id = ? AND parent_id IS NULL",
+						*tip.WorkspaceID).Scan(&maxRank)
+				}
+				if err != nil {
+					return err
+				}
+				res, err := tx.ExecContext(ctx, `INSERT INTO goals (workspace_id, description, sprint_id, status, rank, tags, notes, priority, effort, links, recurrence_rule, due_date)
+					VALUES (?, ?, ?, 'pending', ?, ?, ?, ?, ?, ?, ?, ?)`,
+					toNullableArg(tip.WorkspaceID), tip.Description, toNullableArg(sprintID), maxRank+1, tip.Tags, tip.Notes, tip.Priority, tip.Effort, tip.Links, tip.RecurrenceRule, occ.Format(util.DateLayout),
+				)
+				if err != nil {
+					return err
+				}
+				// Only the tip keeps the rule, so editing an older instance
+				// cannot start a second copy of the series.
+				if _, err := tx.ExecContext(ctx, "UPDATE goals SET recurrence_rule = NULL, recurrence_next = NULL WHERE id = ?", tipID); err != nil {
+					return err
+				}
+				if tipID, err = res.LastInsertId(); err != nil {
+					return err
+				}
+				tipAnchor = occ
+				created++
+			}
+
+			// The newest instance (or the old tip, when every missed
+			// occurrence was skipped) carries the series forward.
+			var next interface{}
+			after := day
+			if tipAnchor.After(after) {
+				after = tipAnchor
+			}
+			if occ, ok := rule.Next(tipAnchor, after); ok {
+				next = occ.Format(util.DateLayout)
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE goals SET recurrence_next = ? WHERE id = ?", next, tipID); err != nil {
+				return err
 			}
 		}
-		wsID := toNullableArg(g.WorkspaceID)
-		_, err = d.DB.ExecContext(ctx, `INSERT INTO goals (workspace_id, description, sprint_id, status, rank, tags, notes, priority, effort, recurrence_rule)
-			VALUES (?, ?, NULL, 'pending', ?, ?, ?, ?, ?, ?)`,
-			wsID, g.Description, maxRank+1, g.Tags, g.Notes, g.Priority, g.Effort, g.RecurrenceRule,
-		)
-		return wrapErr(EntityGoal, "recurrence", goalID, err)
+		return nil
 	})
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "materialize recurrence", 0, err)
+	}
+	return created, nil
 }
 
+// recurrenceSprint resolves a rule's sprint number to a sprint on the given
+// date in the goal's workspace. It returns nil when the rule targets the
+// backlog or the sprint does not exist or has already finished.
+func recurrenceSprint(ctx context.Context, tx *sql.Tx, workspaceID *int64, date string, number int) (*int64, error) {
+	if number <= 0 || workspaceID == nil {
+		return nil, nil
+	}
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT s.id FROM sprints s JOIN days d ON d.id = s.day_id
+		WHERE d.date = ? AND s.workspace_id = ? AND s.sprint_number = ? AND s.status != 'completed'`,
+		date, *workspaceID, number).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// UpdateGoalRecurrence stores a recurrence rule in native or RRULE form and
+// schedules its next occurrence. Rules whose occurrences depend on a series
+// start (intervals, counts) are anchored at today when no start is given.
 func (d *Database) UpdateGoalRecurrence(ctx context.Context, goalID int64, rule string) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
-		value := nullableStringIf(rule)
-		_, err := d.DB.ExecContext(ctx, "UPDATE goals SET recurrence_rule = ? WHERE id = ?", value, goalID)
-		return wrapErr(EntityGoal, "update recurrence", goalID, err)
+		normalized, err := normalizeRecurrenceRule(rule, time.Now())
+		if err != nil {
+			return wrapErr(EntityGoal, "update recurrence", goalID, err)
+		}
+		value := nullableStringIf(normalized)
+		if _, err := d.DB.ExecContext(ctx, "UPDATE goals SET recurrence_rule = ? WHERE id = ?", value, goalID); err != nil {
+			return wrapErr(EntityGoal, "update recurrence", goalID, err)
+		}
+		return d.scheduleRecurrence(ctx, goalID)
 	})
 }
+
+// normalizeSeedRecurrence normalizes a seeded rule, keeping unparseable rules
+// verbatim so imports never fail on them; they simply never recur.
+func normalizeSeedRecurrence(rule string) string {
+	normalized, err := normalizeRecurrenceRule(rule, time.Now())
+	if err != nil {
+		return strings.TrimSpace(rule)
+	}
+	return normalized
+}
+
+// scheduleInsertedRecurrence schedules a freshly inserted goal when it was
+// created with a recurrence rule.
+func scheduleInsertedRecurrence(ctx context.Context, tx *sql.Tx, id int64, rule sql.NullString) error {
+	if !rule.Valid {
+		return nil
+	}
+	return scheduleRecurrenceTx(ctx, tx, id)
+}
+
+func normalizeRecurrenceRule(rule string, now time.Time) (string, error) {
+	if strings.TrimSpace(rule) == "" {
+		return "", nil
+	}
+	parsed, err := util.ParseRecurrence(rule)
+	if err != nil {
+		return "", err
+	}
+	if parsed.NeedsAnchor() && parsed.Start.IsZero() {
+		parsed.Start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return parsed.String(), nil
+}
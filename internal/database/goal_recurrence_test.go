@@ -0,0 +1,246 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+func addBacklogGoalForRecurrenceTest(t *testing.T, db *Database, ctx context.Context, wsID int64, name string) int64 {
+	t.Helper()
+	if err := db.AddGoal(ctx, wsID, name, 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	id, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	return id
+}
+
+func TestRecurringGoalMaterializesOnNextOccurrence(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	goalID := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Water plants")
+	if err := db.UpdateGoalRecurrence(ctx, goalID, "daily"); err != nil {
+		t.Fatalf("UpdateGoalRecurrence failed: %v", err)
+	}
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(util.DateLayout)
+	var next string
+	if err := db.DB.QueryRowContext(ctx, "SELECT recurrence_next FROM goals WHERE id = ?", goalID).Scan(&next); err != nil {
+		t.Fatalf("query recurrence_next failed: %v", err)
+	}
+	if next != tomorrow {
+		t.Fatalf("expected next occurrence %s, got %s", tomorrow, next)
+	}
+
+	created, err := db.MaterializeRecurringGoals(ctx, time.Now().Format(util.DateLayout))
+	if err != nil {
+		t.Fatalf("MaterializeRecurringGoals failed: %v", err)
+	}
+	if created != 0 {
+		t.Fatalf("expected nothing due today, got %d", created)
+	}
+	// The previous instance is still pending; the next one appears anyway.
+	created, err = db.MaterializeRecurringGoals(ctx, tomorrow)
+	if err != nil {
+		t.Fatalf("MaterializeRecurringGoals failed: %v", err)
+	}
+	if created != 1 {
+		t.Fatalf("expected one instance, got %d", created)
+	}
+
+	backlog, err := db.GetBacklogGoals(ctx, wsID)
+	if err != nil {
+		t.Fatalf("GetBacklogGoals failed: %v", err)
+	}
+	found := false
+	for _, g := range backlog {
+		if g.ID != goalID && g.Description == "Water plants" {
+			found = true
+			if g.DueDate == nil || *g.DueDate != tomorrow {
+				t.Fatalf("expected due date %s, got %v", tomorrow, g.DueDate)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected recurring instance in backlog")
+	}
+	var oldRule *string
+	if err := db.DB.QueryRowContext(ctx, "SELECT recurrence_rule FROM goals WHERE id = ?", goalID).Scan(&oldRule); err != nil {
+		t.Fatalf("query recurrence_rule failed: %v", err)
+	}
+	if oldRule != nil {
+		t.Fatalf("expected the rule to move to the new instance, old goal still has %q", *oldRule)
+	}
+	if created, _ := db.MaterializeRecurringGoals(ctx, tomorrow); created != 0 {
+		t.Fatalf("expected instance to be created once, got %d more", created)
+	}
+}
+
+func TestMigrateSchedulesLegacyRecurringGoals(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	older := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Water plants")
+	newer := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Water plants")
+	if _, err := db.DB.ExecContext(ctx,
+		"UPDATE goals SET recurrence_rule = 'daily', recurrence_next = NULL WHERE id IN (?, ?)", older, newer); err != nil {
+		t.Fatalf("seed legacy rule failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, "DELETE FROM settings WHERE key = ?", recurrenceMigratedSetting); err != nil {
+		t.Fatalf("reset setting failed: %v", err)
+	}
+	if err := db.migrate(ctx); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+
+	var rule, next *string
+	if err := db.DB.QueryRowContext(ctx, "SELECT recurrence_rule, recurrence_next FROM goals WHERE id = ?", older).Scan(&rule, &next); err != nil {
+		t.Fatalf("query older goal failed: %v", err)
+	}
+	if rule != nil || next != nil {
+		t.Fatalf("expected older copy to lose its rule, got rule %v next %v", rule, next)
+	}
+	if err := db.DB.QueryRowContext(ctx, "SELECT recurrence_rule, recurrence_next FROM goals WHERE id = ?", newer).Scan(&rule, &next); err != nil {
+		t.Fatalf("query newer goal failed: %v", err)
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(util.DateLayout)
+	if rule == nil || next == nil || *next != tomorrow {
+		t.Fatalf("expected newest copy scheduled for %s, got rule %v next %v", tomorrow, rule, next)
+	}
+}
+
+func TestMaterializeRecurringGoalsMissedPolicies(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().Format(util.DateLayout)
+	missedSince := time.Now().AddDate(0, 0, -3).Format(util.DateLayout)
+
+	cases := []struct {
+		rule    string
+		created int
+	}{
+		{"daily:missed=catchup", 4},
+		{"daily:missed=collapse", 1},
+		{"daily", 1},
+		{"daily:missed=skip", 1},
+		{"weekly:" + strings.ToLower(time.Now().AddDate(0, 0, -2).Weekday().String()[:3]) + ";missed=skip", 0},
+	}
+	for _, tc := range cases {
+		db := setupTestDB(t, ctx)
+		wsID, err := db.EnsureDefaultWorkspace(ctx)
+		if err != nil {
+			t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+		}
+		goalID := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Stand-up notes")
+		if err := db.UpdateGoalRecurrence(ctx, goalID, tc.rule); err != nil {
+			t.Fatalf("UpdateGoalRecurrence(%q) failed: %v", tc.rule, err)
+		}
+		if _, err := db.DB.ExecContext(ctx,
+			"UPDATE goals SET created_at = datetime('now', '-10 days'), recurrence_next = ? WHERE id = ?", missedSince, goalID); err != nil {
+			t.Fatalf("backdate goal failed: %v", err)
+		}
+		created, err := db.MaterializeRecurringGoals(ctx, today)
+		if err != nil {
+			t.Fatalf("MaterializeRecurringGoals(%q) failed: %v", tc.rule, err)
+		}
+		if created != tc.created {
+			t.Fatalf("rule %q: expected %d instances, got %d", tc.rule, tc.created, created)
+		}
+
+		var scheduled int
+		if err := db.DB.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM goals WHERE recurrence_next IS NOT NULL AND recurrence_next > ?", today).Scan(&scheduled); err != nil {
+			t.Fatalf("count scheduled failed: %v", err)
+		}
+		if scheduled != 1 {
+			t.Fatalf("rule %q: expected exactly one series tip scheduled after today, got %d", tc.rule, scheduled)
+		}
+		if tc.created > 0 {
+			var latest string
+			if err := db.DB.QueryRowContext(ctx, "SELECT MAX(due_date) FROM goals").Scan(&latest); err != nil {
+				t.Fatalf("query due date failed: %v", err)
+			}
+			if latest != today {
+				t.Fatalf("rule %q: expected latest instance due today, got %s", tc.rule, latest)
+			}
+		}
+	}
+}
+
+func TestBootstrapDayPlacesRecurringGoalInSprint(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	goalID := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Inbox zero")
+	if err := db.UpdateGoalRecurrence(ctx, goalID, "daily:sprint=2"); err != nil {
+		t.Fatalf("UpdateGoalRecurrence failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx,
+		"UPDATE goals SET created_at = datetime('now', '-1 days'), recurrence_next = ? WHERE id = ?",
+		time.Now().Format(util.DateLayout), goalID); err != nil {
+		t.Fatalf("backdate goal failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 2); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	goals, err := db.GetGoalsForSprint(ctx, sprints[1].ID)
+	if err != nil {
+		t.Fatalf("GetGoalsForSprint failed: %v", err)
+	}
+	if len(goals) != 1 || goals[0].Description != "Inbox zero" {
+		t.Fatalf("expected recurring instance in sprint 2, got %+v", goals)
+	}
+}
+
+func TestUpdateGoalRecurrenceNormalizesRules(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	goalID := addBacklogGoalForRecurrenceTest(t, db, ctx, wsID, "Payroll")
+
+	if err := db.UpdateGoalRecurrence(ctx, goalID, "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"); err != nil {
+		t.Fatalf("UpdateGoalRecurrence failed: %v", err)
+	}
+	goal, err := db.GetGoalByID(ctx, goalID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	want := "weekly:mon;interval=2;start=" + time.Now().Format(util.DateLayout)
+	if goal.RecurrenceRule == nil || *goal.RecurrenceRule != want {
+		t.Fatalf("expected rule %q, got %v", want, goal.RecurrenceRule)
+	}
+
+	if err := db.UpdateGoalRecurrence(ctx, goalID, "fortnightly"); err == nil {
+		t.Fatalf("expected invalid rule to be rejected")
+	}
+
+	export, err := db.GetAllGoalsExport(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGoalsExport failed: %v", err)
+	}
+	if len(export) != 1 || export[0].RRule == nil || !strings.HasPrefix(*export[0].RRule, "RRULE:FREQ=WEEKLY;INTERVAL=2") {
+		t.Fatalf("expected RRULE in export, got %+v", export)
+	}
+}
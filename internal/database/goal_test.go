@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
@@ -81,6 +82,54 @@ func TestMoveGoalToSprint(t *testing.T) {
 	}
 }
 
+func TestMoveGoalsIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) == 0 {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	var ids []int64
+	for _, desc := range []string{"First", "Second"} {
+		if err := db.AddGoal(ctx, wsID, desc, 0); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+		id, err := db.GetLastGoalID(ctx)
+		if err != nil {
+			t.Fatalf("GetLastGoalID failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := db.DB.ExecContext(ctx, fmt.Sprintf(`CREATE TRIGGER refuse_move BEFORE UPDATE OF sprint_id ON goals
+		WHEN NEW.id = %d BEGIN SELECT RAISE(ABORT, 'refused'); END`, ids[1])); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	moves := []GoalMove{{GoalID: ids[0], SprintID: sprints[0].ID}, {GoalID: ids[1], SprintID: sprints[0].ID}}
+	if err := db.MoveGoals(ctx, moves); err == nil {
+		t.Fatalf("expected the refused move to fail the batch")
+	}
+	if goals, _ := db.GetGoalsForSprint(ctx, sprints[0].ID); len(goals) != 0 {
+		t.Fatalf("expected no goal moved after a failed batch, got %d", len(goals))
+	}
+
+	if _, err := db.DB.ExecContext(ctx, "DROP TRIGGER refuse_move"); err != nil {
+		t.Fatalf("drop trigger failed: %v", err)
+	}
+	if err := db.MoveGoals(ctx, moves); err != nil {
+		t.Fatalf("MoveGoals failed: %v", err)
+	}
+	if goals, _ := db.GetGoalsForSprint(ctx, sprints[0].ID); len(goals) != 2 {
+		t.Fatalf("expected both goals moved, got %d", len(goals))
+	}
+}
+
 func TestUpdateGoalStatusCompleted(t *testing.T) {
 	ctx := context.Background()
 	db := setupTestDB(t, ctx)
@@ -113,3 +162,47 @@ func TestUpdateGoalStatusCompleted(t *testing.T) {
 		t.Fatalf("expected completed_at to be set")
 	}
 }
+
+func TestUpdateGoalNotesAndLinks(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.AddGoal(ctx, wsID, "Write spec", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+
+	if err := db.UpdateGoalNotes(ctx, goalID, "# Plan\n- outline\n"); err != nil {
+		t.Fatalf("UpdateGoalNotes failed: %v", err)
+	}
+	if err := db.SetGoalLinks(ctx, goalID, []string{" https://b.example ", "", "/tmp/a.md"}); err != nil {
+		t.Fatalf("SetGoalLinks failed: %v", err)
+	}
+	goal, err := db.GetGoalByID(ctx, goalID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Notes == nil || *goal.Notes != "# Plan\n- outline" {
+		t.Fatalf("unexpected notes: %v", goal.Notes)
+	}
+	if goal.Links == nil || *goal.Links != `["/tmp/a.md","https://b.example"]` {
+		t.Fatalf("unexpected links: %v", goal.Links)
+	}
+
+	if err := db.UpdateGoalNotes(ctx, goalID, "  \n"); err != nil {
+		t.Fatalf("UpdateGoalNotes clear failed: %v", err)
+	}
+	goal, err = db.GetGoalByID(ctx, goalID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Notes != nil {
+		t.Fatalf("expected notes cleared, got %q", *goal.Notes)
+	}
+}
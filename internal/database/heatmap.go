@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+// HeatmapDay is one cell of the activity heatmap.
+type HeatmapDay struct {
+	Date      string
+	Focus     time.Duration
+	Completed int
+}
+
+// GetHeatmap returns the focused sprint time and the number of goals
+// completed on each day from from to to (YYYY-MM-DD), summed over the given
+// workspaces. Every date of the range is included.
+func (d *Database) GetHeatmap(ctx context.Context, workspaceIDs []int64, from, to string) ([]HeatmapDay, error) {
+	dates, index, err := periodDates(from, to)
+	if err != nil {
+		return nil, wrapErr(EntitySprint, "heatmap", 0, err)
+	}
+	days := make([]HeatmapDay, len(dates))
+	for i, date := range dates {
+		days[i].Date = date
+	}
+	if len(workspaceIDs) == 0 {
+		return days, nil
+	}
+
+	placeholders, args := inWorkspaces(workspaceIDs, from, to)
+	err = d.withDBContext(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		err := d.scanPeriodSprints(ctx, workspaceIDs, from, to, func(date string, s models.Sprint) {
+			days[index[date]].Focus += s.FocusedDuration(now)
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT date(completed_at, 'localtime') AS day, COUNT(1)
+			FROM goals
+			WHERE workspace_id IN (`+placeholders+`) AND completed_at IS NOT NULL
+				AND date(completed_at, 'localtime') BETWEEN ? AND ?
+			GROUP BY day`, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var date string
+			var count int
+			if err := rows.Scan(&date, &count); err != nil {
+				return err
+			}
+			days[index[date]].Completed = count
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, wrapErr(EntitySprint, "heatmap", 0, err)
+	}
+	return days, nil
+}
@@ -2,24 +2,57 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
 )
 
+// AddJournalEntry stores a journal entry; #hashtags in the content become
+// its tags.
 func (d *Database) AddJournalEntry(ctx context.Context, dayID int64, workspaceID int64, sprintID *int64, goalID *int64, content string) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "INSERT INTO journal_entries (day_id, workspace_id, sprint_id, goal_id, content) VALUES (?, ?, ?, ?, ?)", dayID, workspaceID, sprintID, goalID, content)
+		tags := util.TagsToJSON(util.ExtractTags(content))
+		_, err := d.DB.ExecContext(ctx, "INSERT INTO journal_entries (day_id, workspace_id, sprint_id, goal_id, content, tags) VALUES (?, ?, ?, ?, ?, ?)", dayID, workspaceID, sprintID, goalID, content, tags)
 		return wrapErr(EntityJournal, OpAdd, 0, err)
 	})
 }
 
+// UpdateJournalEntry replaces an entry's content and re-extracts its tags.
+func (d *Database) UpdateJournalEntry(ctx context.Context, entryID int64, content string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		if strings.TrimSpace(content) == "" {
+			return wrapErr(EntityJournal, OpUpdate, entryID, fmt.Errorf("journal entry cannot be empty"))
+		}
+		tags := util.TagsToJSON(util.ExtractTags(content))
+		res, err := d.DB.ExecContext(ctx, "UPDATE journal_entries SET content = ?, tags = ? WHERE id = ?", content, tags, entryID)
+		if err != nil {
+			return wrapErr(EntityJournal, OpUpdate, entryID, err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return wrapErr(EntityJournal, OpUpdate, entryID, sql.ErrNoRows)
+		}
+		return nil
+	})
+}
+
+// DeleteJournalEntry removes a journal entry.
+func (d *Database) DeleteJournalEntry(ctx context.Context, entryID int64) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "DELETE FROM journal_entries WHERE id = ?", entryID)
+		return wrapErr(EntityJournal, OpDelete, entryID, err)
+	})
+}
+
 func (d *Database) GetJournalEntries(ctx context.Context, dayID int64, workspaceID int64) ([]models.JournalEntry, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]models.JournalEntry, error) {
 		rows, err := d.DB.QueryContext(ctx, `
-			SELECT id, day_id, workspace_id, sprint_id, goal_id, content, created_at 
-			FROM journal_entries 
+			SELECT id, day_id, workspace_id, sprint_id, goal_id, content, tags, created_at
+			FROM journal_entries
 			WHERE day_id = ? AND workspace_id = ?
-			ORDER BY created_at ASC`, dayID, workspaceID)
+			ORDER BY created_at ASC, id ASC`, dayID, workspaceID)
 		if err != nil {
 			return nil, wrapErr(EntityJournal, OpList, 0, err)
 		}
@@ -28,7 +61,7 @@ func (d *Database) GetJournalEntries(ctx context.Context, dayID int64, workspace
 		var entries []models.JournalEntry
 		for rows.Next() {
 			var e models.JournalEntry
-			if err := rows.Scan(&e.ID, &e.DayID, &e.WorkspaceID, &e.SprintID, &e.GoalID, &e.Content, &e.CreatedAt); err != nil {
+			if err := rows.Scan(&e.ID, &e.DayID, &e.WorkspaceID, &e.SprintID, &e.GoalID, &e.Content, &e.Tags, &e.CreatedAt); err != nil {
 				return nil, wrapErr(EntityJournal, OpList, 0, err)
 			}
 			entries = append(entries, e)
@@ -39,3 +72,96 @@ func (d *Database) GetJournalEntries(ctx context.Context, dayID int64, workspace
 		return entries, nil
 	})
 }
+
+// JournalFilter narrows a cross-day journal search. Zero values match
+// everything; From and To are inclusive YYYY-MM-DD dates.
+type JournalFilter struct {
+	WorkspaceIDs []int64
+	Tags         []string
+	GoalID       int64
+	SprintNumber int
+	From         string
+	To           string
+	Text         []string
+	Limit        int
+}
+
+// JournalRecord is a journal entry with the context needed to show it
+// outside its day.
+type JournalRecord struct {
+	models.JournalEntry
+	Date            string
+	Workspace       string
+	SprintNumber    int // 0 when the entry was not logged during a sprint
+	GoalDescription string
+}
+
+// SearchJournal lists journal entries across days, newest first.
+func (d *Database) SearchJournal(ctx context.Context, filter JournalFilter) ([]JournalRecord, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]JournalRecord, error) {
+		var where []string
+		var args []interface{}
+		if len(filter.WorkspaceIDs) > 0 {
+			where = append(where, "j.workspace_id IN ("+strings.TrimRight(strings.Repeat("?,", len(filter.WorkspaceIDs)), ",")+")")
+			for _, id := range filter.WorkspaceIDs {
+				args = append(args, id)
+			}
+		}
+		for _, tag := range filter.Tags {
+			where = append(where, "j.tags LIKE ?")
+			args = append(args, `%"`+strings.ToLower(tag)+`"%`)
+		}
+		if filter.GoalID > 0 {
+			where = append(where, "j.goal_id = ?")
+			args = append(args, filter.GoalID)
+		}
+		if filter.SprintNumber > 0 {
+			where = append(where, "s.sprint_number = ?")
+			args = append(args, filter.SprintNumber)
+		}
+		if filter.From != "" {
+			where = append(where, "d.date >= ?")
+			args = append(args, filter.From)
+		}
+		if filter.To != "" {
+			where = append(where, "d.date <= ?")
+			args = append(args, filter.To)
+		}
+		for _, term := range filter.Text {
+			where = append(where, "j.content LIKE ?")
+			args = append(args, "%"+term+"%")
+		}
+		query := `
+			SELECT j.id, j.day_id, j.workspace_id, j.sprint_id, j.goal_id, j.content, j.tags, j.created_at,
+				d.date, COALESCE(w.name, ''), COALESCE(s.sprint_number, 0), COALESCE(g.description, '')
+			FROM journal_entries j
+			JOIN days d ON d.id = j.day_id
+			LEFT JOIN workspaces w ON w.id = j.workspace_id
+			LEFT JOIN sprints s ON s.id = j.sprint_id
+			LEFT JOIN goals g ON g.id = j.goal_id`
+		if len(where) > 0 {
+			query += " WHERE " + strings.Join(where, " AND ")
+		}
+		query += " ORDER BY d.date DESC, j.created_at DESC, j.id DESC"
+		if filter.Limit > 0 {
+			query += " LIMIT ?"
+			args = append(args, filter.Limit)
+		}
+		rows, err := d.DB.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, wrapErr(EntityJournal, "search", 0, err)
+		}
+		defer rows.Close()
+
+		var out []JournalRecord
+		for rows.Next() {
+			var r JournalRecord
+			if err := rows.Scan(&r.ID, &r.DayID, &r.WorkspaceID, &r.SprintID, &r.GoalID, &r.Content, &r.Tags, &r.CreatedAt,
+				&r.Date, &r.Workspace, &r.SprintNumber, &r.GoalDescription); err != nil {
+				return nil, wrapErr(EntityJournal, "search", 0, err)
+			}
+			out = append(out, r)
+		}
+		return out, wrapErr(EntityJournal, "search", 0, rows.Err())
+	})
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+func TestJournalEntryEditDeleteTags(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	dayID := db.CheckCurrentDay(ctx)
+	if err := db.AddJournalEntry(ctx, dayID, wsID, nil, nil, "Shipped #release\nthen #Retro"); err != nil {
+		t.Fatalf("AddJournalEntry failed: %v", err)
+	}
+	if err := db.AddJournalEntry(ctx, dayID, wsID, nil, nil, "Second"); err != nil {
+		t.Fatalf("AddJournalEntry failed: %v", err)
+	}
+	entries, err := db.GetJournalEntries(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetJournalEntries failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Tags == nil {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if tags := util.JSONToTags(*entries[0].Tags); len(tags) != 2 || tags[0] != "release" || tags[1] != "retro" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+
+	if err := db.UpdateJournalEntry(ctx, entries[0].ID, "Rewritten #focus"); err != nil {
+		t.Fatalf("UpdateJournalEntry failed: %v", err)
+	}
+	if err := db.UpdateJournalEntry(ctx, entries[0].ID, "  "); err == nil {
+		t.Fatalf("expected empty content to be rejected")
+	}
+	if err := db.UpdateJournalEntry(ctx, 9999, "Missing"); err == nil {
+		t.Fatalf("expected missing entry to fail")
+	}
+	if err := db.DeleteJournalEntry(ctx, entries[1].ID); err != nil {
+		t.Fatalf("DeleteJournalEntry failed: %v", err)
+	}
+	entries, err = db.GetJournalEntries(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetJournalEntries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "Rewritten #focus" || *entries[0].Tags != `["focus"]` {
+		t.Fatalf("unexpected entries after edit/delete: %+v", entries)
+	}
+}
+
+func TestSearchJournal(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	otherWS, err := db.CreateWorkspace(ctx, "Work", "work")
+	if err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	addDay := func(date string) int64 {
+		t.Helper()
+		res, err := db.DB.ExecContext(ctx, "INSERT INTO days (date) VALUES (?)", date)
+		if err != nil {
+			t.Fatalf("insert day failed: %v", err)
+		}
+		id, _ := res.LastInsertId()
+		return id
+	}
+	day1 := addDay("2026-10-01")
+	day2 := addDay("2026-10-02")
+	res, err := db.DB.ExecContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, 2)", day2, wsID)
+	if err != nil {
+		t.Fatalf("insert sprint failed: %v", err)
+	}
+	sprintID, _ := res.LastInsertId()
+	if err := db.AddGoal(ctx, wsID, "Ship release", sprintID); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, _ := db.GetLastGoalID(ctx)
+
+	entries := []struct {
+		day      int64
+		ws       int64
+		sprintID *int64
+		goalID   *int64
+		text     string
+	}{
+		{day1, wsID, nil, nil, "Planning #retro"},
+		{day2, wsID, &sprintID, &goalID, "Shipped it #ship"},
+		{day2, otherWS, nil, nil, "Work notes #retro"},
+	}
+	for _, e := range entries {
+		if err := db.AddJournalEntry(ctx, e.day, e.ws, e.sprintID, e.goalID, e.text); err != nil {
+			t.Fatalf("AddJournalEntry failed: %v", err)
+		}
+	}
+
+	all, err := db.SearchJournal(ctx, JournalFilter{})
+	if err != nil {
+		t.Fatalf("SearchJournal failed: %v", err)
+	}
+	if len(all) != 3 || all[2].Content != "Planning #retro" {
+		t.Fatalf("expected all entries newest first, got %+v", all)
+	}
+	cases := []struct {
+		name   string
+		filter JournalFilter
+		want   []string
+	}{
+		{"tag", JournalFilter{Tags: []string{"retro"}}, []string{"Work notes #retro", "Planning #retro"}},
+		{"workspace", JournalFilter{WorkspaceIDs: []int64{wsID}, Tags: []string{"retro"}}, []string{"Planning #retro"}},
+		{"goal", JournalFilter{GoalID: goalID}, []string{"Shipped it #ship"}},
+		{"sprint", JournalFilter{SprintNumber: 2}, []string{"Shipped it #ship"}},
+		{"range", JournalFilter{From: "2026-10-01", To: "2026-10-01"}, []string{"Planning #retro"}},
+		{"text", JournalFilter{Text: []string{"notes"}}, []string{"Work notes #retro"}},
+	}
+	for _, tc := range cases {
+		got, err := db.SearchJournal(ctx, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: SearchJournal failed: %v", tc.name, err)
+		}
+		var contents []string
+		for _, r := range got {
+			contents = append(contents, r.Content)
+		}
+		if strings.Join(contents, "|") != strings.Join(tc.want, "|") {
+			t.Fatalf("%s: got %v, want %v", tc.name, contents, tc.want)
+		}
+	}
+	goalEntries, _ := db.SearchJournal(ctx, JournalFilter{GoalID: goalID})
+	if r := goalEntries[0]; r.Date != "2026-10-02" || r.SprintNumber != 2 || r.GoalDescription != "Ship release" || r.Workspace == "" {
+		t.Fatalf("unexpected record context: %+v", r)
+	}
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// FocusTrendPoint is the average retrospective rating for one day.
+type FocusTrendPoint struct {
+	Date    string
+	Focus   float64
+	Energy  float64
+	Sprints int
+}
+
+// SaveSprintRetro stores a retrospective on a sprint and mirrors it into a
+// #retro journal entry linked to the sprint. Saving again updates both.
+func (d *Database) SaveSprintRetro(ctx context.Context, sprintID int64, retro models.SprintRetro) error {
+	if retro.FocusQuality < 1 || retro.FocusQuality > 5 || retro.Energy < 1 || retro.Energy > 5 {
+		return wrapErr(EntitySprint, "retro", sprintID, fmt.Errorf("ratings must be between 1 and 5"))
+	}
+	retro.WentWell = strings.TrimSpace(retro.WentWell)
+	retro.Blockers = strings.TrimSpace(retro.Blockers)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		var dayID int64
+		var workspaceID sql.NullInt64
+		var number int
+		var journalID sql.NullInt64
+		if err := tx.QueryRowContext(ctx,
+			"SELECT day_id, workspace_id, sprint_number, retro_journal_id FROM sprints WHERE id = ?", sprintID,
+		).Scan(&dayID, &workspaceID, &number, &journalID); err != nil {
+			return err
+		}
+		content := FormatSprintRetro(number, retro)
+		tags := util.TagsToJSON(util.ExtractTags(content))
+		updated := int64(0)
+		if journalID.Valid {
+			res, err := tx.ExecContext(ctx, "UPDATE journal_entries SET content = ?, tags = ? WHERE id = ?", content, tags, journalID.Int64)
+			if err != nil {
+				return err
+			}
+			updated, _ = res.RowsAffected()
+		}
+		if updated == 0 {
+			res, err := tx.ExecContext(ctx,
+				"INSERT INTO journal_entries (day_id, workspace_id, sprint_id, content, tags) VALUES (?, ?, ?, ?, ?)",
+				dayID, workspaceID, sprintID, content, tags)
+			if err != nil {
+				return err
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			journalID = sql.NullInt64{Int64: id, Valid: true}
+		}
+		_, err := tx.ExecContext(ctx, `
+			UPDATE sprints SET retro_focus = ?, retro_energy = ?, retro_went_well = ?, retro_blockers = ?, retro_journal_id = ?
+			WHERE id = ?`,
+			retro.FocusQuality, retro.Energy, nullableStringIf(retro.WentWell), nullableStringIf(retro.Blockers), journalID.Int64, sprintID)
+		return err
+	})
+	return wrapErr(EntitySprint, "retro", sprintID, err)
+}
+
+// FormatSprintRetro renders a retrospective as journal text.
+func FormatSprintRetro(sprintNumber int, retro models.SprintRetro) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Retro S%d: focus %d/5, energy %d/5", sprintNumber, retro.FocusQuality, retro.Energy)
+	if retro.WentWell != "" {
+		fmt.Fprintf(&b, "\nWent well: %s", retro.WentWell)
+	}
+	if retro.Blockers != "" {
+		fmt.Fprintf(&b, "\nBlocked: %s", retro.Blockers)
+	}
+	b.WriteString(" #retro")
+	return b.String()
+}
+
+// GetFocusTrend averages retrospective ratings per day over the last days
+// days (including today), oldest first. Days without retros are omitted.
+func (d *Database) GetFocusTrend(ctx context.Context, workspaceID int64, days int) ([]FocusTrendPoint, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]FocusTrendPoint, error) {
+		since := time.Now().AddDate(0, 0, -(days - 1)).Format(util.DateLayout)
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT d.date, AVG(s.retro_focus), AVG(s.retro_energy), COUNT(*)
+			FROM sprints s JOIN days d ON d.id = s.day_id
+			WHERE s.workspace_id = ? AND s.retro_focus IS NOT NULL AND d.date >= ?
+			GROUP BY d.date
+			ORDER BY d.date ASC`, workspaceID, since)
+		if err != nil {
+			return nil, wrapErr(EntitySprint, "focus trend", 0, err)
+		}
+		defer rows.Close()
+
+		var points []FocusTrendPoint
+		for rows.Next() {
+			var p FocusTrendPoint
+			var energy sql.NullFloat64
+			if err := rows.Scan(&p.Date, &p.Focus, &energy, &p.Sprints); err != nil {
+				return nil, wrapErr(EntitySprint, "focus trend", 0, err)
+			}
+			p.Energy = energy.Float64
+			points = append(points, p)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapErr(EntitySprint, "focus trend", 0, err)
+		}
+		return points, nil
+	})
+}
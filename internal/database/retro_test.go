@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+func TestSaveSprintRetro(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 1); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	dayID := db.CheckCurrentDay(ctx)
+	sprints, err := db.GetSprints(ctx, dayID, wsID)
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("GetSprints failed: %v (%d sprints)", err, len(sprints))
+	}
+	sprintID := sprints[0].ID
+
+	if err := db.SaveSprintRetro(ctx, sprintID, models.SprintRetro{FocusQuality: 6, Energy: 3}); err == nil {
+		t.Fatalf("expected out-of-range rating to be rejected")
+	}
+	retro := models.SprintRetro{FocusQuality: 4, Energy: 2, WentWell: "Deep work", Blockers: "Slack pings"}
+	if err := db.SaveSprintRetro(ctx, sprintID, retro); err != nil {
+		t.Fatalf("SaveSprintRetro failed: %v", err)
+	}
+	sprints, err = db.GetSprints(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	if sprints[0].Retro == nil || *sprints[0].Retro != retro {
+		t.Fatalf("expected retro %+v, got %+v", retro, sprints[0].Retro)
+	}
+
+	entries, err := db.GetJournalEntries(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetJournalEntries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].SprintID == nil || *entries[0].SprintID != sprintID {
+		t.Fatalf("expected one journal entry linked to the sprint, got %+v", entries)
+	}
+	if !strings.Contains(entries[0].Content, "focus 4/5") || !strings.Contains(entries[0].Content, "Blocked: Slack pings") {
+		t.Fatalf("unexpected retro journal content %q", entries[0].Content)
+	}
+	if entries[0].Tags == nil || !strings.Contains(*entries[0].Tags, "retro") {
+		t.Fatalf("expected retro tag, got %v", entries[0].Tags)
+	}
+
+	// Saving again updates the linked entry instead of adding another.
+	retro.FocusQuality = 2
+	retro.Blockers = ""
+	if err := db.SaveSprintRetro(ctx, sprintID, retro); err != nil {
+		t.Fatalf("SaveSprintRetro update failed: %v", err)
+	}
+	entries, err = db.GetJournalEntries(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetJournalEntries failed: %v", err)
+	}
+	if len(entries) != 1 || !strings.Contains(entries[0].Content, "focus 2/5") || strings.Contains(entries[0].Content, "Blocked") {
+		t.Fatalf("expected updated retro entry, got %+v", entries)
+	}
+
+	trend, err := db.GetFocusTrend(ctx, wsID, 7)
+	if err != nil {
+		t.Fatalf("GetFocusTrend failed: %v", err)
+	}
+	if len(trend) != 1 || trend[0].Focus != 2 || trend[0].Energy != 2 || trend[0].Sprints != 1 {
+		t.Fatalf("unexpected focus trend %+v", trend)
+	}
+}
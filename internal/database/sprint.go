@@ -20,10 +20,11 @@ func (d *Database) GetAdjacentDay(ctx context.Context, currentDayID int64, direc
 	}
 	result, err := withDBContextResult(d, ctx, func(ctx context.Context) (dayResult, error) {
 		var query string
+		// Days planned ahead may be created out of order, so step by date.
 		if direction < 0 {
-			query = "SELECT id, date FROM days WHERE id < ? ORDER BY id DESC LIMIT 1"
+			query = "SELECT id, date FROM days WHERE date < (SELECT date FROM days WHERE id = ?) ORDER BY date DESC LIMIT 1"
 		} else {
-			query = "SELECT id, date FROM days WHERE id > ? ORDER BY id ASC LIMIT 1"
+			query = "SELECT id, date FROM days WHERE date > (SELECT date FROM days WHERE id = ?) ORDER BY date ASC LIMIT 1"
 		}
 		var id int64
 		var date string
@@ -62,45 +63,141 @@ func (d *Database) CheckCurrentDay(ctx context.Context) int64 {
 	return id
 }
 
+// GetDayIDByDate returns the ID of the day record for date (YYYY-MM-DD).
+func (d *Database) GetDayIDByDate(ctx context.Context, date string) (int64, bool, error) {
+	type dayResult struct {
+		id int64
+		ok bool
+	}
+	result, err := withDBContextResult(d, ctx, func(ctx context.Context) (dayResult, error) {
+		var id int64
+		err := d.DB.QueryRowContext(ctx, "SELECT id FROM days WHERE date = ?", date).Scan(&id)
+		if err == sql.ErrNoRows {
+			return dayResult{}, nil
+		}
+		if err != nil {
+			return dayResult{}, wrapErr(EntitySprint, "get day", 0, err)
+		}
+		return dayResult{id: id, ok: true}, nil
+	})
+	return result.id, result.ok, err
+}
+
 // BootstrapDay creates the day record and pre-allocates the chosen number of sprints for a workspace.
 
 // BootstrapDay creates the day record and pre-allocates the chosen number of sprints for a workspace.
+// Sprints planned ahead for today are kept and only topped up to numSprints.
 func (d *Database) BootstrapDay(ctx context.Context, workspaceID int64, numSprints int) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
-		tx, err := d.DB.BeginTx(ctx, nil)
-		if err != nil {
+		dateStr := time.Now().Format("2006-01-02")
+		if _, err := d.ensureDay(ctx, workspaceID, dateStr, numSprints, false); err != nil {
 			return wrapErr(EntitySprint, "bootstrap", 0, err)
 		}
+		if _, err := d.MaterializeRecurringGoals(ctx, dateStr); err != nil {
+			util.LogError("materialize recurring goals", err)
+		}
+		return nil
+	})
+}
 
-		dateStr := time.Now().Format("2006-01-02")
-		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO days (date) VALUES (?)", dateStr)
-		if err != nil {
-			return wrapErr(EntitySprint, "bootstrap", 0, rollbackWithLog(tx, fmt.Errorf("failed to ensure day: %w", err)))
+// ensureDay creates the day record for date if needed and adds sprints to
+// the workspace until it has numSprints. A planned day is marked as started
+// when it is ensured with planned false.
+func (d *Database) ensureDay(ctx context.Context, workspaceID int64, date string, numSprints int, planned bool) (int64, error) {
+	var dayID int64
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		dayID, err = ensureDayTx(ctx, tx, workspaceID, date, numSprints, planned)
+		return err
+	})
+	return dayID, err
+}
+
+func ensureDayTx(ctx context.Context, tx *sql.Tx, workspaceID int64, date string, numSprints int, planned bool) (int64, error) {
+	if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO days (date, planned) VALUES (?, ?)", date, planned); err != nil {
+		return 0, fmt.Errorf("failed to ensure day: %w", err)
+	}
+	if !planned {
+		if _, err := tx.ExecContext(ctx, "UPDATE days SET planned = 0, started_at = CURRENT_TIMESTAMP WHERE date = ? AND planned = 1", date); err != nil {
+			return 0, fmt.Errorf("failed to start day: %w", err)
 		}
+	}
+	var dayID int64
+	if err := tx.QueryRowContext(ctx, "SELECT id FROM days WHERE date = ?", date).Scan(&dayID); err != nil {
+		return 0, err
+	}
 
-		var dayID int64
-		err = tx.QueryRowContext(ctx, "SELECT id FROM days WHERE date = ?", dateStr).Scan(&dayID)
-		if err != nil {
-			return wrapErr(EntitySprint, "bootstrap", 0, rollbackWithLog(tx, err))
+	var existing int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(sprint_number), 0) FROM sprints WHERE day_id = ? AND workspace_id = ?",
+		dayID, workspaceID,
+	).Scan(&existing); err != nil {
+		return 0, err
+	}
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, ?)")
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for i := existing + 1; i <= numSprints; i++ {
+		if _, err := stmt.ExecContext(ctx, dayID, workspaceID, i); err != nil {
+			return 0, fmt.Errorf("failed to insert sprint %d: %w", i, err)
 		}
+	}
+	return dayID, nil
+}
+
+// PlanDay creates a future day (YYYY-MM-DD) with numSprints sprints for the
+// workspace ahead of time. Sprints that already exist are kept.
+func (d *Database) PlanDay(ctx context.Context, workspaceID int64, date string, numSprints int) (int64, error) {
+	if _, err := time.Parse(util.DateLayout, date); err != nil {
+		return 0, wrapErr(EntitySprint, "plan day", 0, err)
+	}
+	if numSprints < 1 || numSprints > maxSprintsPerDay {
+		return 0, wrapErr(EntitySprint, "plan day", 0, fmt.Errorf("sprint count must be between 1 and %d", maxSprintsPerDay))
+	}
+	dayID, err := d.ensureDay(ctx, workspaceID, date, numSprints, true)
+	return dayID, wrapErr(EntitySprint, "plan day", 0, err)
+}
 
-		stmt, err := tx.PrepareContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, ?)")
+// StartPlannedDay marks a day that was planned ahead as started and reports
+// whether it was still planned.
+func (d *Database) StartPlannedDay(ctx context.Context, dayID int64) (bool, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) (bool, error) {
+		res, err := d.DB.ExecContext(ctx, "UPDATE days SET planned = 0, started_at = CURRENT_TIMESTAMP WHERE id = ? AND planned = 1", dayID)
 		if err != nil {
-			return wrapErr(EntitySprint, "bootstrap", 0, rollbackWithLog(tx, err))
+			return false, wrapErr(EntitySprint, "start day", dayID, err)
 		}
-		defer stmt.Close()
+		n, err := res.RowsAffected()
+		return n > 0, wrapErr(EntitySprint, "start day", dayID, err)
+	})
+}
 
-		for i := 1; i <= numSprints; i++ {
-			_, err = stmt.ExecContext(ctx, dayID, workspaceID, i)
-			if err != nil {
-				return wrapErr(EntitySprint, "bootstrap", 0, rollbackWithLog(tx, fmt.Errorf("failed to insert sprint %d: %w", i, err)))
-			}
+// MoveGoalToDate moves a goal into the first open sprint of date, planning
+// the day with one sprint when the workspace has none there yet. It returns
+// the sprint number.
+func (d *Database) MoveGoalToDate(ctx context.Context, goalID int64, workspaceID int64, date string) (int, error) {
+	dayID, err := d.PlanDay(ctx, workspaceID, date, 1)
+	if err != nil {
+		return 0, wrapErr(EntityGoal, "move to date", goalID, err)
+	}
+	return withDBContextResult(d, ctx, func(ctx context.Context) (int, error) {
+		var sprintID int64
+		var number int
+		err := d.DB.QueryRowContext(ctx, `
+			SELECT id, sprint_number FROM sprints
+			WHERE day_id = ? AND workspace_id = ? AND status != 'completed'
+			ORDER BY sprint_number ASC LIMIT 1`, dayID, workspaceID).Scan(&sprintID, &number)
+		if err == sql.ErrNoRows {
+			err = fmt.Errorf("no open sprint on %s", date)
 		}
-
-		if err := tx.Commit(); err != nil {
-			return wrapErr(EntitySprint, "bootstrap", 0, err)
+		if err != nil {
+			return 0, wrapErr(EntityGoal, "move to date", goalID, err)
 		}
-		return nil
+		if _, err := d.DB.ExecContext(ctx, "UPDATE goals SET sprint_id = ? WHERE id = ?", sprintID, goalID); err != nil {
+			return 0, wrapErr(EntityGoal, "move to date", goalID, err)
+		}
+		return number, nil
 	})
 }
 
@@ -112,8 +209,8 @@ func (d *Database) GetDay(ctx context.Context, id int64) (models.Day, error) {
 		var day models.Day
 		var dateStr string
 
-		err := d.DB.QueryRowContext(ctx, "SELECT id, date, started_at FROM days WHERE id = ?", id).
-			Scan(&day.ID, &dateStr, &day.StartedAt)
+		err := d.DB.QueryRowContext(ctx, "SELECT id, date, started_at, COALESCE(planned, 0) FROM days WHERE id = ?", id).
+			Scan(&day.ID, &dateStr, &day.StartedAt, &day.Planned)
 
 		if err != nil {
 			return day, err
@@ -129,7 +226,8 @@ func (d *Database) GetDay(ctx context.Context, id int64) (models.Day, error) {
 func (d *Database) GetSprints(ctx context.Context, dayID int64, workspaceID int64) ([]models.Sprint, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]models.Sprint, error) {
 		rows, err := d.DB.QueryContext(ctx, `
-			SELECT id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds
+			SELECT id, day_id, workspace_id, sprint_number, status, start_time, end_time, last_paused_at, elapsed_seconds, label,
+				retro_focus, retro_energy, retro_went_well, retro_blockers, COALESCE(corrected, 0)
 			FROM sprints 
 			WHERE day_id = ? AND workspace_id = ?
 			ORDER BY sprint_number ASC`, dayID, workspaceID)
@@ -142,6 +240,8 @@ func (d *Database) GetSprints(ctx context.Context, dayID int64, workspaceID int6
 		var sprints []models.Sprint
 		for rows.Next() {
 			var s models.Sprint
+			var retroFocus, retroEnergy sql.NullInt64
+			var retroWell, retroBlockers sql.NullString
 			err := rows.Scan(
 				&s.ID,
 				&s.DayID,
@@ -152,10 +252,24 @@ func (d *Database) GetSprints(ctx context.Context, dayID int64, workspaceID int6
 				&s.EndTime,
 				&s.LastPausedAt,
 				&s.ElapsedSeconds,
+				&s.Label,
+				&retroFocus,
+				&retroEnergy,
+				&retroWell,
+				&retroBlockers,
+				&s.Corrected,
 			)
 			if err != nil {
 				return nil, wrapErr(EntitySprint, "list", 0, err)
 			}
+			if retroFocus.Valid || retroEnergy.Valid {
+				s.Retro = &models.SprintRetro{
+					FocusQuality: int(retroFocus.Int64),
+					Energy:       int(retroEnergy.Int64),
+					WentWell:     retroWell.String,
+					Blockers:     retroBlockers.String,
+				}
+			}
 			sprints = append(sprints, s)
 		}
 		if err := rows.Err(); err != nil {
@@ -212,6 +326,14 @@ func (d *Database) ResetSprint(ctx context.Context, sprintID int64) error {
 	})
 }
 
+// SetSprintLabel names a sprint; an empty label clears it.
+func (d *Database) SetSprintLabel(ctx context.Context, sprintID int64, label string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "UPDATE sprints SET label = ? WHERE id = ?", nullableStringIf(label), sprintID)
+		return wrapErr(EntitySprint, "label", sprintID, err)
+	})
+}
+
 func (d *Database) AppendSprint(ctx context.Context, dayID int64, workspaceID int64) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
 		var lastSprintNum int
@@ -219,8 +341,8 @@ func (d *Database) AppendSprint(ctx context.Context, dayID int64, workspaceID in
 		if err != nil {
 			return wrapErr(EntitySprint, "append", 0, err)
 		}
-		if lastSprintNum >= 8 {
-			return wrapErr(EntitySprint, "append", 0, fmt.Errorf("max sprints reached (8)"))
+		if lastSprintNum >= maxSprintsPerDay {
+			return wrapErr(EntitySprint, "append", 0, fmt.Errorf("max sprints reached (%d)", maxSprintsPerDay))
 		}
 
 		_, err = d.DB.ExecContext(ctx, "INSERT INTO sprints (day_id, workspace_id, sprint_number) VALUES (?, ?, ?)", dayID, workspaceID, lastSprintNum+1)
@@ -289,12 +411,18 @@ func (d *Database) GetSprintGoalCounts(ctx context.Context, sprintID int64) (int
 	return result.total, result.completed, nil
 }
 
+// MovePendingToBacklog sends the unfinished goals of a sprint to the backlog
+// and records the sprint they came from for the carry-over review.
 func (d *Database) MovePendingToBacklog(ctx context.Context, sprintID int64) error {
-	return d.withDBContext(ctx, func(ctx context.Context) error {
-		_, err := d.DB.ExecContext(ctx, "UPDATE goals SET sprint_id = NULL WHERE sprint_id = ? AND status != 'completed'", sprintID)
-		if err != nil {
-			return wrapErr(EntitySprint, "move pending", sprintID, err)
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO carry_overs (goal_id, sprint_id)
+			SELECT id, sprint_id FROM goals
+			WHERE sprint_id = ? AND status NOT IN ('completed', 'archived')`, sprintID); err != nil {
+			return err
 		}
-		return nil
+		_, err := tx.ExecContext(ctx, "UPDATE goals SET sprint_id = NULL WHERE sprint_id = ? AND status != 'completed'", sprintID)
+		return err
 	})
+	return wrapErr(EntitySprint, "move pending", sprintID, err)
 }
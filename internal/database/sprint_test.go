@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
 )
@@ -52,3 +53,142 @@ func TestSprintLifecycle(t *testing.T) {
 		t.Fatalf("expected end time to be set")
 	}
 }
+
+func TestGetWeekSummary(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 2); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	dayID := db.CheckCurrentDay(ctx)
+	sprints, err := db.GetSprints(ctx, dayID, wsID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	for _, desc := range []string{"Done", "Open"} {
+		if err := db.AddGoal(ctx, wsID, desc, sprints[0].ID); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+	}
+	doneID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.UpdateGoalStatus(ctx, doneID, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if err := db.PauseSprint(ctx, sprints[0].ID, 600); err != nil {
+		t.Fatalf("PauseSprint failed: %v", err)
+	}
+
+	today := time.Now()
+	start := today.AddDate(0, 0, -1).Format("2006-01-02")
+	week, err := db.GetWeekSummary(ctx, wsID, start)
+	if err != nil {
+		t.Fatalf("GetWeekSummary failed: %v", err)
+	}
+	if len(week) != 7 || week[0].Date != start {
+		t.Fatalf("expected 7 days from %s, got %+v", start, week)
+	}
+	if week[0].DayID != 0 || len(week[0].Sprints) != 0 {
+		t.Fatalf("expected yesterday to be empty, got %+v", week[0])
+	}
+	day := week[1]
+	if day.DayID != dayID || len(day.Sprints) != 2 {
+		t.Fatalf("expected today with 2 sprints, got %+v", day)
+	}
+	first := day.Sprints[0]
+	if first.Completed != 1 || first.Total != 2 || first.Status != models.StatusPaused {
+		t.Fatalf("unexpected first sprint %+v", first)
+	}
+	if got := first.FocusedDuration(today); got != 10*time.Minute {
+		t.Fatalf("expected 10m focused, got %v", got)
+	}
+	if day.Sprints[1].Total != 0 {
+		t.Fatalf("expected empty second sprint, got %+v", day.Sprints[1])
+	}
+}
+
+func TestPlanDayAhead(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	// Plan tomorrow before today exists, so its ID is lower.
+	tomorrowID, err := db.PlanDay(ctx, wsID, tomorrow, 2)
+	if err != nil {
+		t.Fatalf("PlanDay failed: %v", err)
+	}
+	if _, err := db.PlanDay(ctx, wsID, tomorrow, 3); err != nil {
+		t.Fatalf("PlanDay again failed: %v", err)
+	}
+	if sprints, err := db.GetSprints(ctx, tomorrowID, wsID); err != nil || len(sprints) != 3 {
+		t.Fatalf("expected 3 planned sprints, got %d (%v)", len(sprints), err)
+	}
+	if day, err := db.GetDay(ctx, tomorrowID); err != nil || !day.Planned {
+		t.Fatalf("expected planned day, got %+v (%v)", day, err)
+	}
+	if _, err := db.PlanDay(ctx, wsID, tomorrow, 9); err == nil {
+		t.Fatalf("expected sprint count to be validated")
+	}
+
+	if _, err := db.PlanDay(ctx, wsID, today, 2); err != nil {
+		t.Fatalf("PlanDay today failed: %v", err)
+	}
+	if err := db.BootstrapDay(ctx, wsID, 3); err != nil {
+		t.Fatalf("BootstrapDay failed: %v", err)
+	}
+	todayID := db.CheckCurrentDay(ctx)
+	if sprints, err := db.GetSprints(ctx, todayID, wsID); err != nil || len(sprints) != 3 {
+		t.Fatalf("expected planned sprints to be picked up, got %d (%v)", len(sprints), err)
+	}
+	if day, err := db.GetDay(ctx, todayID); err != nil || day.Planned {
+		t.Fatalf("expected bootstrapped day to be started, got %+v (%v)", day, err)
+	}
+	if nextID, date, err := db.GetAdjacentDay(ctx, todayID, 1); err != nil || nextID != tomorrowID || date != tomorrow {
+		t.Fatalf("expected next day %d, got %d %s (%v)", tomorrowID, nextID, date, err)
+	}
+	if _, _, err := db.GetAdjacentDay(ctx, tomorrowID, 1); err == nil {
+		t.Fatalf("expected no day after tomorrow")
+	}
+
+	if err := db.AddGoal(ctx, wsID, "Later", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	dayAfter := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	number, err := db.MoveGoalToDate(ctx, goalID, wsID, dayAfter)
+	if err != nil || number != 1 {
+		t.Fatalf("MoveGoalToDate returned %d (%v)", number, err)
+	}
+	dayAfterID, ok, err := db.GetDayIDByDate(ctx, dayAfter)
+	if err != nil || !ok {
+		t.Fatalf("expected %s to be planned (%v)", dayAfter, err)
+	}
+	sprints, err := db.GetSprints(ctx, dayAfterID, wsID)
+	if err != nil || len(sprints) != 1 {
+		t.Fatalf("expected one sprint on %s, got %d (%v)", dayAfter, len(sprints), err)
+	}
+	if goal, err := db.GetGoalByID(ctx, goalID); err != nil || goal.SprintID == nil || *goal.SprintID != sprints[0].ID {
+		t.Fatalf("expected goal in the planned sprint, got %+v (%v)", goal.SprintID, err)
+	}
+
+	if started, err := db.StartPlannedDay(ctx, tomorrowID); err != nil || !started {
+		t.Fatalf("expected planned day to start, got %v (%v)", started, err)
+	}
+	if started, err := db.StartPlannedDay(ctx, tomorrowID); err != nil || started {
+		t.Fatalf("expected day to start only once, got %v (%v)", started, err)
+	}
+}
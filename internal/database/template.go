@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// TemplateGoal is one node of a goal template: the seed for a goal plus its
+// subtask tree.
+type TemplateGoal struct {
+	GoalSeed
+	Subtasks []TemplateGoal `json:"subtasks,omitempty"`
+}
+
+// GoalTemplate is a named, reusable goal with subtasks.
+type GoalTemplate struct {
+	ID   int64
+	Name string
+	Goal TemplateGoal
+}
+
+// Count returns the number of goals the template creates, root included.
+func (t TemplateGoal) Count() int {
+	n := 1
+	for _, sub := range t.Subtasks {
+		n += sub.Count()
+	}
+	return n
+}
+
+var placeholderRegex = regexp.MustCompile(`\{\{\s*(date|weekday|week|month|year)\s*(?:([+-])\s*(\d+))?\s*\}\}`)
+
+// ExpandTemplatePlaceholders replaces {{date}}, {{weekday}}, {{week}},
+// {{month}} and {{year}} with values for the given day. {{date+N}} and
+// {{date-N}} offset the date by N days; the offset applies to the other
+// placeholders as well.
+func ExpandTemplatePlaceholders(text string, at time.Time) string {
+	return placeholderRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := placeholderRegex.FindStringSubmatch(match)
+		day := at
+		if parts[2] != "" {
+			offset, err := strconv.Atoi(parts[3])
+			if err != nil {
+				return match
+			}
+			if parts[2] == "-" {
+				offset = -offset
+			}
+			day = day.AddDate(0, 0, offset)
+		}
+		switch parts[1] {
+		case "date":
+			return day.Format(util.DateLayout)
+		case "weekday":
+			return day.Format("Monday")
+		case "week":
+			_, week := day.ISOWeek()
+			return fmt.Sprintf("%02d", week)
+		case "month":
+			return day.Format("January")
+		case "year":
+			return day.Format("2006")
+		}
+		return match
+	})
+}
+
+func expandTemplateSeed(seed GoalSeed, at time.Time) GoalSeed {
+	seed.Description = ExpandTemplatePlaceholders(seed.Description, at)
+	seed.Notes = ExpandTemplatePlaceholders(seed.Notes, at)
+	links := make([]string, 0, len(seed.Links))
+	for _, link := range seed.Links {
+		links = append(links, ExpandTemplatePlaceholders(link, at))
+	}
+	seed.Links = links
+	return seed
+}
+
+// SaveGoalTemplate creates or replaces the template with the given name.
+func (d *Database) SaveGoalTemplate(ctx context.Context, name string, goal TemplateGoal) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return wrapErr(EntityTemplate, OpUpdate, 0, fmt.Errorf("template name required"))
+		}
+		if strings.TrimSpace(goal.Description) == "" {
+			return wrapErr(EntityTemplate, OpUpdate, 0, fmt.Errorf("template %q has no description", name))
+		}
+		payload, err := json.Marshal(goal)
+		if err != nil {
+			return wrapErr(EntityTemplate, OpUpdate, 0, err)
+		}
+		_, err = d.DB.ExecContext(ctx, `INSERT INTO goal_templates (name, payload) VALUES (?, ?)
+			ON CONFLICT(name) DO UPDATE SET payload = excluded.payload`, name, string(payload))
+		return wrapErr(EntityTemplate, OpUpdate, 0, err)
+	})
+}
+
+// GetGoalTemplates lists all templates ordered by name.
+func (d *Database) GetGoalTemplates(ctx context.Context) ([]GoalTemplate, error) {
+	return withDBContextResult(d, ctx, func(ctx context.Context) ([]GoalTemplate, error) {
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, name, payload FROM goal_templates ORDER BY name COLLATE NOCASE ASC")
+		if err != nil {
+			return nil, wrapErr(EntityTemplate, OpList, 0, err)
+		}
+		defer rows.Close()
+		var out []GoalTemplate
+		for rows.Next() {
+			tpl, err := scanGoalTemplate(rows)
+			if err != nil {
+				return nil, wrapErr(EntityTemplate, OpList, 0, err)
+			}
+			out = append(out, tpl)
+		}
+		return out, wrapErr(EntityTemplate, OpList, 0, rows.Err())
+	})
+}
+
+// GetGoalTemplate looks up a template by name, case-insensitively.
+func (d *Database) GetGoalTemplate(ctx context.Context, name string) (GoalTemplate, bool, error) {
+	type templateResult struct {
+		tpl GoalTemplate
+		ok  bool
+	}
+	result, err := withDBContextResult(d, ctx, func(ctx context.Context) (templateResult, error) {
+		row := d.DB.QueryRowContext(ctx, "SELECT id, name, payload FROM goal_templates WHERE name = ? COLLATE NOCASE", strings.TrimSpace(name))
+		tpl, err := scanGoalTemplate(row)
+		if err == sql.ErrNoRows {
+			return templateResult{}, nil
+		}
+		if err != nil {
+			return templateResult{}, wrapErr(EntityTemplate, OpGet, 0, err)
+		}
+		return templateResult{tpl: tpl, ok: true}, nil
+	})
+	return result.tpl, result.ok, err
+}
+
+// DeleteGoalTemplate removes a template by name.
+func (d *Database) DeleteGoalTemplate(ctx context.Context, name string) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "DELETE FROM goal_templates WHERE name = ? COLLATE NOCASE", strings.TrimSpace(name))
+		return wrapErr(EntityTemplate, OpDelete, 0, err)
+	})
+}
+
+// InstantiateGoalTemplate creates the template's goal and subtask tree in the
+// given sprint (0 = backlog), expanding placeholders for the given day. The
+// tree is created in one transaction. It returns the ID of the top-level goal.
+func (d *Database) InstantiateGoalTemplate(ctx context.Context, tpl GoalTemplate, workspaceID, sprintID int64, at time.Time) (int64, error) {
+	var rootID int64
+	err := d.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		rootID, err = instantiateGoalTemplateTx(ctx, tx, tpl, workspaceID, sprintID, at)
+		return err
+	})
+	if err != nil {
+		return 0, wrapErr(EntityTemplate, "instantiate", tpl.ID, err)
+	}
+	return rootID, nil
+}
+
+func instantiateGoalTemplateTx(ctx context.Context, tx *sql.Tx, tpl GoalTemplate, workspaceID, sprintID int64, at time.Time) (int64, error) {
+	rootID, err := addGoalDetailedTx(ctx, tx, workspaceID, sprintID, expandTemplateSeed(tpl.Goal.GoalSeed, at))
+	if err != nil {
+		return 0, err
+	}
+	if rootID == 0 {
+		return 0, fmt.Errorf("template %q has no description", tpl.Name)
+	}
+	return rootID, addTemplateSubtasks(ctx, tx, rootID, tpl.Goal.Subtasks, at)
+}
+
+func addTemplateSubtasks(ctx context.Context, tx *sql.Tx, parentID int64, subtasks []TemplateGoal, at time.Time) error {
+	for _, sub := range subtasks {
+		id, err := addSubtaskDetailedTx(ctx, tx, parentID, expandTemplateSeed(sub.GoalSeed, at))
+		if err != nil {
+			return err
+		}
+		if err := addTemplateSubtasks(ctx, tx, id, sub.Subtasks, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanGoalTemplate(row interface{ Scan(...interface{}) error }) (GoalTemplate, error) {
+	var tpl GoalTemplate
+	var payload string
+	if err := row.Scan(&tpl.ID, &tpl.Name, &payload); err != nil {
+		return GoalTemplate{}, err
+	}
+	if err := json.Unmarshal([]byte(payload), &tpl.Goal); err != nil {
+		return GoalTemplate{}, fmt.Errorf("template %q: %w", tpl.Name, err)
+	}
+	return tpl, nil
+}
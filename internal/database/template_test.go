@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExpandTemplatePlaceholders(t *testing.T) {
+	at := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local)
+	got := ExpandTemplatePlaceholders("Review {{date}} due {{ date+3 }} ({{weekday}}, W{{week}} {{month}} {{year}}) {{unknown}}", at)
+	want := "Review 2026-10-16 due 2026-10-19 (Friday, W42 October 2026) {{unknown}}"
+	if got != want {
+		t.Fatalf("ExpandTemplatePlaceholders() = %q, want %q", got, want)
+	}
+	if got := ExpandTemplatePlaceholders("{{date-16}}", at); got != "2026-09-30" {
+		t.Fatalf("expected negative offset, got %q", got)
+	}
+}
+
+func TestGoalTemplateInstantiate(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	goal := TemplateGoal{
+		GoalSeed: GoalSeed{Description: "Release {{date}}", Tags: []string{"release"}, Priority: 2, Effort: "L", Notes: "Cut on {{weekday}}"},
+		Subtasks: []TemplateGoal{
+			{GoalSeed: GoalSeed{Description: "Changelog"}},
+			{
+				GoalSeed: GoalSeed{Description: "Publish"},
+				Subtasks: []TemplateGoal{{GoalSeed: GoalSeed{Description: "Tag build"}}},
+			},
+		},
+	}
+	if err := db.SaveGoalTemplate(ctx, "Release", goal); err != nil {
+		t.Fatalf("SaveGoalTemplate failed: %v", err)
+	}
+	if err := db.SaveGoalTemplate(ctx, "  ", goal); err == nil {
+		t.Fatalf("expected empty name to be rejected")
+	}
+	goal.Priority = 1
+	if err := db.SaveGoalTemplate(ctx, "release", goal); err != nil {
+		t.Fatalf("SaveGoalTemplate overwrite failed: %v", err)
+	}
+	templates, err := db.GetGoalTemplates(ctx)
+	if err != nil {
+		t.Fatalf("GetGoalTemplates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Goal.Priority != 1 || templates[0].Goal.Count() != 4 {
+		t.Fatalf("unexpected templates: %+v", templates)
+	}
+
+	tpl, ok, err := db.GetGoalTemplate(ctx, "RELEASE")
+	if err != nil || !ok {
+		t.Fatalf("GetGoalTemplate failed: ok=%v err=%v", ok, err)
+	}
+	at := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local)
+	rootID, err := db.InstantiateGoalTemplate(ctx, tpl, wsID, 0, at)
+	if err != nil {
+		t.Fatalf("InstantiateGoalTemplate failed: %v", err)
+	}
+	root, err := db.GetGoalByID(ctx, rootID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if root.Description != "Release 2026-10-16" || root.Priority != 1 {
+		t.Fatalf("unexpected root goal: %+v", root)
+	}
+	var notes string
+	if err := db.DB.QueryRowContext(ctx, "SELECT notes FROM goals WHERE id = ?", rootID).Scan(&notes); err != nil {
+		t.Fatalf("query notes failed: %v", err)
+	}
+	if notes != "Cut on Friday" {
+		t.Fatalf("expected expanded notes, got %q", notes)
+	}
+	var subtasks, nested int
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM goals WHERE parent_id = ?", rootID).Scan(&subtasks); err != nil {
+		t.Fatalf("count subtasks failed: %v", err)
+	}
+	if err := db.DB.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM goals g JOIN goals p ON g.parent_id = p.id WHERE p.parent_id = ? AND g.description = 'Tag build'", rootID).Scan(&nested); err != nil {
+		t.Fatalf("count nested failed: %v", err)
+	}
+	if subtasks != 2 || nested != 1 {
+		t.Fatalf("expected 2 subtasks and 1 nested, got %d and %d", subtasks, nested)
+	}
+
+	// A failure partway through the tree leaves no goals behind.
+	if _, err := db.DB.ExecContext(ctx, `CREATE TRIGGER refuse_tag BEFORE INSERT ON goals
+		WHEN NEW.description = 'Tag build' BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	var before, after int
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM goals").Scan(&before); err != nil {
+		t.Fatalf("count goals failed: %v", err)
+	}
+	if _, err := db.InstantiateGoalTemplate(ctx, tpl, wsID, 0, at); err == nil {
+		t.Fatalf("expected the refused subtask to fail the instantiation")
+	}
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM goals").Scan(&after); err != nil {
+		t.Fatalf("count goals failed: %v", err)
+	}
+	if after != before {
+		t.Fatalf("expected no goals from a failed instantiation, got %d new", after-before)
+	}
+
+	if err := db.DeleteGoalTemplate(ctx, "release"); err != nil {
+		t.Fatalf("DeleteGoalTemplate failed: %v", err)
+	}
+	if _, ok, _ := db.GetGoalTemplate(ctx, "Release"); ok {
+		t.Fatalf("expected template to be deleted")
+	}
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeGroup selects how a time report aggregates its entries.
+type TimeGroup int
+
+const (
+	TimeByGoal TimeGroup = iota
+	TimeByWorkspace
+	TimeByTag
+	TimeByPriority
+)
+
+// TimeGroups lists the groupings in the order the report cycles through them.
+var TimeGroups = []TimeGroup{TimeByGoal, TimeByWorkspace, TimeByTag, TimeByPriority}
+
+func (g TimeGroup) String() string {
+	switch g {
+	case TimeByWorkspace:
+		return "workspace"
+	case TimeByTag:
+		return "tag"
+	case TimeByPriority:
+		return "priority"
+	default:
+		return "goal"
+	}
+}
+
+// ParseTimeGroup maps a grouping name to its TimeGroup.
+func ParseTimeGroup(name string) (TimeGroup, bool) {
+	for _, g := range TimeGroups {
+		if strings.EqualFold(name, g.String()) {
+			return g, true
+		}
+	}
+	return TimeByGoal, false
+}
+
+// TimeEntry is the task timer time of a top-level goal, its subtasks
+// included, within a time report.
+type TimeEntry struct {
+	GoalID      int64
+	Description string
+	WorkspaceID int64
+	Workspace   string
+	Tags        []string
+	Priority    int
+	Duration    time.Duration
+}
+
+// TimeRow is one line of a grouped time report.
+type TimeRow struct {
+	Key      string
+	Goals    int
+	Duration time.Duration
+}
+
+// TimeReport holds the task timer time logged from From to To
+// (YYYY-MM-DD), one entry per top-level goal.
+type TimeReport struct {
+	From    string
+	To      string
+	Entries []TimeEntry
+}
+
+// Total is the time of all entries.
+func (r TimeReport) Total() time.Duration {
+	var total time.Duration
+	for _, e := range r.Entries {
+		total += e.Duration
+	}
+	return total
+}
+
+// Group aggregates the entries by g, longest first. A goal counts toward
+// each of its tags, so tag rows can add up to more than the total; goals
+// without tags are grouped under "untagged".
+func (r TimeReport) Group(g TimeGroup) []TimeRow {
+	index := make(map[string]int)
+	var rows []TimeRow
+	add := func(key string, d time.Duration) {
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, TimeRow{Key: key})
+		}
+		rows[i].Goals++
+		rows[i].Duration += d
+	}
+	for _, e := range r.Entries {
+		switch g {
+		case TimeByWorkspace:
+			add(e.Workspace, e.Duration)
+		case TimeByTag:
+			if len(e.Tags) == 0 {
+				add("untagged", e.Duration)
+			}
+			for _, tag := range e.Tags {
+				add("#"+tag, e.Duration)
+			}
+		case TimeByPriority:
+			add("P"+strconv.Itoa(e.Priority), e.Duration)
+		default:
+			add(e.Description, e.Duration)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Duration != rows[j].Duration {
+			return rows[i].Duration > rows[j].Duration
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
+
+func recordTaskSession(ctx context.Context, tx *sql.Tx, goalID int64, started time.Time, seconds int) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO task_sessions (goal_id, started_at, ended_at, seconds) VALUES (?, ?, CURRENT_TIMESTAMP, ?)",
+		goalID, started.UTC().Format(sqlTimeLayout), seconds)
+	return err
+}
+
+// GetTimeReport returns the task timer time of the given workspaces from
+// from to to (YYYY-MM-DD). Each timer session counts on the local day it
+// started, running timers up to now, and subtask time rolls up to its
+// top-level goal.
+func (d *Database) GetTimeReport(ctx context.Context, workspaceIDs []int64, from, to string) (TimeReport, error) {
+	report := TimeReport{From: from, To: to}
+	if _, _, err := periodDates(from, to); err != nil {
+		return report, wrapErr(EntityGoal, "time report", 0, err)
+	}
+	if len(workspaceIDs) == 0 {
+		return report, nil
+	}
+	placeholders, args := inWorkspaces(workspaceIDs, from, to)
+
+	err := d.withDBContext(ctx, func(ctx context.Context) error {
+		rows, err := d.DB.QueryContext(ctx, `
+			WITH RECURSIVE roots(id, root_id) AS (
+				SELECT id, id FROM goals WHERE parent_id IS NULL AND workspace_id IN (`+placeholders+`)
+				UNION ALL
+				SELECT g.id, r.root_id FROM goals g JOIN roots r ON g.parent_id = r.id
+			),
+			sessions(goal_id, started_at, seconds) AS (
+				SELECT goal_id, started_at, seconds FROM task_sessions
+				UNION ALL
+				SELECT id, task_started_at, CAST(strftime('%s', 'now') - strftime('%s', task_started_at) AS INTEGER)
+				FROM goals WHERE task_active = 1 AND task_started_at IS NOT NULL
+			),
+			totals(goal_id, seconds) AS (
+				SELECT r.root_id, SUM(s.seconds)
+				FROM sessions s
+				JOIN roots r ON r.id = s.goal_id
+				WHERE date(s.started_at, 'localtime') BETWEEN ? AND ?
+				GROUP BY r.root_id
+			)
+			SELECT g.id, g.description, g.workspace_id, COALESCE(w.name, ''), COALESCE(g.tags, ''), COALESCE(g.priority, 3), t.seconds
+			FROM totals t
+			JOIN goals g ON g.id = t.goal_id
+			LEFT JOIN workspaces w ON w.id = g.workspace_id
+			WHERE t.seconds > 0
+			ORDER BY t.seconds DESC, g.id ASC`, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var e TimeEntry
+			var tags string
+			var seconds int64
+			if err := rows.Scan(&e.GoalID, &e.Description, &e.WorkspaceID, &e.Workspace, &tags, &e.Priority, &seconds); err != nil {
+				return err
+			}
+			e.Tags = normalizeTags(tags)
+			e.Duration = time.Duration(seconds) * time.Second
+			report.Entries = append(report.Entries, e)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return report, wrapErr(EntityGoal, "time report", 0, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetTimeReport(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	otherID, err := db.CreateWorkspace(ctx, "Client", "client")
+	if err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	addGoal := func(workspaceID int64, seed GoalSeed) int64 {
+		t.Helper()
+		if err := db.AddGoalDetailed(ctx, workspaceID, 0, seed); err != nil {
+			t.Fatalf("AddGoalDetailed failed: %v", err)
+		}
+		id, err := db.GetLastGoalID(ctx)
+		if err != nil {
+			t.Fatalf("GetLastGoalID failed: %v", err)
+		}
+		return id
+	}
+	// track runs a goal's timer for d, started ago before now.
+	track := func(goalID int64, ago, d time.Duration) {
+		t.Helper()
+		if err := db.StartTaskTimer(ctx, goalID); err != nil {
+			t.Fatalf("StartTaskTimer failed: %v", err)
+		}
+		if err := db.PauseTaskTimer(ctx, goalID); err != nil {
+			t.Fatalf("PauseTaskTimer failed: %v", err)
+		}
+		started := time.Now().Add(-ago).UTC().Format(sqlTimeLayout)
+		if _, err := db.DB.ExecContext(ctx, "UPDATE task_sessions SET started_at = ?, seconds = ? WHERE id = (SELECT MAX(id) FROM task_sessions)", started, int(d.Seconds())); err != nil {
+			t.Fatalf("adjusting session failed: %v", err)
+		}
+	}
+
+	api := addGoal(wsID, GoalSeed{Description: "API", Tags: []string{"dev", "client"}, Priority: 1})
+	if err := db.AddSubtask(ctx, "Endpoint", api); err != nil {
+		t.Fatalf("AddSubtask failed: %v", err)
+	}
+	endpoint, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	docs := addGoal(wsID, GoalSeed{Description: "Docs"})
+	meeting := addGoal(otherID, GoalSeed{Description: "Meeting", Tags: []string{"client"}, Priority: 2})
+
+	track(api, time.Minute, time.Hour)
+	track(endpoint, time.Minute, 30*time.Minute)
+	track(docs, 10*24*time.Hour, time.Hour)
+	track(meeting, time.Minute, 15*time.Minute)
+	if err := db.StartTaskTimer(ctx, docs); err != nil {
+		t.Fatalf("StartTaskTimer failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, "UPDATE goals SET task_started_at = ? WHERE id = ?", time.Now().Add(-20*time.Minute).UTC().Format(sqlTimeLayout), docs); err != nil {
+		t.Fatalf("backdating timer failed: %v", err)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	from := time.Now().AddDate(0, 0, -6).Format("2006-01-02")
+	report, err := db.GetTimeReport(ctx, []int64{wsID, otherID}, from, today)
+	if err != nil {
+		t.Fatalf("GetTimeReport failed: %v", err)
+	}
+	if len(report.Entries) != 3 || report.Entries[0].GoalID != api || report.Entries[0].Duration != 90*time.Minute {
+		t.Fatalf("expected subtask time rolled up into API first, got %+v", report.Entries)
+	}
+	if docs := report.Entries[1]; docs.Description != "Docs" || docs.Duration < 20*time.Minute || docs.Duration > 21*time.Minute {
+		t.Fatalf("expected only the running timer of Docs in range, got %+v", docs)
+	}
+	if got := report.Group(TimeByWorkspace); len(got) != 2 || got[1].Key != "Client" || got[1].Duration != 15*time.Minute {
+		t.Fatalf("unexpected workspace rows %+v", got)
+	}
+	tags := report.Group(TimeByTag)
+	if len(tags) != 3 || tags[0].Key != "#client" || tags[0].Goals != 2 || tags[0].Duration != 105*time.Minute {
+		t.Fatalf("unexpected tag rows %+v", tags)
+	}
+	if got := report.Group(TimeByPriority); got[0].Key != "P1" || got[0].Duration != 90*time.Minute {
+		t.Fatalf("unexpected priority rows %+v", got)
+	}
+
+	report, err = db.GetTimeReport(ctx, []int64{otherID}, from, today)
+	if err != nil || len(report.Entries) != 1 || report.Total() != 15*time.Minute {
+		t.Fatalf("expected one workspace only, got %+v (%v)", report.Entries, err)
+	}
+	if _, err := db.GetTimeReport(ctx, []int64{wsID}, today, from); err == nil {
+		t.Fatalf("expected reversed range to be refused")
+	}
+}
+
+func TestMigrateLogsLegacyTaskTime(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	if err := db.AddGoal(ctx, wsID, "Legacy", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, "UPDATE goals SET task_elapsed_seconds = 600 WHERE id = ?", goalID); err != nil {
+		t.Fatalf("setting legacy time failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := db.migrate(ctx); err != nil {
+			t.Fatalf("migrate failed: %v", err)
+		}
+	}
+	today := time.Now().Format("2006-01-02")
+	report, err := db.GetTimeReport(ctx, []int64{wsID}, today, today)
+	if err != nil || report.Total() != 10*time.Minute {
+		t.Fatalf("expected legacy time logged once on its creation day, got %+v (%v)", report.Entries, err)
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+// VelocityDay is the cadence and output of one day of a workspace: the
+// sprints it had and the effort points of the top-level goals completed on
+// it.
+type VelocityDay struct {
+	Date    string
+	Sprints int
+	Points  int
+}
+
+// GetVelocity returns a VelocityDay for every date from from to to
+// (YYYY-MM-DD). Days without sprints count as days off.
+func (d *Database) GetVelocity(ctx context.Context, workspaceID int64, from, to string) ([]VelocityDay, error) {
+	dates, index, err := periodDates(from, to)
+	if err != nil {
+		return nil, wrapErr(EntityGoal, "velocity", 0, err)
+	}
+	days := make([]VelocityDay, len(dates))
+	for i, date := range dates {
+		days[i].Date = date
+	}
+
+	err = d.withDBContext(ctx, func(ctx context.Context) error {
+		err := d.scanPeriodSprints(ctx, []int64{workspaceID}, from, to, func(date string, _ models.Sprint) {
+			days[index[date]].Sprints++
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := d.DB.QueryContext(ctx, `
+			SELECT date(completed_at, 'localtime'), effort
+			FROM goals
+			WHERE workspace_id = ? AND parent_id IS NULL AND completed_at IS NOT NULL
+				AND date(completed_at, 'localtime') BETWEEN ? AND ?`, workspaceID, from, to)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var date string
+			var effort *string
+			if err := rows.Scan(&date, &effort); err != nil {
+				return err
+			}
+			days[index[date]].Points += models.EffortPoints(effort)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, wrapErr(EntityGoal, "velocity", 0, err)
+	}
+	return days, nil
+}
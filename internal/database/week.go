@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// SprintSummary is a sprint with the counts of its top-level goals.
+type SprintSummary struct {
+	models.Sprint
+	Completed int
+	Total     int
+}
+
+// DaySummary is one day of the week view. DayID is 0 for days that were
+// never opened.
+type DaySummary struct {
+	Date    string
+	DayID   int64
+	Sprints []SprintSummary
+}
+
+// GetWeekSummary returns seven days starting at start (YYYY-MM-DD) with the
+// workspace's sprints of each day.
+func (d *Database) GetWeekSummary(ctx context.Context, workspaceID int64, start string) ([]DaySummary, error) {
+	first, err := time.Parse(util.DateLayout, start)
+	if err != nil {
+		return nil, wrapErr(EntitySprint, "week summary", 0, err)
+	}
+	week := make([]DaySummary, 7)
+	index := make(map[string]int, len(week))
+	for i := range week {
+		week[i].Date = first.AddDate(0, 0, i).Format(util.DateLayout)
+		index[week[i].Date] = i
+	}
+	end := week[len(week)-1].Date
+
+	err = d.withDBContext(ctx, func(ctx context.Context) error {
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, date FROM days WHERE date BETWEEN ? AND ?", start, end)
+		if err != nil {
+			return wrapErr(EntitySprint, "week summary", 0, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			var date string
+			if err := rows.Scan(&id, &date); err != nil {
+				return wrapErr(EntitySprint, "week summary", 0, err)
+			}
+			week[index[date]].DayID = id
+		}
+		if err := rows.Err(); err != nil {
+			return wrapErr(EntitySprint, "week summary", 0, err)
+		}
+
+		rows, err = d.DB.QueryContext(ctx, `
+			SELECT dy.date, s.id, s.day_id, s.sprint_number, s.status, s.start_time, s.end_time, s.last_paused_at, s.elapsed_seconds, s.label,
+				COALESCE(s.corrected, 0), COUNT(g.id), COALESCE(SUM(CASE WHEN g.status = 'completed' THEN 1 ELSE 0 END), 0)
+			FROM sprints s
+			JOIN days dy ON dy.id = s.day_id
+			LEFT JOIN goals g ON g.sprint_id = s.id AND g.parent_id IS NULL AND g.status != 'archived'
+			WHERE s.workspace_id = ? AND dy.date BETWEEN ? AND ?
+			GROUP BY s.id
+			ORDER BY dy.date ASC, s.sprint_number ASC`, workspaceID, start, end)
+		if err != nil {
+			return wrapErr(EntitySprint, "week summary", 0, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var date string
+			var s SprintSummary
+			if err := rows.Scan(&date, &s.ID, &s.DayID, &s.SprintNumber, &s.Status, &s.StartTime, &s.EndTime,
+				&s.LastPausedAt, &s.ElapsedSeconds, &s.Label, &s.Corrected, &s.Total, &s.Completed); err != nil {
+				return wrapErr(EntitySprint, "week summary", 0, err)
+			}
+			s.WorkspaceID = &workspaceID
+			week[index[date]].Sprints = append(week[index[date]].Sprints, s)
+		}
+		return wrapErr(EntitySprint, "week summary", 0, rows.Err())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return week, nil
+}
@@ -9,7 +9,7 @@ import (
 
 func (d *Database) GetWorkspaces(ctx context.Context) ([]models.Workspace, error) {
 	return withDBContextResult(d, ctx, func(ctx context.Context) ([]models.Workspace, error) {
-		rows, err := d.DB.QueryContext(ctx, "SELECT id, name, slug, view_mode, theme, show_backlog, show_completed, show_archived FROM workspaces ORDER BY id ASC")
+		rows, err := d.DB.QueryContext(ctx, "SELECT id, name, slug, view_mode, theme, show_backlog, show_completed, show_archived, workflow, board_mode, wip_limits FROM workspaces ORDER BY id ASC")
 		if err != nil {
 			return nil, wrapErr(EntityWorkspace, "list", 0, err)
 		}
@@ -18,11 +18,11 @@ func (d *Database) GetWorkspaces(ctx context.Context) ([]models.Workspace, error
 		var ws []models.Workspace
 		for rows.Next() {
 			var w models.Workspace
-			var viewMode *int64
-			var theme *string
+			var viewMode, boardMode *int64
+			var theme, workflow, wipLimits *string
 			var showBacklog, showCompleted, showArchived *int64
 
-			if err := rows.Scan(&w.ID, &w.Name, &w.Slug, &viewMode, &theme, &showBacklog, &showCompleted, &showArchived); err != nil {
+			if err := rows.Scan(&w.ID, &w.Name, &w.Slug, &viewMode, &theme, &showBacklog, &showCompleted, &showArchived, &workflow, &boardMode, &wipLimits); err != nil {
 				return nil, wrapErr(EntityWorkspace, "list", 0, err)
 			}
 
@@ -52,6 +52,18 @@ func (d *Database) GetWorkspaces(ctx context.Context) ([]models.Workspace, error
 			} else {
 				w.ShowArchived = false
 			}
+			if workflow != nil {
+				w.Workflow = models.WorkflowOrDefault(*workflow)
+			} else {
+				w.Workflow = models.DefaultWorkflow
+			}
+			if boardMode != nil {
+				w.BoardMode = int(*boardMode)
+			}
+			w.WIPLimits = make(map[models.GoalStatus]int)
+			if wipLimits != nil {
+				w.WIPLimits = models.ParseWIPLimits(*wipLimits)
+			}
 
 			ws = append(ws, w)
 		}
@@ -82,6 +94,38 @@ func (d *Database) UpdateWorkspaceTheme(ctx context.Context, workspaceID int64,
 	})
 }
 
+// UpdateWorkspaceWorkflow stores the goal status workflow of a workspace.
+func (d *Database) UpdateWorkspaceWorkflow(ctx context.Context, workspaceID int64, workflow models.Workflow) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "UPDATE workspaces SET workflow = ? WHERE id = ?", workflow.String(), workspaceID)
+		if err != nil {
+			return wrapErr(EntityWorkspace, "update workflow", workspaceID, err)
+		}
+		return nil
+	})
+}
+
+func (d *Database) UpdateWorkspaceBoardMode(ctx context.Context, workspaceID int64, mode int) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "UPDATE workspaces SET board_mode = ? WHERE id = ?", mode, workspaceID)
+		if err != nil {
+			return wrapErr(EntityWorkspace, "update board_mode", workspaceID, err)
+		}
+		return nil
+	})
+}
+
+// UpdateWorkspaceWIPLimits stores the Kanban column limits of a workspace.
+func (d *Database) UpdateWorkspaceWIPLimits(ctx context.Context, workspaceID int64, limits map[models.GoalStatus]int) error {
+	return d.withDBContext(ctx, func(ctx context.Context) error {
+		_, err := d.DB.ExecContext(ctx, "UPDATE workspaces SET wip_limits = ? WHERE id = ?", models.FormatWIPLimits(limits), workspaceID)
+		if err != nil {
+			return wrapErr(EntityWorkspace, "update wip_limits", workspaceID, err)
+		}
+		return nil
+	})
+}
+
 func (d *Database) UpdateWorkspacePaneVisibility(ctx context.Context, workspaceID int64, showBacklog, showCompleted, showArchived bool) error {
 	return d.withDBContext(ctx, func(ctx context.Context) error {
 		backlog := 0
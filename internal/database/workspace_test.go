@@ -2,7 +2,10 @@ package database
 
 import (
 	"context"
+	"strings"
 	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
 )
 
 func TestWorkspaceUpdates(t *testing.T) {
@@ -65,3 +68,93 @@ func TestWorkspaceUpdates(t *testing.T) {
 		t.Fatalf("expected show archived to be true")
 	}
 }
+
+func TestWorkspaceWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t, ctx)
+	wsID, err := db.CreateWorkspace(ctx, "Work", "work")
+	if err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	workflowOf := func() models.Workflow {
+		t.Helper()
+		workspaces, err := db.GetWorkspaces(ctx)
+		if err != nil {
+			t.Fatalf("GetWorkspaces failed: %v", err)
+		}
+		for _, ws := range workspaces {
+			if ws.ID == wsID {
+				return ws.Workflow
+			}
+		}
+		t.Fatalf("workspace %d not found", wsID)
+		return nil
+	}
+	if got := workflowOf().String(); got != models.DefaultWorkflow.String() {
+		t.Fatalf("expected default workflow, got %q", got)
+	}
+
+	if err := db.AddGoal(ctx, wsID, "Timed", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.StartTaskTimer(ctx, goalID); err != nil {
+		t.Fatalf("StartTaskTimer failed: %v", err)
+	}
+	if goal, err := db.GetGoalByID(ctx, goalID); err != nil || goal.Status != models.GoalStatusInProgress {
+		t.Fatalf("expected timed goal in progress, got %q (%v)", goal.Status, err)
+	}
+
+	wf, err := models.ParseWorkflow("pending, design, qa, completed")
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if err := db.UpdateWorkspaceWorkflow(ctx, wsID, wf); err != nil {
+		t.Fatalf("UpdateWorkspaceWorkflow failed: %v", err)
+	}
+	if got := workflowOf().String(); got != "pending, design, qa, completed" {
+		t.Fatalf("unexpected workflow %q", got)
+	}
+
+	// Without an in_progress status the timer leaves the goal pending.
+	if err := db.AddGoal(ctx, wsID, "Custom", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	customID, err := db.GetLastGoalID(ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := db.StartTaskTimer(ctx, customID); err != nil {
+		t.Fatalf("StartTaskTimer failed: %v", err)
+	}
+	if goal, err := db.GetGoalByID(ctx, customID); err != nil || goal.Status != models.GoalStatusPending {
+		t.Fatalf("expected goal to stay pending, got %q (%v)", goal.Status, err)
+	}
+
+	if err := db.UpdateWorkspaceBoardMode(ctx, wsID, 1); err != nil {
+		t.Fatalf("UpdateWorkspaceBoardMode failed: %v", err)
+	}
+	if err := db.UpdateWorkspaceWIPLimits(ctx, wsID, map[models.GoalStatus]int{"qa": 2}); err != nil {
+		t.Fatalf("UpdateWorkspaceWIPLimits failed: %v", err)
+	}
+	workspaces, err := db.GetWorkspaces(ctx)
+	if err != nil {
+		t.Fatalf("GetWorkspaces failed: %v", err)
+	}
+	for _, ws := range workspaces {
+		if ws.ID == wsID && (ws.BoardMode != 1 || ws.WIPLimits["qa"] != 2) {
+			t.Fatalf("unexpected board settings %d %v", ws.BoardMode, ws.WIPLimits)
+		}
+	}
+
+	data, err := db.ExportVault(ctx, ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportVault failed: %v", err)
+	}
+	if !strings.Contains(string(data), "pending, design, qa, completed") {
+		t.Fatalf("expected workflow in export")
+	}
+}
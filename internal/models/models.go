@@ -3,7 +3,10 @@
 // These types are used by both the database and TUI packages.
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // SprintStatus enumerates the possible states of a work block.
 type SprintStatus string
@@ -31,6 +34,7 @@ type GoalStatus string
 const (
 	GoalStatusPending    GoalStatus = "pending"
 	GoalStatusInProgress GoalStatus = "in_progress"
+	GoalStatusReview     GoalStatus = "review"
 	GoalStatusCompleted  GoalStatus = "completed"
 	GoalStatusBlocked    GoalStatus = "blocked"
 	GoalStatusArchived   GoalStatus = "archived"
@@ -38,7 +42,7 @@ const (
 
 func (s GoalStatus) IsValid() bool {
 	switch s {
-	case GoalStatusPending, GoalStatusInProgress, GoalStatusCompleted, GoalStatusBlocked, GoalStatusArchived:
+	case GoalStatusPending, GoalStatusInProgress, GoalStatusReview, GoalStatusCompleted, GoalStatusBlocked, GoalStatusArchived:
 		return true
 	default:
 		return false
@@ -50,6 +54,7 @@ type Day struct {
 	ID        int64
 	Date      string
 	StartedAt time.Time
+	Planned   bool // Created ahead of time and not yet started
 }
 
 // Workspace represents an isolated project environment.
@@ -62,6 +67,9 @@ type Workspace struct {
 	ShowBacklog   bool
 	ShowCompleted bool
 	ShowArchived  bool
+	Workflow      Workflow           // Goal statuses from pending to completed
+	BoardMode     int                // 0=Sprints, 1=Kanban
+	WIPLimits     map[GoalStatus]int // Kanban column limits; 0 means none
 }
 
 // Sprint represents a 90-minute block.
@@ -75,6 +83,37 @@ type Sprint struct {
 	EndTime        *time.Time
 	LastPausedAt   *time.Time
 	ElapsedSeconds int
+	Label          *string // Optional name shown next to the sprint number
+	Retro          *SprintRetro
+	Corrected      bool // Times were entered after the fact
+}
+
+// FocusedDuration returns the time spent in the sprint as of now: the
+// elapsed time saved at the last pause plus the current run. Corrected
+// sprints hold their whole focused time in ElapsedSeconds.
+func (s Sprint) FocusedDuration(now time.Time) time.Duration {
+	focused := time.Duration(s.ElapsedSeconds) * time.Second
+	if s.Corrected || s.StartTime == nil {
+		return focused
+	}
+	switch s.Status {
+	case StatusActive:
+		focused += now.Sub(*s.StartTime)
+	case StatusCompleted:
+		if s.EndTime != nil && s.EndTime.After(*s.StartTime) {
+			focused += s.EndTime.Sub(*s.StartTime)
+		}
+	}
+	return focused
+}
+
+// SprintRetro captures the retrospective answers recorded after a sprint.
+// FocusQuality and Energy are rated 1-5.
+type SprintRetro struct {
+	FocusQuality int
+	Energy       int
+	WentWell     string
+	Blockers     string
 }
 
 // Goal represents a single actionable item (Task).
@@ -85,11 +124,14 @@ type Goal struct {
 	SprintID       *int64 // Nil means backlog
 	Description    string
 	Notes          *string
-	Status         GoalStatus // open, done, blocked, waiting, archived
+	Status         GoalStatus // a workflow status, blocked or archived
 	Priority       int        // 1=High, 3=Low
 	Effort         *string    // S, M, L
 	Tags           *string    // JSON array
 	RecurrenceRule *string
+	DueDate        *string // YYYY-MM-DD; set on recurring instances
+	CarryOverCount int     // Times the goal was carried over to a later day
+	DeferredUntil  *string // YYYY-MM-DD; the goal is reviewed again on this day
 	Links          *string // JSON array
 	Rank           int
 	CreatedAt      time.Time
@@ -100,6 +142,24 @@ type Goal struct {
 	TaskActive     bool
 }
 
+// EffortPoints scores an effort size: S=1, M=2, L=3, XL=5. Goals without a
+// known effort count as small.
+func EffortPoints(effort *string) int {
+	if effort == nil {
+		return 1
+	}
+	switch strings.ToUpper(strings.TrimSpace(*effort)) {
+	case "M":
+		return 2
+	case "L":
+		return 3
+	case "XL":
+		return 5
+	default:
+		return 1
+	}
+}
+
 // JournalEntry represents a contextual note linked to a day and optionally a sprint.
 type JournalEntry struct {
 	ID          int64
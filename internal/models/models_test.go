@@ -29,3 +29,44 @@ func TestGoalZeroValues(t *testing.T) {
 		t.Fatalf("expected nil time fields by default")
 	}
 }
+
+func TestParseWorkflow(t *testing.T) {
+	wf, err := ParseWorkflow("Pending, in progress, QA check ,completed")
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if got := wf.String(); got != "pending, in_progress, qa_check, completed" {
+		t.Fatalf("unexpected workflow %q", got)
+	}
+	if next, ok := wf.Next(GoalStatusInProgress); !ok || next != "qa_check" {
+		t.Fatalf("expected qa_check after in_progress, got %q", next)
+	}
+	if _, ok := wf.Next(GoalStatusCompleted); ok {
+		t.Fatalf("expected no status after completed")
+	}
+	if prev, ok := wf.Prev(GoalStatusReview); !ok || prev != GoalStatusPending {
+		t.Fatalf("expected unknown status to fall back to pending, got %q", prev)
+	}
+	if _, ok := wf.Prev(GoalStatusPending); ok {
+		t.Fatalf("expected no status before pending")
+	}
+
+	for _, raw := range []string{"", "in_progress, completed", "pending, done", "pending, blocked, completed", "pending, x-y, completed", "pending, review, review, completed"} {
+		if _, err := ParseWorkflow(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+	if got := WorkflowOrDefault(""); got.String() != DefaultWorkflow.String() {
+		t.Fatalf("expected default workflow, got %q", got)
+	}
+}
+
+func TestParseWIPLimits(t *testing.T) {
+	limits := ParseWIPLimits(" in_progress=3, review = 2,bad,qa=0,x=y")
+	if len(limits) != 2 || limits[GoalStatusInProgress] != 3 || limits[GoalStatusReview] != 2 {
+		t.Fatalf("unexpected limits %v", limits)
+	}
+	if got := FormatWIPLimits(limits); got != "in_progress=3,review=2" {
+		t.Fatalf("unexpected format %q", got)
+	}
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Workflow is the ordered list of statuses a goal moves through. It starts
+// with pending and ends with completed; the statuses in between are free.
+type Workflow []GoalStatus
+
+// DefaultWorkflow is used by workspaces that have not configured one.
+var DefaultWorkflow = Workflow{GoalStatusPending, GoalStatusInProgress, GoalStatusReview, GoalStatusCompleted}
+
+var workflowStatusRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParseWorkflow reads a comma separated list of statuses such as
+// "pending, in progress, review, completed". Names are lowercased and
+// spaces become underscores.
+func ParseWorkflow(raw string) (Workflow, error) {
+	var wf Workflow
+	seen := make(map[GoalStatus]bool)
+	for _, part := range strings.Split(raw, ",") {
+		name := strings.Join(strings.Fields(strings.ToLower(part)), "_")
+		if name == "" {
+			continue
+		}
+		status := GoalStatus(name)
+		if !workflowStatusRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid status %q", name)
+		}
+		if status == GoalStatusBlocked || status == GoalStatusArchived {
+			return nil, fmt.Errorf("status %q is set automatically", name)
+		}
+		if seen[status] {
+			return nil, fmt.Errorf("duplicate status %q", name)
+		}
+		seen[status] = true
+		wf = append(wf, status)
+	}
+	if len(wf) < 2 || wf[0] != GoalStatusPending || wf[len(wf)-1] != GoalStatusCompleted {
+		return nil, fmt.Errorf("workflow must start with pending and end with completed")
+	}
+	return wf, nil
+}
+
+// WorkflowOrDefault parses a stored workflow, falling back to
+// DefaultWorkflow when it is empty or invalid.
+func WorkflowOrDefault(raw string) Workflow {
+	if wf, err := ParseWorkflow(raw); err == nil {
+		return wf
+	}
+	return DefaultWorkflow
+}
+
+// String joins the statuses with commas, as accepted by ParseWorkflow.
+func (w Workflow) String() string {
+	parts := make([]string, len(w))
+	for i, status := range w {
+		parts[i] = string(status)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Index returns the position of status in the workflow, or -1.
+func (w Workflow) Index(status GoalStatus) int {
+	for i, s := range w {
+		if s == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether status is part of the workflow.
+func (w Workflow) Contains(status GoalStatus) bool {
+	return w.Index(status) >= 0
+}
+
+// Next returns the status after status. Statuses outside the workflow
+// advance from pending. ok is false at the end of the workflow.
+func (w Workflow) Next(status GoalStatus) (GoalStatus, bool) {
+	i := w.Index(status)
+	if i < 0 {
+		i = 0
+	}
+	if i+1 >= len(w) {
+		return status, false
+	}
+	return w[i+1], true
+}
+
+// Prev returns the status before status. ok is false at the start of the
+// workflow; statuses outside the workflow fall back to pending.
+func (w Workflow) Prev(status GoalStatus) (GoalStatus, bool) {
+	i := w.Index(status)
+	if i < 0 {
+		return GoalStatusPending, status != GoalStatusPending
+	}
+	if i == 0 {
+		return status, false
+	}
+	return w[i-1], true
+}
+
+// Label formats a status for display, e.g. "in progress".
+func (s GoalStatus) Label() string {
+	return strings.ReplaceAll(string(s), "_", " ")
+}
+
+// ParseWIPLimits reads limits stored as "in_progress=3,review=2". Malformed
+// and non-positive entries are skipped.
+func ParseWIPLimits(raw string) map[GoalStatus]int {
+	limits := make(map[GoalStatus]int)
+	for _, part := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			continue
+		}
+		limits[GoalStatus(strings.TrimSpace(name))] = n
+	}
+	return limits
+}
+
+// FormatWIPLimits is the inverse of ParseWIPLimits, sorted by status.
+func FormatWIPLimits(limits map[GoalStatus]int) string {
+	var parts []string
+	for status, n := range limits {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", status, n))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// planSprint is a sprint that can receive goals, with the effort already
+// committed to it.
+type planSprint struct {
+	ID       int64
+	Number   int
+	Label    *string
+	Load     int    // effort of goals already in the sprint
+	HasFocus bool   // a #focus goal is already in the sprint
+	Blocked  string // why no goals are proposed for the sprint, e.g. a meeting
+}
+
+// planItem is a backlog goal and the sprint it is proposed for; Target is an
+// index into autoPlan.Sprints, or -1 to leave it in the backlog.
+type planItem struct {
+	Goal   models.Goal
+	Weight int
+	Focus  bool
+	Target int
+	Reason string // why the goal was left in the backlog
+}
+
+// autoPlan proposes sprint assignments for backlog goals. Items are kept in
+// dependency order.
+type autoPlan struct {
+	Sprints  []planSprint
+	Items    []planItem
+	Capacity int
+	// deps holds each item's prerequisites; depSprint pins prerequisites
+	// already scheduled today to their sprint index.
+	deps      map[int64][]int64
+	depSprint map[int64]int
+}
+
+func hasFocusTag(goal models.Goal) bool {
+	if goal.Tags == nil {
+		return false
+	}
+	for _, tag := range util.JSONToTags(*goal.Tags) {
+		if tag == "focus" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildAutoPlan assigns candidates to sprints in dependency order. Ready
+// goals are taken #focus first, then by priority, due date and backlog
+// order. Each goal goes to the earliest sprint with room that is not before
+// any of its prerequisites; #focus goals prefer sprints without another
+// #focus goal. scheduled maps goals already in today's sprints to their
+// sprint index and done holds completed goals; prerequisites that are
+// neither leave the dependent goal in the backlog.
+func buildAutoPlan(sprints []planSprint, candidates []models.Goal, deps map[int64][]int64, scheduled map[int64]int, done map[int64]bool, capacity int) autoPlan {
+	p := autoPlan{Sprints: sprints, Capacity: capacity, deps: make(map[int64][]int64), depSprint: scheduled}
+	inPlan := make(map[int64]int, len(candidates))
+	for i, goal := range candidates {
+		inPlan[goal.ID] = i
+	}
+	// Count unresolved prerequisites among the candidates (Kahn's algorithm).
+	waiting := make([]int, len(candidates))
+	dependents := make(map[int64][]int)
+	for i, goal := range candidates {
+		for _, dep := range deps[goal.ID] {
+			if done[dep] {
+				continue
+			}
+			p.deps[goal.ID] = append(p.deps[goal.ID], dep)
+			if _, ok := inPlan[dep]; ok {
+				waiting[i]++
+				dependents[dep] = append(dependents[dep], i)
+			}
+		}
+	}
+	less := func(a, b int) bool {
+		ga, gb := candidates[a], candidates[b]
+		if fa, fb := hasFocusTag(ga), hasFocusTag(gb); fa != fb {
+			return fa
+		}
+		pa, pb := ga.Priority, gb.Priority
+		if pa <= 0 {
+			pa = 3
+		}
+		if pb <= 0 {
+			pb = 3
+		}
+		if pa != pb {
+			return pa < pb
+		}
+		if (ga.DueDate == nil) != (gb.DueDate == nil) {
+			return ga.DueDate != nil
+		}
+		if ga.DueDate != nil && *ga.DueDate != *gb.DueDate {
+			return *ga.DueDate < *gb.DueDate
+		}
+		return a < b
+	}
+	var ready []int
+	for i := range candidates {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	placed := make(map[int64]int)
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		idx := ready[0]
+		ready = ready[1:]
+		goal := candidates[idx]
+		item := planItem{Goal: goal, Weight: models.EffortPoints(goal.Effort), Focus: hasFocusTag(goal), Target: -1}
+		earliest, reason := 0, ""
+		for _, dep := range p.deps[goal.ID] {
+			at, ok := placed[dep]
+			if !ok {
+				at, ok = scheduled[dep]
+			}
+			if !ok || at < 0 {
+				reason = fmt.Sprintf("waits on #%d", dep)
+				break
+			}
+			if at > earliest {
+				earliest = at
+			}
+		}
+		if reason == "" {
+			item.Target = p.pick(earliest, item)
+			if item.Target < 0 {
+				reason = "no sprint has room"
+			}
+		}
+		item.Reason = reason
+		placed[goal.ID] = item.Target
+		p.Items = append(p.Items, item)
+		for _, next := range dependents[goal.ID] {
+			waiting[next]--
+			if waiting[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	for _, goal := range candidates {
+		if _, ok := placed[goal.ID]; !ok {
+			p.Items = append(p.Items, planItem{Goal: goal, Weight: models.EffortPoints(goal.Effort), Focus: hasFocusTag(goal), Target: -1, Reason: "dependency cycle"})
+		}
+	}
+	return p
+}
+
+// pick returns the sprint index for item at or after earliest, or -1.
+func (p autoPlan) pick(earliest int, item planItem) int {
+	fits := func(i int) bool { return p.Sprints[i].Blocked == "" && p.Load(i)+item.Weight <= p.Capacity }
+	if item.Focus {
+		for i := earliest; i < len(p.Sprints); i++ {
+			if fits(i) && !p.HasFocus(i) {
+				return i
+			}
+		}
+	}
+	for i := earliest; i < len(p.Sprints); i++ {
+		if fits(i) {
+			return i
+		}
+	}
+	// Oversized goals get an empty sprint of their own.
+	if item.Weight > p.Capacity {
+		for i := earliest; i < len(p.Sprints); i++ {
+			if p.Sprints[i].Blocked == "" && p.Load(i) == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Load is the effort committed to sprint i, including proposed goals.
+func (p autoPlan) Load(i int) int {
+	load := p.Sprints[i].Load
+	for _, item := range p.Items {
+		if item.Target == i {
+			load += item.Weight
+		}
+	}
+	return load
+}
+
+// HasFocus reports whether sprint i holds or is proposed a #focus goal.
+func (p autoPlan) HasFocus(i int) bool {
+	if p.Sprints[i].HasFocus {
+		return true
+	}
+	for _, item := range p.Items {
+		if item.Target == i && item.Focus {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflicts lists the prerequisites of item i that the plan schedules after
+// it, or not at all.
+func (p autoPlan) Conflicts(i int) []int64 {
+	item := p.Items[i]
+	if item.Target < 0 {
+		return nil
+	}
+	var out []int64
+	for _, dep := range p.deps[item.Goal.ID] {
+		at, ok := p.depSprint[dep]
+		for _, other := range p.Items {
+			if other.Goal.ID == dep {
+				at, ok = other.Target, true
+			}
+		}
+		if !ok || at < 0 || at > item.Target {
+			out = append(out, dep)
+		}
+	}
+	return out
+}
+
+// Planned counts the goals the plan moves into sprints.
+func (p autoPlan) Planned() int {
+	n := 0
+	for _, item := range p.Items {
+		if item.Target >= 0 {
+			n++
+		}
+	}
+	return n
+}
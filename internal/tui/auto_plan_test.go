@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBuildAutoPlan(t *testing.T) {
+	small, medium, large := "S", "M", "L"
+	focus := `["focus"]`
+	candidates := []models.Goal{
+		{ID: 1, Description: "Deep A", Effort: &medium, Tags: &focus},
+		{ID: 2, Description: "Deep B", Effort: &small, Tags: &focus},
+		{ID: 3, Description: "Design", Effort: &large, Priority: 1},
+		{ID: 4, Description: "Build", Effort: &medium},
+		{ID: 5, Description: "Waiting"},
+		{ID: 6, Description: "Chore", Effort: &small},
+		{ID: 7, Description: "Follow-up"},
+	}
+	deps := map[int64][]int64{4: {3}, 5: {99}, 7: {50, 60}}
+	scheduled := map[int64]int{50: 1}
+	done := map[int64]bool{60: true}
+	sprints := []planSprint{{ID: 10, Number: 1}, {ID: 20, Number: 2}, {ID: 30, Number: 3}}
+
+	p := buildAutoPlan(sprints, candidates, deps, scheduled, done, 4)
+	want := map[int64]int{1: 0, 2: 1, 3: 1, 4: 2, 5: -1, 6: 0, 7: 2}
+	pos := make(map[int64]int)
+	for i, item := range p.Items {
+		pos[item.Goal.ID] = i
+		if item.Target != want[item.Goal.ID] {
+			t.Fatalf("goal #%d planned into %d, want %d (%+v)", item.Goal.ID, item.Target, want[item.Goal.ID], p.Items)
+		}
+	}
+	if pos[3] > pos[4] {
+		t.Fatalf("expected prerequisites ahead of dependents, got %+v", p.Items)
+	}
+	if r := p.Items[pos[5]].Reason; r != "waits on #99" {
+		t.Fatalf("unexpected reason %q", r)
+	}
+	if p.Load(1) != 4 || p.Planned() != 6 {
+		t.Fatalf("unexpected load %d / planned %d", p.Load(1), p.Planned())
+	}
+
+	p.Items[pos[4]].Target = 0
+	if c := p.Conflicts(pos[4]); len(c) != 1 || c[0] != 3 {
+		t.Fatalf("expected conflict with #3, got %v", c)
+	}
+}
+
+func TestAutoPlanModalApply(t *testing.T) {
+	m := setupTestDashboard(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	addBacklog := func(desc string) int64 {
+		t.Helper()
+		if err := m.db.AddGoal(m.ctx, wsID, desc, 0); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+		id, err := m.db.GetLastGoalID(m.ctx)
+		if err != nil {
+			t.Fatalf("GetLastGoalID failed: %v", err)
+		}
+		return id
+	}
+	implID := addBacklog("Implement")
+	specID := addBacklog("Write spec")
+	if err := m.db.SetGoalDependencies(m.ctx, implID, []int64{specID}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+
+	m, _, _ = m.handleAutoPlan("S")
+	state, ok := m.modal.AutoPlanState()
+	if !ok || len(state.Plan.Items) != 2 || state.Plan.Items[0].Goal.ID != specID {
+		t.Fatalf("expected plan with the spec first, got %+v", m.modal.Current())
+	}
+	if view := m.renderAutoPlan(state); view == "" {
+		t.Fatalf("expected preview")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if state.Plan.Capacity != 4 {
+		t.Fatalf("expected capacity raised to 4, got %d", state.Plan.Capacity)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected preview closed after apply")
+	}
+	spec, err := m.db.GetGoalByID(m.ctx, specID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	impl, err := m.db.GetGoalByID(m.ctx, implID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if spec.SprintID == nil || impl.SprintID == nil {
+		t.Fatalf("expected both goals planned, got %+v / %+v", spec.SprintID, impl.SprintID)
+	}
+	if value, ok := m.db.GetSetting(m.ctx, autoPlanCapacitySetting); !ok || value != "4" {
+		t.Fatalf("expected capacity setting saved, got %q", value)
+	}
+}
@@ -0,0 +1,413 @@
+package tui
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// CSV export file names, written side by side in one folder.
+const (
+	CSVGoalsFile   = "goals.csv"
+	CSVSprintsFile = "sprints.csv"
+	CSVTimeFile    = "time_entries.csv"
+)
+
+var csvGoalHeader = []string{
+	"id", "parent_id", "workspace", "date", "sprint_number", "description", "status", "priority", "effort",
+	"tags", "notes", "links", "recurrence_rule", "recurrence_next", "due_date", "deferred_until",
+	"carry_over_count", "rank", "created_at", "completed_at", "archived_at",
+	"task_started_at", "task_elapsed_seconds", "task_active",
+}
+
+var csvSprintHeader = []string{
+	"id", "workspace", "date", "sprint_number", "label", "status", "start_time", "end_time",
+	"elapsed_seconds", "corrected", "retro_focus", "retro_energy", "retro_went_well", "retro_blockers",
+}
+
+var csvTimeHeader = []string{"id", "goal_id", "goal", "workspace", "date", "started_at", "ended_at", "seconds", "hours"}
+
+func csvString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func csvInt(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func csvBool(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// ExportCSV writes goals, sprints and time entries as CSV files to a new
+// folder in the exports directory and returns the folder.
+func ExportCSV(ctx context.Context, db Database) (string, error) {
+	dir := filepath.Join(util.ReportsDir("sspt"), "exports", fmt.Sprintf("sspt_csv_%s", time.Now().Format("20060102_150405")))
+	if err := WriteCSVExport(ctx, db, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// WriteCSVExport writes goals.csv, sprints.csv and time_entries.csv to dir.
+// Goals carry every field, with tags and links separated by spaces;
+// workspaces are named by slug and sprints by date and number.
+func WriteCSVExport(ctx context.Context, db Database, dir string) error {
+	workspaces, err := db.GetWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	slugs := make(map[int64]string, len(workspaces))
+	for _, ws := range workspaces {
+		slugs[ws.ID] = ws.Slug
+	}
+	slug := func(id *int64) string {
+		if id == nil {
+			return ""
+		}
+		return slugs[*id]
+	}
+	days, err := db.GetAllDays(ctx)
+	if err != nil {
+		return err
+	}
+	dates := make(map[int64]string, len(days))
+	for _, day := range days {
+		dates[day.ID] = day.Date
+	}
+	sprints, err := db.GetAllSprintsFlat(ctx)
+	if err != nil {
+		return err
+	}
+	sprintByID := make(map[int64]database.ExportSprint, len(sprints))
+	for _, s := range sprints {
+		sprintByID[s.ID] = s
+	}
+	goals, err := db.GetAllGoalsExport(ctx)
+	if err != nil {
+		return err
+	}
+	goalByID := make(map[int64]database.ExportGoal, len(goals))
+	for _, g := range goals {
+		goalByID[g.ID] = g
+	}
+	sessions, err := db.GetAllTaskSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	goalRows := make([][]string, 0, len(goals))
+	for _, g := range goals {
+		date, number := "", ""
+		if g.SprintID != nil {
+			if s, ok := sprintByID[*g.SprintID]; ok {
+				date, number = dates[s.DayID], strconv.Itoa(s.SprintNumber)
+			}
+		}
+		goalRows = append(goalRows, []string{
+			strconv.FormatInt(g.ID, 10), csvInt(g.ParentID), slug(g.WorkspaceID), date, number, g.Description, g.Status,
+			strconv.Itoa(g.Priority), csvString(g.Effort), strings.Join(g.Tags, " "), csvString(g.Notes), strings.Join(g.Links, " "),
+			csvString(g.RecurrenceRule), csvString(g.RecurrenceNext), csvString(g.DueDate), csvString(g.DeferredUntil),
+			strconv.Itoa(g.CarryOverCount), strconv.Itoa(g.Rank), g.CreatedAt, csvString(g.CompletedAt), csvString(g.ArchivedAt),
+			csvString(g.TaskStartedAt), strconv.Itoa(g.TaskElapsedSec), csvBool(g.TaskActive),
+		})
+	}
+	if err := writeCSVFile(filepath.Join(dir, CSVGoalsFile), csvGoalHeader, goalRows); err != nil {
+		return err
+	}
+
+	sprintRows := make([][]string, 0, len(sprints))
+	for _, s := range sprints {
+		sprintRows = append(sprintRows, []string{
+			strconv.FormatInt(s.ID, 10), slug(s.WorkspaceID), dates[s.DayID], strconv.Itoa(s.SprintNumber), csvString(s.Label), s.Status,
+			csvString(s.StartTime), csvString(s.EndTime), strconv.Itoa(s.ElapsedSeconds), csvBool(s.Corrected),
+			csvInt(s.RetroFocus), csvInt(s.RetroEnergy), csvString(s.RetroWentWell), csvString(s.RetroBlockers),
+		})
+	}
+	if err := writeCSVFile(filepath.Join(dir, CSVSprintsFile), csvSprintHeader, sprintRows); err != nil {
+		return err
+	}
+
+	timeRows := make([][]string, 0, len(sessions))
+	for _, s := range sessions {
+		g := goalByID[s.GoalID]
+		date := s.StartedAt
+		if started, err := time.Parse(time.RFC3339, s.StartedAt); err == nil {
+			date = started.Local().Format(util.DateLayout)
+		}
+		timeRows = append(timeRows, []string{
+			strconv.FormatInt(s.ID, 10), strconv.FormatInt(s.GoalID, 10), g.Description, slug(g.WorkspaceID), date,
+			s.StartedAt, csvString(s.EndedAt), strconv.Itoa(s.Seconds), formatHours(time.Duration(s.Seconds) * time.Second),
+		})
+	}
+	return writeCSVFile(filepath.Join(dir, CSVTimeFile), csvTimeHeader, timeRows)
+}
+
+func writeCSVFile(path string, header []string, rows [][]string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.Write(header); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// CSVImportFields are the goal fields a CSV column can be mapped to.
+var CSVImportFields = []string{"description", "tags", "priority", "effort", "notes", "links", "recurrence"}
+
+// csvFieldAliases are the header names recognized for each import field.
+var csvFieldAliases = map[string][]string{
+	"description": {"description", "goal", "title", "task", "name", "summary"},
+	"tags":        {"tags", "tag", "labels", "label"},
+	"priority":    {"priority", "prio"},
+	"effort":      {"effort", "size", "estimate"},
+	"notes":       {"notes", "note", "details"},
+	"links":       {"links", "link", "url", "urls"},
+	"recurrence":  {"recurrence", "recurrence_rule", "repeat"},
+}
+
+// CSVImport is a parsed CSV file and the column each import field is read
+// from; -1 leaves the field empty.
+type CSVImport struct {
+	Header  []string
+	Rows    [][]string
+	Mapping map[string]int
+}
+
+// ParseCSVImport reads a CSV file with a header row and guesses the column
+// mapping from the header names.
+func ParseCSVImport(r io.Reader) (*CSVImport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	c := &CSVImport{Header: header, Rows: records[1:], Mapping: make(map[string]int)}
+	for _, field := range CSVImportFields {
+		c.Mapping[field] = -1
+		for _, alias := range csvFieldAliases[field] {
+			if col := c.column(alias); col >= 0 {
+				c.Mapping[field] = col
+				break
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *CSVImport) column(name string) int {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+	for i, h := range c.Header {
+		if strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_") == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Map reads field from column, given by header name or 1-based number;
+// an empty column leaves the field empty.
+func (c *CSVImport) Map(field, column string) error {
+	if _, ok := csvFieldAliases[field]; !ok {
+		return fmt.Errorf("unknown field %q (use %s)", field, strings.Join(CSVImportFields, ", "))
+	}
+	if strings.TrimSpace(column) == "" {
+		c.Mapping[field] = -1
+		return nil
+	}
+	if col := c.column(column); col >= 0 {
+		c.Mapping[field] = col
+		return nil
+	}
+	if n, err := strconv.Atoi(column); err == nil && n >= 1 && n <= len(c.Header) {
+		c.Mapping[field] = n - 1
+		return nil
+	}
+	return fmt.Errorf("unknown column %q", column)
+}
+
+// ColumnName names the column mapped to field, or "-" when it has none.
+func (c *CSVImport) ColumnName(field string) string {
+	col := c.Mapping[field]
+	if col < 0 || col >= len(c.Header) {
+		return "-"
+	}
+	if c.Header[col] == "" {
+		return fmt.Sprintf("column %d", col+1)
+	}
+	return c.Header[col]
+}
+
+func (c *CSVImport) value(row []string, field string) string {
+	col := c.Mapping[field]
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[col])
+}
+
+// CSVRowStatus is the outcome of importing one CSV row.
+type CSVRowStatus int
+
+const (
+	CSVRowNew CSVRowStatus = iota
+	CSVRowDuplicate
+	CSVRowInvalid
+)
+
+func (s CSVRowStatus) String() string {
+	switch s {
+	case CSVRowDuplicate:
+		return "duplicate"
+	case CSVRowInvalid:
+		return "invalid"
+	default:
+		return "new"
+	}
+}
+
+// CSVPreviewRow is one row of the file as it would be imported. Line is the
+// row's line in the file, counting the header as line 1.
+type CSVPreviewRow struct {
+	Line   int
+	Seed   database.GoalSeed
+	Status CSVRowStatus
+	Reason string
+}
+
+func splitCSVList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t'
+	})
+}
+
+func (c *CSVImport) seed(row []string) (database.GoalSeed, error) {
+	seed := database.GoalSeed{
+		Description: c.value(row, "description"),
+		Notes:       c.value(row, "notes"),
+		Links:       strings.FieldsFunc(c.value(row, "links"), func(r rune) bool { return r == ';' || r == ' ' || r == '\t' }),
+	}
+	if seed.Description == "" {
+		return seed, fmt.Errorf("no description")
+	}
+	for _, tag := range splitCSVList(c.value(row, "tags")) {
+		seed.Tags = append(seed.Tags, strings.TrimPrefix(tag, "#"))
+	}
+	if priority := strings.TrimPrefix(strings.ToUpper(c.value(row, "priority")), "P"); priority != "" {
+		n, err := strconv.Atoi(priority)
+		if err != nil || n < 1 || n > 5 {
+			return seed, fmt.Errorf("priority %q is not 1-5", c.value(row, "priority"))
+		}
+		seed.Priority = n
+	}
+	if effort := strings.ToUpper(c.value(row, "effort")); effort != "" {
+		switch effort {
+		case "S", "M", "L", "XL":
+			seed.Effort = effort
+		default:
+			return seed, fmt.Errorf("effort %q is not S, M, L or XL", effort)
+		}
+	}
+	if recurrence := c.value(row, "recurrence"); recurrence != "" {
+		normalized, err := util.NormalizeRecurrence(recurrence)
+		if err != nil {
+			return seed, fmt.Errorf("recurrence %q: %v", recurrence, err)
+		}
+		seed.Recurrence = normalized
+	}
+	return seed, nil
+}
+
+// PreviewCSVImport maps every row to a backlog goal of the workspace and
+// marks rows that are invalid or whose description is already in the
+// backlog or earlier in the file.
+func PreviewCSVImport(ctx context.Context, db Database, workspaceID int64, c *CSVImport) ([]CSVPreviewRow, error) {
+	preview := make([]CSVPreviewRow, 0, len(c.Rows))
+	seen := make(map[string]int)
+	for i, row := range c.Rows {
+		p := CSVPreviewRow{Line: i + 2}
+		seed, err := c.seed(row)
+		p.Seed = seed
+		switch {
+		case err != nil:
+			p.Status, p.Reason = CSVRowInvalid, err.Error()
+		case seen[seed.Description] > 0:
+			p.Status, p.Reason = CSVRowDuplicate, fmt.Sprintf("same as line %d", seen[seed.Description])
+		default:
+			seen[seed.Description] = p.Line
+			result, err := db.CheckGoalExists(ctx, workspaceID, 0, nil, seed.Description, database.ExistsCheckBasic, nil)
+			if err != nil {
+				return nil, err
+			}
+			if result.Exists {
+				p.Status, p.Reason = CSVRowDuplicate, fmt.Sprintf("goal %d exists", result.ExistingID)
+			}
+		}
+		preview = append(preview, p)
+	}
+	return preview, nil
+}
+
+// CountCSVRows counts the preview rows by status.
+func CountCSVRows(preview []CSVPreviewRow) map[CSVRowStatus]int {
+	counts := make(map[CSVRowStatus]int)
+	for _, p := range preview {
+		counts[p.Status]++
+	}
+	return counts
+}
+
+// ImportCSVRows adds the new rows of a preview to the workspace backlog and
+// returns how many were added.
+func ImportCSVRows(ctx context.Context, db Database, workspaceID int64, preview []CSVPreviewRow) (int, error) {
+	imported := 0
+	for _, p := range preview {
+		if p.Status != CSVRowNew {
+			continue
+		}
+		if err := db.AddGoalDetailed(ctx, workspaceID, 0, p.Seed); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+	return imported, nil
+}
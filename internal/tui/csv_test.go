@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseCSVImportMapping(t *testing.T) {
+	c, err := ParseCSVImport(strings.NewReader("\ufeffTitle,Size,Labels,Extra\nShip it,l,\"a, b\",x\n"))
+	if err != nil {
+		t.Fatalf("ParseCSVImport failed: %v", err)
+	}
+	if c.ColumnName("description") != "Title" || c.ColumnName("effort") != "Size" || c.ColumnName("tags") != "Labels" || c.ColumnName("notes") != "-" {
+		t.Fatalf("unexpected guessed mapping %v", c.Mapping)
+	}
+	if err := c.Map("notes", "extra"); err != nil || c.Mapping["notes"] != 3 {
+		t.Fatalf("expected notes mapped by name, got %v (%v)", c.Mapping["notes"], err)
+	}
+	if err := c.Map("notes", "2"); err != nil || c.Mapping["notes"] != 1 {
+		t.Fatalf("expected notes mapped by number, got %v (%v)", c.Mapping["notes"], err)
+	}
+	if err := c.Map("owner", "Title"); err == nil {
+		t.Fatalf("expected unknown field to be refused")
+	}
+	if err := c.Map("notes", "Missing"); err == nil {
+		t.Fatalf("expected unknown column to be refused")
+	}
+	seed, err := c.seed(c.Rows[0])
+	if err != nil || seed.Effort != "L" || strings.Join(seed.Tags, " ") != "a b" {
+		t.Fatalf("unexpected seed %+v (%v)", seed, err)
+	}
+	if _, err := ParseCSVImport(strings.NewReader("")); err == nil {
+		t.Fatalf("expected empty file to be refused")
+	}
+}
+
+func TestCSVExportAndImport(t *testing.T) {
+	m := setupTestDashboard(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	if err := m.db.AddGoalDetailed(m.ctx, wsID, 0, database.GoalSeed{Description: "Existing, with comma", Tags: []string{"ops", "docs"}, Priority: 2, Effort: "S"}); err != nil {
+		t.Fatalf("AddGoalDetailed failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteCSVExport(m.ctx, m.db, dir); err != nil {
+		t.Fatalf("WriteCSVExport failed: %v", err)
+	}
+	for _, name := range []string{CSVGoalsFile, CSVSprintsFile, CSVTimeFile} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil || len(records) == 0 {
+			t.Fatalf("expected %s to have a header: %v", name, err)
+		}
+		if name == CSVGoalsFile && (len(records) != 2 || records[1][9] != "docs ops" || records[1][2] != m.workspaces[m.activeWorkspaceIdx].Slug) {
+			t.Fatalf("unexpected goals export %v", records)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(dir, CSVGoalsFile))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	c, err := ParseCSVImport(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("ParseCSVImport failed: %v", err)
+	}
+	c.Rows = append(c.Rows,
+		[]string{"", "", "", "", "", "New goal", "", "P1", "xl", "#a;b"},
+		[]string{"", "", "", "", "", "New goal"},
+		[]string{"", "", "", "", "", "Bad priority", "", "9"},
+		[]string{"", "", "", "", "", ""},
+	)
+	preview, err := PreviewCSVImport(m.ctx, m.db, wsID, c)
+	if err != nil {
+		t.Fatalf("PreviewCSVImport failed: %v", err)
+	}
+	want := []CSVRowStatus{CSVRowDuplicate, CSVRowNew, CSVRowDuplicate, CSVRowInvalid, CSVRowInvalid}
+	for i, p := range preview {
+		if p.Status != want[i] {
+			t.Fatalf("line %d: expected %s, got %s (%s)", p.Line, want[i], p.Status, p.Reason)
+		}
+	}
+	if p := preview[1]; p.Seed.Priority != 1 || p.Seed.Effort != "XL" || len(p.Seed.Tags) != 2 {
+		t.Fatalf("unexpected seed %+v", p.Seed)
+	}
+	imported, err := ImportCSVRows(m.ctx, m.db, wsID, preview)
+	if err != nil || imported != 1 {
+		t.Fatalf("expected 1 import, got %d (%v)", imported, err)
+	}
+}
+
+func TestCSVImportModal(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width, m.height = 120, 40
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	if err := os.WriteFile(path, []byte("Owner,Task\nana,Write spec\nbo,Review spec\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	m, _, _ = m.handleCSVImport("U")
+	state, ok := m.modal.CSVImportState()
+	if !ok {
+		t.Fatalf("expected CSV import modal")
+	}
+	m.inputs.textInput.SetValue(path)
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if state.Step != csvImportMapping || state.Import.ColumnName("description") != "Task" {
+		t.Fatalf("expected the mapping step with Task as description, got %+v", state)
+	}
+	for i := 0; i < 2; i++ {
+		m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRight})
+	}
+	if state.Import.ColumnName("description") != "Owner" {
+		t.Fatalf("expected right to cycle past unmapped to Owner, got %s", state.Import.ColumnName("description"))
+	}
+	if view := m.renderCSVImport(state); !strings.Contains(view, "e.g. ana") {
+		t.Fatalf("expected a sample value:\n%s", view)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyLeft})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyLeft})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if state.Step != csvImportPreview || CountCSVRows(state.Preview)[CSVRowNew] != 2 {
+		t.Fatalf("expected a preview of 2 new rows, got %+v", state.Preview)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEsc})
+	if state.Step != csvImportMapping || !m.modal.Is(ModalCSVImport) {
+		t.Fatalf("expected Esc to return to the mapping")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() || !strings.Contains(m.Message, "Imported 2 goal(s)") {
+		t.Fatalf("expected import to finish, got %q", m.Message)
+	}
+	goals, err := m.db.GetBacklogGoals(m.ctx, m.workspaces[m.activeWorkspaceIdx].ID)
+	if err != nil || len(goals) != 2 {
+		t.Fatalf("expected 2 backlog goals, got %d (%v)", len(goals), err)
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
 	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -36,6 +38,12 @@ const (
 	ViewModeMinimal = config.ViewModeMinimal // Hide Completed & Backlog
 )
 
+// Board Modes
+const (
+	BoardModeSprints = config.BoardModeSprints
+	BoardModeKanban  = config.BoardModeKanban // Columns are goal statuses
+)
+
 // --- Model ---
 type DashboardModel struct {
 	db                 Database
@@ -52,7 +60,14 @@ type DashboardModel struct {
 	journalEntries     []models.JournalEntry
 	search             SearchManager
 	showAnalytics      bool
+	showDetails        bool
+	showFullHelp       bool
+	forecast           *Forecast        // Backlog forecast shown in its header
+	forecastKey        string           // Inputs the cached forecast was computed from
+	meetings           []util.ICalEvent // Meetings on the shown day from the calendar file
+	meetingsDate       string           // Day the meetings were loaded for
 	goalTreeCache      map[string][]GoalView
+	lastTransitionID   int64
 	progress           progress.Model
 	timer              TimerManager
 	theme              Theme
@@ -127,10 +142,13 @@ func NewDashboardModel(ctx context.Context, db Database, dayID int64, theme Them
 	}
 	sort.Strings(m.modal.themeNames)
 	m.progress.Width = config.TargetTitleWidth
+	if id, err := m.db.GetLastGoalTransitionID(ctx); err == nil {
+		m.lastTransitionID = id
+	}
 	m.refreshData(dayID)
 
 	// Set initial focus
-	if len(m.sprints) > 1 {
+	if len(m.sprints) > 1 && !m.kanbanMode() {
 		for i := 1; i < len(m.sprints); i++ {
 			if m.sprints[i].Status != models.StatusCompleted && m.sprints[i].SprintNumber > 0 {
 				m.view.focusedColIdx = i
@@ -156,6 +174,56 @@ func (m DashboardModel) currentSprint() *SprintView {
 	return &m.sprints[m.view.focusedColIdx]
 }
 
+// focusedGoal returns the goal under the cursor, if any.
+func (m DashboardModel) focusedGoal() (GoalView, bool) {
+	sprint := m.currentSprint()
+	if sprint == nil || m.view.focusedGoalIdx < 0 || m.view.focusedGoalIdx >= len(sprint.Goals) {
+		return GoalView{}, false
+	}
+	return sprint.Goals[m.view.focusedGoalIdx], true
+}
+
+// focusGoalOnBoard moves the cursor to goalID, expanding collapsed parents
+// so subtasks become visible. It reports false when the goal is not on the
+// current board.
+func (m DashboardModel) focusGoalOnBoard(goalID int64) (DashboardModel, bool) {
+	find := func() bool {
+		for ci, sprint := range m.sprints {
+			if !m.onBoard(ci) {
+				continue
+			}
+			for gi, goal := range sprint.Goals {
+				if goal.ID == goalID {
+					m.view.focusedColIdx = ci
+					m.view.focusedGoalIdx = gi
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if find() {
+		return m, true
+	}
+	expanded := false
+	for id := goalID; ; {
+		goal, err := m.db.GetGoalByID(m.ctx, id)
+		if err != nil || goal.ParentID == nil {
+			break
+		}
+		id = *goal.ParentID
+		if !m.view.expandedState[id] {
+			m.view.expandedState[id] = true
+			expanded = true
+		}
+	}
+	if !expanded {
+		return m, false
+	}
+	m.refreshData(m.day.ID)
+	return m, find()
+}
+
 func (m DashboardModel) canModifyGoals() bool {
 	return !m.security.lock.Locked && !m.inInputMode()
 }
@@ -235,6 +303,25 @@ func (m *DashboardModel) loadWorkspaces() error {
 	return nil
 }
 
+// announceUnblocked reports goals whose last blocker completed since the
+// previous refresh.
+func (m *DashboardModel) announceUnblocked() {
+	transitions, err := m.db.GetGoalTransitions(m.ctx, m.lastTransitionID)
+	if err != nil || len(transitions) == 0 {
+		return
+	}
+	m.lastTransitionID = transitions[len(transitions)-1].ID
+	var unblocked []database.GoalTransition
+	for _, t := range transitions {
+		if t.From == models.GoalStatusBlocked && t.Reason == database.UnblockedByCompletion {
+			unblocked = append(unblocked, t)
+		}
+	}
+	if len(unblocked) > 0 {
+		m.Message = "Unblocked: " + database.FormatTransitionGoals(unblocked)
+	}
+}
+
 func (m *DashboardModel) refreshData(dayID int64) {
 	m.clearStatus()
 	// Initialize with empty placeholders to prevent panics
@@ -255,6 +342,7 @@ func (m *DashboardModel) refreshData(dayID int64) {
 		m.setStatusError(fmt.Sprintf("Error loading blocked goals: %v", err))
 		return
 	}
+	m.announceUnblocked()
 	m.timer.ActiveTask = nil
 	if task, err := m.db.GetActiveTask(m.ctx, activeWS.ID); err == nil {
 		m.timer.ActiveTask = task
@@ -353,6 +441,7 @@ func (m *DashboardModel) refreshData(dayID int64) {
 			m.setStatusError(fmt.Sprintf("Error loading backlog goals: %v", err))
 			return
 		}
+		m.refreshForecast(activeWS.ID, day, rawSprints, backlogGoals)
 		backlogTree := applyBlocked(pruneCompleted(cloneGoals(backlogGoals)), 0)
 		flatBacklog := Flatten(backlogTree, 0, m.view.expandedState, 0)
 		fullList = append(fullList, SprintView{Sprint: models.Sprint{ID: 0, SprintNumber: 0}, Goals: flatBacklog})
@@ -373,8 +462,21 @@ func (m *DashboardModel) refreshData(dayID int64) {
 		fullList = append(fullList, sprintView)
 	}
 
+	// Kanban status columns follow the sprint columns
+	if activeWS.BoardMode == BoardModeKanban {
+		roots, err := m.kanbanRoots(activeWS.ID, dayID, rawSprints)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Error loading Kanban board: %v", err))
+			return
+		}
+		fullList = append(fullList, buildKanbanColumns(m.workflow(), applyBlocked(roots, 0), m.view.expandedState)...)
+	}
+
+	m.refreshMeetings(day.Date)
+
 	m.sprints = fullList
 	m.day, m.journalEntries = day, journalEntries
+	m.focusKanbanColumn()
 	m.timer.ActiveSprint = nil
 	for i := range m.sprints {
 		if m.sprints[i].Status == models.StatusActive {
@@ -387,7 +489,7 @@ func (m *DashboardModel) refreshData(dayID int64) {
 func (m *DashboardModel) buildDepOptions(targetID int64) []depOption {
 	var opts []depOption
 	for _, sprint := range m.sprints {
-		if sprint.SprintNumber == -2 {
+		if sprint.SprintNumber == -2 || sprint.Column != "" {
 			continue
 		}
 		var title string
@@ -397,7 +499,7 @@ func (m *DashboardModel) buildDepOptions(targetID int64) []depOption {
 		case 0:
 			title = "Backlog"
 		default:
-			title = fmt.Sprintf("Sprint %d", sprint.SprintNumber)
+			title = FormatSprintTitle(sprint.SprintNumber, sprint.Label)
 		}
 		for _, g := range sprint.Goals {
 			if g.ID == targetID {
@@ -127,6 +127,81 @@ func (m *ModalManager) JournalState() (*JournalState, bool) {
 	return state, ok
 }
 
+func (m *ModalManager) TemplateState() (*TemplateState, bool) {
+	state, ok := m.current.(*TemplateState)
+	return state, ok
+}
+
+func (m *ModalManager) CarryOverState() (*CarryOverState, bool) {
+	state, ok := m.current.(*CarryOverState)
+	return state, ok
+}
+
+func (m *ModalManager) JournalBrowserState() (*JournalBrowserState, bool) {
+	state, ok := m.current.(*JournalBrowserState)
+	return state, ok
+}
+
+func (m *ModalManager) RetroState() (*RetroState, bool) {
+	state, ok := m.current.(*RetroState)
+	return state, ok
+}
+
+func (m *ModalManager) DepGraphState() (*DepGraphState, bool) {
+	state, ok := m.current.(*DepGraphState)
+	return state, ok
+}
+
+func (m *ModalManager) AutoPlanState() (*AutoPlanState, bool) {
+	state, ok := m.current.(*AutoPlanState)
+	return state, ok
+}
+
+func (m *ModalManager) WorkflowState() (*WorkflowState, bool) {
+	state, ok := m.current.(*WorkflowState)
+	return state, ok
+}
+
+func (m *ModalManager) WeekState() (*WeekState, bool) {
+	state, ok := m.current.(*WeekState)
+	return state, ok
+}
+
+func (m *ModalManager) PlanDayState() (*PlanDayState, bool) {
+	state, ok := m.current.(*PlanDayState)
+	return state, ok
+}
+
+func (m *ModalManager) AnalyticsState() (*AnalyticsState, bool) {
+	state, ok := m.current.(*AnalyticsState)
+	return state, ok
+}
+
+func (m *ModalManager) CSVImportState() (*CSVImportState, bool) {
+	state, ok := m.current.(*CSVImportState)
+	return state, ok
+}
+
+func (m *ModalManager) CalendarFileState() (*CalendarFileState, bool) {
+	state, ok := m.current.(*CalendarFileState)
+	return state, ok
+}
+
+func (m *ModalManager) CorrectionState() (*CorrectionState, bool) {
+	state, ok := m.current.(*CorrectionState)
+	return state, ok
+}
+
+func (m *ModalManager) JournalSelectState() (*JournalSelectState, bool) {
+	state, ok := m.current.(*JournalSelectState)
+	return state, ok
+}
+
+func (m *ModalManager) LinksState() (*LinksState, bool) {
+	state, ok := m.current.(*LinksState)
+	return state, ok
+}
+
 // InputState stores all text input models.
 type InputState struct {
 	textInput         textinput.Model
@@ -24,6 +24,12 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if msg, ok := msg.(TickMsg); ok {
 		return m.handleTick(msg)
 	}
+	if msg, ok := msg.(notesEditedMsg); ok {
+		return m.handleNotesEdited(msg)
+	}
+	if msg, ok := msg.(journalEditedMsg); ok {
+		return m.handleJournalEdited(msg)
+	}
 
 	if m.security.lock.Locked {
 		return m.handleLockedState(msg)
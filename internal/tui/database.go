@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/database"
 	"github.com/akyairhashvil/SSPT/internal/models"
@@ -26,12 +27,23 @@ type Database interface {
 	GetWorkspaceIDBySlug(ctx context.Context, slug string) (int64, bool, error)
 	UpdateWorkspaceViewMode(ctx context.Context, workspaceID int64, mode int) error
 	UpdateWorkspaceTheme(ctx context.Context, workspaceID int64, theme string) error
+	UpdateWorkspaceWorkflow(ctx context.Context, workspaceID int64, workflow models.Workflow) error
+	UpdateWorkspaceBoardMode(ctx context.Context, workspaceID int64, mode int) error
+	UpdateWorkspaceWIPLimits(ctx context.Context, workspaceID int64, limits map[models.GoalStatus]int) error
 	UpdateWorkspacePaneVisibility(ctx context.Context, workspaceID int64, showBacklog, showCompleted, showArchived bool) error
 
 	CheckCurrentDay(ctx context.Context) int64
 	BootstrapDay(ctx context.Context, workspaceID int64, numSprints int) error
+	PlanDay(ctx context.Context, workspaceID int64, date string, numSprints int) (int64, error)
+	StartPlannedDay(ctx context.Context, dayID int64) (bool, error)
+	BackfillDay(ctx context.Context, workspaceID int64, date string, numSprints int) (int64, error)
 	GetDay(ctx context.Context, id int64) (models.Day, error)
 	GetAdjacentDay(ctx context.Context, currentDayID int64, direction int) (int64, string, error)
+	GetWeekSummary(ctx context.Context, workspaceID int64, start string) ([]database.DaySummary, error)
+	GetAnalytics(ctx context.Context, workspaceID int64, from, to string) (database.Analytics, error)
+	GetHeatmap(ctx context.Context, workspaceIDs []int64, from, to string) ([]database.HeatmapDay, error)
+	GetVelocity(ctx context.Context, workspaceID int64, from, to string) ([]database.VelocityDay, error)
+	GetTimeReport(ctx context.Context, workspaceIDs []int64, from, to string) (database.TimeReport, error)
 	GetSprints(ctx context.Context, dayID int64, workspaceID int64) ([]models.Sprint, error)
 	AppendSprint(ctx context.Context, dayID int64, workspaceID int64) error
 	RemoveLastSprint(ctx context.Context, dayID int64, workspaceID int64) error
@@ -41,6 +53,8 @@ type Database interface {
 	CompleteSprint(ctx context.Context, sprintID int64) error
 	ResetSprint(ctx context.Context, sprintID int64) error
 	MovePendingToBacklog(ctx context.Context, sprintID int64) error
+	CorrectSprint(ctx context.Context, sprintID int64, c database.SprintCorrection) error
+	CompleteGoalAt(ctx context.Context, goalID int64, at time.Time) error
 
 	AddGoal(ctx context.Context, workspaceID int64, description string, sprintID int64) error
 	AddGoalDetailed(ctx context.Context, workspaceID int64, sprintID int64, seed database.GoalSeed) error
@@ -49,15 +63,34 @@ type Database interface {
 	EditGoal(ctx context.Context, goalID int64, newDescription string) error
 	DeleteGoal(ctx context.Context, goalID int64) error
 	MoveGoal(ctx context.Context, goalID int64, targetSprintID int64) error
+	MoveGoals(ctx context.Context, moves []database.GoalMove) error
+	MoveGoalToDate(ctx context.Context, goalID int64, workspaceID int64, date string) (int, error)
 	UpdateGoalPriority(ctx context.Context, goalID int64, priority int) error
 	UpdateGoalStatus(ctx context.Context, goalID int64, status models.GoalStatus) error
 	UpdateGoalRecurrence(ctx context.Context, goalID int64, rule string) error
+	MaterializeRecurringGoals(ctx context.Context, date string) (int, error)
 	SetGoalTags(ctx context.Context, goalID int64, tags []string) error
 	SetGoalDependencies(ctx context.Context, goalID int64, deps []int64) error
 	GetGoalByID(ctx context.Context, goalID int64) (models.Goal, error)
 	GetGoalDependencies(ctx context.Context, goalID int64) (map[int64]bool, error)
 	IsGoalBlocked(ctx context.Context, goalID int64) (bool, error)
 	GetBlockedGoalIDs(ctx context.Context, workspaceID int64) (map[int64]bool, error)
+	GetDependencyEdges(ctx context.Context, workspaceID int64) (map[int64][]int64, error)
+	GetDependencyGoals(ctx context.Context, workspaceID int64) ([]models.Goal, error)
+	GetGoalTransitions(ctx context.Context, afterID int64) ([]database.GoalTransition, error)
+	GetLastGoalTransitionID(ctx context.Context) (int64, error)
+	GetCarryOverGoals(ctx context.Context, workspaceID int64, date string) ([]models.Goal, error)
+	CarryOverGoal(ctx context.Context, goalID int64, sprintID int64) error
+	SkipCarryOver(ctx context.Context, goalID int64) error
+	DeferGoal(ctx context.Context, goalID int64, date string) error
+	GetChronicCarryOvers(ctx context.Context, workspaceID int64, minCount int) ([]models.Goal, error)
+	SetGoalLinks(ctx context.Context, goalID int64, links []string) error
+	UpdateGoalNotes(ctx context.Context, goalID int64, notes string) error
+	UpdateJournalEntry(ctx context.Context, entryID int64, content string) error
+	DeleteJournalEntry(ctx context.Context, entryID int64) error
+	SearchJournal(ctx context.Context, filter database.JournalFilter) ([]database.JournalRecord, error)
+	SaveSprintRetro(ctx context.Context, sprintID int64, retro models.SprintRetro) error
+	GetFocusTrend(ctx context.Context, workspaceID int64, days int) ([]database.FocusTrendPoint, error)
 	ArchiveGoal(ctx context.Context, goalID int64) error
 	UnarchiveGoal(ctx context.Context, goalID int64) error
 	StartTaskTimer(ctx context.Context, goalID int64) error
@@ -70,7 +103,15 @@ type Database interface {
 	GetAllGoals(ctx context.Context) ([]models.Goal, error)
 	GetActiveTask(ctx context.Context, workspaceID int64) (*models.Goal, error)
 	GoalExistsDetailed(ctx context.Context, workspaceID int64, sprintID int64, parentID *int64, seed database.GoalSeed) (bool, error)
+	CheckGoalExists(ctx context.Context, workspaceID int64, sprintID int64, parentID *int64, description string, level database.ExistsCheckLevel, seed *database.GoalSeed) (database.ExistsResult, error)
 	GetLastGoalID(ctx context.Context) (int64, error)
+	SaveGoalTemplate(ctx context.Context, name string, goal database.TemplateGoal) error
+	GetGoalTemplates(ctx context.Context) ([]database.GoalTemplate, error)
+	InstantiateGoalTemplate(ctx context.Context, tpl database.GoalTemplate, workspaceID, sprintID int64, at time.Time) (int64, error)
+	SaveDayTemplate(ctx context.Context, tpl database.DayTemplate) error
+	GetDayTemplates(ctx context.Context) ([]database.DayTemplate, error)
+	DayTemplateFor(ctx context.Context, day time.Time) (database.DayTemplate, bool, error)
+	ApplyDayTemplate(ctx context.Context, tpl database.DayTemplate, workspaceID int64) error
 	Search(ctx context.Context, query util.SearchQuery, workspaceID int64) ([]models.Goal, error)
 
 	AddJournalEntry(ctx context.Context, dayID int64, workspaceID int64, sprintID *int64, goalID *int64, content string) error
@@ -79,6 +120,7 @@ type Database interface {
 	GetAllDays(ctx context.Context) ([]database.ExportDay, error)
 	GetAllSprintsFlat(ctx context.Context) ([]database.ExportSprint, error)
 	GetAllGoalsExport(ctx context.Context) ([]database.ExportGoal, error)
+	GetAllTaskSessions(ctx context.Context) ([]database.ExportTaskSession, error)
 	GetAllJournalEntriesExport(ctx context.Context) ([]database.ExportJournalEntry, error)
 	GetAllTaskDeps(ctx context.Context) ([]database.ExportTaskDep, error)
 }
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+// depGraph is a layered view of the dependency DAG. Layer 0 holds goals with
+// no prerequisites in the graph; every other goal sits one layer below its
+// deepest prerequisite.
+type depGraph struct {
+	Nodes    map[int64]models.Goal
+	Deps     map[int64][]int64 // goal -> prerequisites
+	Layers   [][]int64
+	Blocked  map[int64]bool
+	Critical []int64 // longest remaining chain, first prerequisite first
+	Weight   int     // effort weight of the critical chain
+}
+
+// buildDepGraph lays out the dependency graph. When focusID is set, only the
+// goal's prerequisites and dependents (transitively) are included.
+func buildDepGraph(goals []models.Goal, edges map[int64][]int64, focusID int64) depGraph {
+	g := depGraph{
+		Nodes:   make(map[int64]models.Goal),
+		Deps:    make(map[int64][]int64),
+		Blocked: make(map[int64]bool),
+	}
+	all := make(map[int64]models.Goal, len(goals))
+	for _, goal := range goals {
+		all[goal.ID] = goal
+	}
+	dependents := make(map[int64][]int64)
+	for id, deps := range edges {
+		for _, dep := range deps {
+			if _, ok := all[id]; !ok {
+				continue
+			}
+			if _, ok := all[dep]; !ok {
+				continue
+			}
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+
+	include := func(id int64) {
+		if goal, ok := all[id]; ok {
+			g.Nodes[id] = goal
+		}
+	}
+	if focusID == 0 {
+		for id := range all {
+			include(id)
+		}
+	} else if _, ok := all[focusID]; ok {
+		include(focusID)
+		walk := func(next map[int64][]int64) {
+			queue := []int64{focusID}
+			seen := map[int64]bool{focusID: true}
+			for len(queue) > 0 {
+				id := queue[0]
+				queue = queue[1:]
+				for _, n := range next[id] {
+					if !seen[n] {
+						seen[n] = true
+						include(n)
+						queue = append(queue, n)
+					}
+				}
+			}
+		}
+		walk(edges)
+		walk(dependents)
+	}
+	for id := range g.Nodes {
+		for _, dep := range edges[id] {
+			if _, ok := g.Nodes[dep]; ok {
+				g.Deps[id] = append(g.Deps[id], dep)
+				if g.Nodes[dep].Status != models.GoalStatusCompleted && g.Nodes[id].Status != models.GoalStatusCompleted {
+					g.Blocked[id] = true
+				}
+			}
+		}
+		sort.Slice(g.Deps[id], func(i, j int) bool { return g.Deps[id][i] < g.Deps[id][j] })
+	}
+
+	// Layers and the heaviest remaining chain, memoised per node. The
+	// database refuses cycles, but guard against them anyway.
+	layer := make(map[int64]int)
+	dist := make(map[int64]int)
+	prev := make(map[int64]int64)
+	visiting := make(map[int64]bool)
+	var visit func(id int64)
+	visit = func(id int64) {
+		if _, ok := layer[id]; ok || visiting[id] {
+			return
+		}
+		visiting[id] = true
+		l, best, bestDep := 0, 0, int64(0)
+		for _, dep := range g.Deps[id] {
+			visit(dep)
+			if layer[dep]+1 > l {
+				l = layer[dep] + 1
+			}
+			if dist[dep] > best {
+				best, bestDep = dist[dep], dep
+			}
+		}
+		visiting[id] = false
+		layer[id] = l
+		w := 0
+		if g.Nodes[id].Status != models.GoalStatusCompleted {
+			w = models.EffortPoints(g.Nodes[id].Effort)
+		}
+		dist[id] = best + w
+		prev[id] = bestDep
+	}
+	ids := make([]int64, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var end int64
+	for _, id := range ids {
+		visit(id)
+		for len(g.Layers) <= layer[id] {
+			g.Layers = append(g.Layers, nil)
+		}
+		g.Layers[layer[id]] = append(g.Layers[layer[id]], id)
+		if dist[id] > g.Weight {
+			g.Weight, end = dist[id], id
+		}
+	}
+	for id := end; id != 0; id = prev[id] {
+		if g.Nodes[id].Status != models.GoalStatusCompleted {
+			g.Critical = append([]int64{id}, g.Critical...)
+		}
+	}
+	return g
+}
+
+// Order lists the nodes layer by layer, the order the cursor walks them.
+func (g depGraph) Order() []int64 {
+	var out []int64
+	for _, l := range g.Layers {
+		out = append(out, l...)
+	}
+	return out
+}
+
+// IsCritical reports whether id lies on the critical chain.
+func (g depGraph) IsCritical(id int64) bool {
+	for _, c := range g.Critical {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBuildDepGraph(t *testing.T) {
+	large, medium := "L", "M"
+	goals := []models.Goal{
+		{ID: 1, Description: "Design", Status: models.GoalStatusCompleted, Effort: &large},
+		{ID: 2, Description: "Spec", Effort: &medium},
+		{ID: 3, Description: "Build"},
+		{ID: 4, Description: "Ship", Effort: &large},
+		{ID: 5, Description: "Docs"},
+		{ID: 6, Description: "Elsewhere"},
+		{ID: 7, Description: "Elsewhere too"},
+	}
+	// 3 waits on 1 and 2; 4 waits on 3; 5 waits on 1; 7 waits on 6.
+	edges := map[int64][]int64{3: {2, 1}, 4: {3}, 5: {1}, 7: {6}}
+
+	g := buildDepGraph(goals, edges, 0)
+	if len(g.Layers) != 3 || len(g.Layers[0]) != 3 || g.Layers[2][0] != 4 {
+		t.Fatalf("unexpected layers %v", g.Layers)
+	}
+	if !g.Blocked[3] || !g.Blocked[4] || g.Blocked[5] || g.Blocked[1] {
+		t.Fatalf("unexpected blocked set %v", g.Blocked)
+	}
+	// Spec (2) + Build (1) + Ship (3); the completed Design weighs nothing.
+	if g.Weight != 6 || len(g.Critical) != 3 || g.Critical[0] != 2 || g.Critical[2] != 4 {
+		t.Fatalf("unexpected critical path %v (weight %d)", g.Critical, g.Weight)
+	}
+
+	focused := buildDepGraph(goals, edges, 3)
+	if len(focused.Nodes) != 4 {
+		t.Fatalf("expected Build's prerequisites and dependents only, got %v", focused.Order())
+	}
+	if _, ok := focused.Nodes[5]; ok {
+		t.Fatalf("sibling dependent should not be included")
+	}
+}
+
+func TestDependencyGraphModal(t *testing.T) {
+	m, idA, idB, _, sprintIdx := setupTwoGoalsInSprint(t)
+	if err := m.db.SetGoalDependencies(m.ctx, idB, []int64{idA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	m.view.focusedColIdx = sprintIdx
+	m.view.focusedGoalIdx = 0
+
+	m, _, _ = m.handleDependencyGraph("g")
+	state, ok := m.modal.DepGraphState()
+	if !ok || len(state.Graph.Nodes) != 2 {
+		t.Fatalf("expected graph of the focused goal, got %+v", m.modal.Current())
+	}
+	m.width = 120
+	view := m.renderDepGraph(state)
+	if !strings.Contains(view, "Layer 2") || !strings.Contains(view, "←") {
+		t.Fatalf("expected layered diagram, got %q", view)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if state.FocusID != 0 {
+		t.Fatalf("expected workspace scope after w")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected graph closed after jump")
+	}
+	if goal, ok := m.focusedGoal(); !ok || goal.ID != idB {
+		t.Fatalf("expected cursor on the dependent goal, got %+v", goal)
+	}
+}
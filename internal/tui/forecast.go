@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// forecastPercentiles are the confidence levels of a forecast.
+var forecastPercentiles = [3]float64{0.50, 0.85, 0.95}
+
+// Forecast is the range of days in which open goals are expected to be done,
+// simulated from the days of the recent past.
+type Forecast struct {
+	Goals         int
+	Points        int
+	SprintsPerDay int
+	PerSprint     float64 // Average points completed per sprint
+	Days          [3]int  // Days from today per percentile; -1 beyond the horizon
+	Known         bool    // False when no points were completed recently
+}
+
+// simulateForecast runs Monte Carlo trials: each future day replays a random
+// past day, with its points per sprint scaled to sprintsPerDay. Past days
+// without sprints stay days off, so the cadence of the history carries over.
+func simulateForecast(history []database.VelocityDay, points, sprintsPerDay int, rng *rand.Rand) Forecast {
+	f := Forecast{Points: points, SprintsPerDay: sprintsPerDay}
+	samples := make([]float64, len(history))
+	totalPoints, totalSprints := 0, 0
+	for i, day := range history {
+		if day.Sprints > 0 {
+			samples[i] = float64(day.Points) / float64(day.Sprints) * float64(sprintsPerDay)
+			totalSprints += day.Sprints
+		}
+		totalPoints += day.Points
+	}
+	if totalSprints > 0 {
+		f.PerSprint = float64(totalPoints) / float64(totalSprints)
+	}
+	if points <= 0 {
+		f.Known = true
+		return f
+	}
+	if totalPoints == 0 || totalSprints == 0 || sprintsPerDay <= 0 {
+		return f
+	}
+	f.Known = true
+	results := make([]int, config.ForecastTrials)
+	for t := range results {
+		done, day := 0.0, 0
+		for done < float64(points) && day <= config.ForecastMaxDays {
+			day++
+			done += samples[rng.Intn(len(samples))]
+		}
+		results[t] = day
+	}
+	sort.Ints(results)
+	for i, p := range forecastPercentiles {
+		f.Days[i] = results[int(p*float64(len(results)-1))]
+		if f.Days[i] > config.ForecastMaxDays {
+			f.Days[i] = -1
+		}
+	}
+	return f
+}
+
+// hasTag reports whether the goal carries tag (with or without '#').
+func hasTag(goal models.Goal, tag string) bool {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	if tag == "" {
+		return true
+	}
+	if goal.Tags == nil {
+		return false
+	}
+	for _, t := range util.JSONToTags(*goal.Tags) {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadForecast forecasts the open top-level backlog goals of the active
+// workspace, limited to tag when it is set, at today's number of sprints.
+func (m DashboardModel) loadForecast(tag string) (Forecast, error) {
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	goals, err := m.db.GetBacklogGoals(m.ctx, wsID)
+	if err != nil {
+		return Forecast{}, err
+	}
+	count, points := 0, 0
+	for _, g := range goals {
+		if g.ParentID == nil && hasTag(g, tag) {
+			count++
+			points += models.EffortPoints(g.Effort)
+		}
+	}
+	now := time.Now()
+	history, err := m.db.GetVelocity(m.ctx, wsID,
+		now.AddDate(0, 0, -config.ForecastHistoryDays).Format(util.DateLayout),
+		now.AddDate(0, 0, -1).Format(util.DateLayout))
+	if err != nil {
+		return Forecast{}, err
+	}
+	sprintsPerDay := 0
+	if todayID := m.db.CheckCurrentDay(m.ctx); todayID > 0 {
+		if sprints, err := m.db.GetSprints(m.ctx, todayID, wsID); err == nil {
+			for _, s := range sprints {
+				if s.SprintNumber > 0 {
+					sprintsPerDay++
+				}
+			}
+		}
+	}
+	if sprintsPerDay == 0 {
+		// No day today yet: use the usual number of sprints.
+		total, days := 0, 0
+		for _, day := range history {
+			if day.Sprints > 0 {
+				total += day.Sprints
+				days++
+			}
+		}
+		if days > 0 {
+			sprintsPerDay = (total + days/2) / days
+		}
+	}
+	// Seeding with the points keeps the dates steady between refreshes.
+	f := simulateForecast(history, points, sprintsPerDay, rand.New(rand.NewSource(int64(points))))
+	f.Goals = count
+	return f, nil
+}
+
+// refreshForecast updates the backlog header forecast. The simulation only
+// runs again when its inputs change: the date, the backlog's goals and
+// effort, or the number of sprints today.
+func (m *DashboardModel) refreshForecast(wsID int64, day models.Day, sprints []models.Sprint, backlog []GoalView) {
+	count, points := 0, 0
+	for _, g := range backlog {
+		if g.ParentID == nil {
+			count++
+			points += models.EffortPoints(g.Effort)
+		}
+	}
+	today := time.Now().Format(util.DateLayout)
+	sprintsToday := -1
+	if day.Date == today {
+		sprintsToday = 0
+		for _, s := range sprints {
+			if s.SprintNumber > 0 {
+				sprintsToday++
+			}
+		}
+	}
+	key := fmt.Sprintf("%d:%s:%d:%d:%d", wsID, today, count, points, sprintsToday)
+	if key == m.forecastKey {
+		return
+	}
+	m.forecast, m.forecastKey = nil, key
+	if f, err := m.loadForecast(""); err == nil && f.Known && f.Points > 0 {
+		m.forecast = &f
+	}
+}
+
+// forecastDate names the day n days from today; -1 is beyond the horizon.
+func forecastDate(n int) string {
+	if n < 0 {
+		return "later"
+	}
+	return time.Now().AddDate(0, 0, n).Format("Jan 2")
+}
+
+// Range is the short form of the forecast, from the 50th to the 85th
+// percentile, e.g. "Nov 3–Nov 10".
+func (f Forecast) Range() string {
+	if f.Days[0] == f.Days[1] {
+		return forecastDate(f.Days[0])
+	}
+	return forecastDate(f.Days[0]) + "–" + forecastDate(f.Days[1])
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSimulateForecast(t *testing.T) {
+	steady := []database.VelocityDay{{Sprints: 1, Points: 2}, {Sprints: 2, Points: 4}}
+	f := simulateForecast(steady, 10, 2, rand.New(rand.NewSource(1)))
+	if !f.Known || f.PerSprint != 2 || f.Days != [3]int{3, 3, 3} {
+		t.Fatalf("expected 3 days at 4 points a day, got %+v", f)
+	}
+
+	withDaysOff := []database.VelocityDay{{Sprints: 2, Points: 4}, {}, {Sprints: 1, Points: 1}, {}}
+	f = simulateForecast(withDaysOff, 20, 2, rand.New(rand.NewSource(1)))
+	if !f.Known || f.Days[0] <= 5 || f.Days[0] > f.Days[1] || f.Days[1] > f.Days[2] {
+		t.Fatalf("expected a widening range beyond 5 days, got %+v", f)
+	}
+
+	if f := simulateForecast([]database.VelocityDay{{Sprints: 2}}, 5, 2, rand.New(rand.NewSource(1))); f.Known {
+		t.Fatalf("expected no forecast without completed points")
+	}
+	if f := simulateForecast(nil, 0, 2, rand.New(rand.NewSource(1))); !f.Known || f.Days != [3]int{} {
+		t.Fatalf("expected an empty backlog to be done today, got %+v", f)
+	}
+}
+
+func TestBacklogForecast(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width, m.height = 160, 40
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	yesterday := time.Now().AddDate(0, 0, -1)
+	if _, err := m.db.BackfillDay(m.ctx, wsID, yesterday.Format(util.DateLayout), 1); err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	for _, seed := range []database.GoalSeed{
+		{Description: "Shipped", Effort: "M"},
+		{Description: "Docs", Effort: "M", Tags: []string{"docs"}},
+		{Description: "Refactor", Effort: "XL"},
+	} {
+		if err := m.db.AddGoalDetailed(m.ctx, wsID, 0, seed); err != nil {
+			t.Fatalf("AddGoalDetailed failed: %v", err)
+		}
+	}
+	goals, err := m.db.GetBacklogGoals(m.ctx, wsID)
+	if err != nil {
+		t.Fatalf("GetBacklogGoals failed: %v", err)
+	}
+	for _, g := range goals {
+		if g.Description == "Shipped" {
+			at := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 12, 0, 0, 0, time.Local)
+			if err := m.db.CompleteGoalAt(m.ctx, g.ID, at); err != nil {
+				t.Fatalf("CompleteGoalAt failed: %v", err)
+			}
+		}
+	}
+
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if m.forecast == nil || m.forecast.Points != 7 || m.forecast.SprintsPerDay != 1 {
+		t.Fatalf("expected a backlog forecast of 7 points, got %+v", m.forecast)
+	}
+	if view := m.renderBoard(m.height, m.buildBoardLayout()); !strings.Contains(view, "Backlog ~") {
+		t.Fatalf("expected forecast in the backlog header:\n%s", view)
+	}
+
+	cached := m.forecast
+	m.refreshData(m.day.ID)
+	if m.forecast != cached {
+		t.Fatalf("expected the forecast to be reused while the backlog is unchanged")
+	}
+	if err := m.db.AddGoalDetailed(m.ctx, wsID, 0, database.GoalSeed{Description: "Tests", Effort: "S"}); err != nil {
+		t.Fatalf("AddGoalDetailed failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if m.forecast == nil || m.forecast.Points != 8 {
+		t.Fatalf("expected the forecast to follow the backlog, got %+v", m.forecast)
+	}
+
+	m, _, _ = m.handleAnalyticsView("M")
+	state, _ := m.modal.AnalyticsState()
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m.inputs.textInput.SetValue("#docs")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if state.ForecastTag != "docs" || state.Forecast.Goals != 1 || state.Forecast.Points != 2 {
+		t.Fatalf("expected forecast of the #docs goal, got %+v", state.Forecast)
+	}
+	if view := m.renderAnalytics(state); !strings.Contains(view, "85% by") {
+		t.Fatalf("expected percentile dates:\n%s", view)
+	}
+}
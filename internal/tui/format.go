@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,15 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dh %dm", hours, mins)
 }
 
+// FormatSprintTitle names a sprint column, e.g. "Sprint 2" or
+// "Sprint 2: Admin" when the sprint has a label.
+func FormatSprintTitle(number int, label *string) string {
+	if label != nil && strings.TrimSpace(*label) != "" {
+		return fmt.Sprintf("Sprint %d: %s", number, strings.TrimSpace(*label))
+	}
+	return fmt.Sprintf("Sprint %d", number)
+}
+
 // FormatTimeRemaining formats remaining time with appropriate precision.
 func FormatTimeRemaining(remaining time.Duration) string {
 	if remaining <= 0 {
@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestHandleGoalPriority(t *testing.T) {
@@ -134,3 +137,116 @@ func TestHandleGoalTagging(t *testing.T) {
 		t.Fatalf("expected pending goal")
 	}
 }
+
+func TestCompletingLastBlockerAnnouncesUnblocked(t *testing.T) {
+	m, idA, idB, _, sprintIdx := setupTwoGoalsInSprint(t)
+	if err := m.db.SetGoalDependencies(m.ctx, idB, []int64{idA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if goal, err := m.db.GetGoalByID(m.ctx, idB); err != nil || goal.Status != models.GoalStatusBlocked {
+		t.Fatalf("expected goal B blocked, got %+v (%v)", goal, err)
+	}
+	if m.Message != "" {
+		t.Fatalf("expected no notice when a goal becomes blocked, got %q", m.Message)
+	}
+
+	m.view.focusedColIdx = sprintIdx
+	for i, g := range m.sprints[sprintIdx].Goals {
+		if g.ID == idA {
+			m.view.focusedGoalIdx = i
+		}
+	}
+	m, _, _ = m.handleGoalStatusToggle(" ")
+	want := fmt.Sprintf("Unblocked: #%d", idB)
+	if !strings.HasPrefix(m.Message, want) {
+		t.Fatalf("expected %q notice, got %q", want, m.Message)
+	}
+	if goal, err := m.db.GetGoalByID(m.ctx, idB); err != nil || goal.Status != models.GoalStatusPending {
+		t.Fatalf("expected goal B pending again, got %+v (%v)", goal, err)
+	}
+}
+
+func TestDeletingBlockerDoesNotAnnounceUnblocked(t *testing.T) {
+	m, idA, idB, _, _ := setupTwoGoalsInSprint(t)
+	if err := m.db.SetGoalDependencies(m.ctx, idB, []int64{idA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+
+	if err := m.db.DeleteGoal(m.ctx, idA); err != nil {
+		t.Fatalf("DeleteGoal failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if strings.Contains(m.Message, "Unblocked") {
+		t.Fatalf("expected no unblocked notice after deleting the blocker, got %q", m.Message)
+	}
+	if goal, err := m.db.GetGoalByID(m.ctx, idB); err != nil || goal.Status != models.GoalStatusPending {
+		t.Fatalf("expected goal B pending again, got %+v (%v)", goal, err)
+	}
+}
+
+func TestGoalStatusWorkflow(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	focus := func() {
+		t.Helper()
+		m.view.focusedColIdx = sprintIdx
+		for i, g := range m.sprints[sprintIdx].Goals {
+			if g.ID == idA {
+				m.view.focusedGoalIdx = i
+				return
+			}
+		}
+		t.Fatalf("goal %d not on the board", idA)
+	}
+	status := func() models.GoalStatus {
+		t.Helper()
+		goal, err := m.db.GetGoalByID(m.ctx, idA)
+		if err != nil {
+			t.Fatalf("GetGoalByID failed: %v", err)
+		}
+		return goal.Status
+	}
+
+	focus()
+	for _, want := range []models.GoalStatus{models.GoalStatusInProgress, models.GoalStatusReview} {
+		m, _, _ = m.handleGoalStatusStep("]")
+		if got := status(); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+		focus()
+	}
+	m.width, m.height = 160, 40
+	if !strings.Contains(m.renderBoard(30, m.buildBoardLayout()), "[review]") {
+		t.Fatalf("expected review badge on the board")
+	}
+	m, _, _ = m.handleGoalStatusStep("[")
+	if got := status(); got != models.GoalStatusInProgress {
+		t.Fatalf("expected in_progress after regress, got %s", got)
+	}
+
+	m, _, _ = m.handleWorkspaceWorkflow("F")
+	if !m.modal.Is(ModalWorkflow) {
+		t.Fatalf("expected workflow modal")
+	}
+	m.inputs.textInput.SetValue("pending, Doing, completed")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Message != "Workflow: pending → doing → completed" {
+		t.Fatalf("unexpected message %q", m.Message)
+	}
+
+	// in_progress is no longer part of the workflow, so it advances from pending.
+	focus()
+	m, _, _ = m.handleGoalStatusStep("]")
+	if got := status(); got != "doing" {
+		t.Fatalf("expected doing, got %s", got)
+	}
+	focus()
+	m, _, _ = m.handleGoalStatusStep("]")
+	if got := status(); got != models.GoalStatusCompleted {
+		t.Fatalf("expected completed, got %s", got)
+	}
+}
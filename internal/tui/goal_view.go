@@ -14,5 +14,6 @@ type GoalView struct {
 // SprintView wraps a sprint with UI-only goal state.
 type SprintView struct {
 	models.Sprint
-	Goals []GoalView
+	Goals  []GoalView
+	Column models.GoalStatus // set on Kanban status columns
 }
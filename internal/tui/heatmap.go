@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// HeatmapMetric selects what the heatmap counts per day.
+type HeatmapMetric int
+
+const (
+	HeatmapFocus HeatmapMetric = iota // Focused sprint minutes
+	HeatmapGoals                      // Completed goals
+)
+
+// HeatmapDays is the span of the heatmap: a year.
+const HeatmapDays = 365
+
+// heatmapLevels are the cell glyphs from no activity to the most active days.
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapLabelWidth is the width of the weekday labels left of the grid.
+const heatmapLabelWidth = 4
+
+func (m HeatmapMetric) String() string {
+	if m == HeatmapGoals {
+		return "completed goals"
+	}
+	return "focus minutes"
+}
+
+func (m HeatmapMetric) value(day database.HeatmapDay) int {
+	if m == HeatmapGoals {
+		return day.Completed
+	}
+	return int(day.Focus.Minutes())
+}
+
+// heatmapLevel maps a value to one of the heatmap levels, in quarters of the
+// busiest day.
+func heatmapLevel(value, max int) int {
+	if value <= 0 || max <= 0 {
+		return 0
+	}
+	level := int(math.Ceil(float64(value) / float64(max) * float64(len(heatmapLevels)-1)))
+	if level >= len(heatmapLevels) {
+		level = len(heatmapLevels) - 1
+	}
+	return level
+}
+
+// RenderHeatmap draws days as a contribution graph: one column per week,
+// one row per weekday from Monday, shaded by metric in the theme's colors.
+// The oldest weeks are dropped when the grid is wider than width.
+func RenderHeatmap(days []database.HeatmapDay, metric HeatmapMetric, theme Theme, width int) string {
+	if len(days) == 0 {
+		return theme.Dim.Render("No days") + "\n"
+	}
+	values := make(map[string]int, len(days))
+	max, total := 0, 0
+	best := days[0]
+	for _, day := range days {
+		v := metric.value(day)
+		values[day.Date] = v
+		total += v
+		if v > max {
+			max, best = v, day
+		}
+	}
+
+	first, _ := time.Parse(util.DateLayout, weekStart(days[0].Date))
+	last, _ := time.Parse(util.DateLayout, days[len(days)-1].Date)
+	weeks := int(last.Sub(first).Hours()/24)/7 + 1
+	if room := width - heatmapLabelWidth; room > 0 && weeks > room {
+		first = first.AddDate(0, 0, 7*(weeks-room))
+		weeks = room
+	}
+
+	var b strings.Builder
+	months := []rune(strings.Repeat(" ", weeks+3))
+	for w, prev, free := 0, time.Month(0), 0; w < weeks; w++ {
+		month := first.AddDate(0, 0, 7*w).Month()
+		if month != prev && w >= free {
+			copy(months[w:], []rune(month.String()[:3]))
+			free = w + 4
+		}
+		prev = month
+	}
+	b.WriteString(strings.Repeat(" ", heatmapLabelWidth) + theme.Dim.Render(strings.TrimRight(string(months), " ")) + "\n")
+
+	labels := []string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+	for row := 0; row < 7; row++ {
+		b.WriteString(theme.Dim.Render(fmt.Sprintf("%-*s", heatmapLabelWidth, labels[row])))
+		for w := 0; w < weeks; w++ {
+			date := first.AddDate(0, 0, 7*w+row).Format(util.DateLayout)
+			v, ok := values[date]
+			if !ok {
+				b.WriteString(" ")
+				continue
+			}
+			level := heatmapLevel(v, max)
+			if level == 0 {
+				b.WriteString(theme.Dim.Render(heatmapLevels[0]))
+			} else {
+				b.WriteString(theme.Highlight.Render(heatmapLevels[level]))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	legend := "Less"
+	for i, glyph := range heatmapLevels {
+		if i == 0 {
+			legend += " " + theme.Dim.Render(glyph)
+		} else {
+			legend += " " + theme.Highlight.Render(glyph)
+		}
+	}
+	b.WriteString(strings.Repeat(" ", heatmapLabelWidth) + legend + " More\n")
+	summary := fmt.Sprintf("%d %s", total, metric)
+	if metric == HeatmapFocus && total > 0 {
+		summary = FormatDuration(time.Duration(total)*time.Minute) + " focused"
+	}
+	if max > 0 && metric == HeatmapFocus {
+		summary += fmt.Sprintf(", busiest day %s (%s)", best.Date, FormatDuration(time.Duration(max)*time.Minute))
+	} else if max > 0 {
+		summary += fmt.Sprintf(", busiest day %s (%d)", best.Date, max)
+	}
+	b.WriteString(theme.Dim.Render(summary) + "\n")
+	return b.String()
+}
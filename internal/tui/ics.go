@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// ICSOptions selects the workspace and the dates (YYYY-MM-DD, empty for
+// open-ended) of a calendar export.
+type ICSOptions struct {
+	WorkspaceID int64
+	From        string
+	To          string
+}
+
+func (o ICSOptions) covers(date string) bool {
+	return (o.From == "" || date >= o.From) && (o.To == "" || date <= o.To)
+}
+
+// icsPriority maps goal priorities 1-5 onto the iCalendar scale 1-9.
+func icsPriority(priority int) int {
+	if priority < 1 || priority > 5 {
+		priority = 3
+	}
+	return priority*2 - 1
+}
+
+// BuildICS renders the sprints of a workspace as events, with the goals
+// worked on in each, and its goals with a due date as to-dos.
+func BuildICS(ctx context.Context, db Database, opts ICSOptions, now time.Time) (string, error) {
+	workspaces, err := db.GetWorkspaces(ctx)
+	if err != nil {
+		return "", err
+	}
+	var ws models.Workspace
+	for _, w := range workspaces {
+		if w.ID == opts.WorkspaceID {
+			ws = w
+		}
+	}
+	if ws.ID == 0 {
+		return "", fmt.Errorf("workspace %d not found", opts.WorkspaceID)
+	}
+	days, err := db.GetAllDays(ctx)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	goals, err := db.GetAllGoalsExport(ctx)
+	if err != nil {
+		return "", err
+	}
+	bySprint := make(map[int64][]database.ExportGoal)
+	for _, g := range goals {
+		if g.SprintID != nil && g.Status != string(models.GoalStatusArchived) {
+			bySprint[*g.SprintID] = append(bySprint[*g.SprintID], g)
+		}
+	}
+
+	var cal util.ICalBuilder
+	cal.Prop("BEGIN", "VCALENDAR")
+	cal.Prop("VERSION", "2.0")
+	cal.Prop("PRODID", "-//SSPT//SSPT "+AppVersion+"//EN")
+	cal.Prop("CALSCALE", "GREGORIAN")
+	cal.Text("X-WR-CALNAME", "SSPT "+ws.Name)
+
+	for _, day := range days {
+		if !opts.covers(day.Date) {
+			continue
+		}
+		sprints, err := db.GetSprints(ctx, day.ID, ws.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, slot := range scheduleSprints(day.Date, sprints, nil, now) {
+			s := slot.Sprint
+			cal.Prop("BEGIN", "VEVENT")
+			cal.Prop("UID", fmt.Sprintf("sspt-sprint-%d@%s", s.ID, ws.Slug))
+			cal.Time("DTSTAMP", now)
+			cal.Time("DTSTART", slot.Start)
+			cal.Time("DTEND", slot.End)
+			cal.Text("SUMMARY", FormatSprintTitle(s.SprintNumber, s.Label))
+			switch {
+			case s.Status == models.StatusInterrupted:
+				cal.Prop("STATUS", "CANCELLED")
+			case slot.Planned:
+				cal.Prop("STATUS", "TENTATIVE")
+			default:
+				cal.Prop("STATUS", "CONFIRMED")
+			}
+			lines := []string{fmt.Sprintf("%s sprint in %s", s.Status, ws.Name)}
+			if slot.Planned {
+				lines[0] += ", planned time"
+			} else if focused := s.FocusedDuration(now); focused > 0 {
+				lines[0] += ", focused " + FormatDuration(focused)
+			}
+			if sprintGoals := bySprint[s.ID]; len(sprintGoals) > 0 {
+				lines = append(lines, "Goals:")
+				for _, g := range sprintGoals {
+					check := "[ ]"
+					if g.Status == string(models.GoalStatusCompleted) {
+						check = "[x]"
+					}
+					lines = append(lines, check+" "+g.Description)
+				}
+			}
+			cal.Text("DESCRIPTION", strings.Join(lines, "\n"))
+			cal.Prop("END", "VEVENT")
+		}
+	}
+
+	for _, g := range goals {
+		if g.WorkspaceID == nil || *g.WorkspaceID != ws.ID || g.DueDate == nil || g.Status == string(models.GoalStatusArchived) || !opts.covers(*g.DueDate) {
+			continue
+		}
+		due, err := time.Parse(util.DateLayout, *g.DueDate)
+		if err != nil {
+			continue
+		}
+		cal.Prop("BEGIN", "VTODO")
+		cal.Prop("UID", fmt.Sprintf("sspt-goal-%d@%s", g.ID, ws.Slug))
+		cal.Time("DTSTAMP", now)
+		cal.Text("SUMMARY", g.Description)
+		cal.Prop("DUE;VALUE=DATE", due.Format(util.ICalDateLayout))
+		cal.Prop("PRIORITY", fmt.Sprint(icsPriority(g.Priority)))
+		switch models.GoalStatus(g.Status) {
+		case models.GoalStatusCompleted:
+			cal.Prop("STATUS", "COMPLETED")
+			if g.CompletedAt != nil {
+				if at, err := time.Parse(time.RFC3339, *g.CompletedAt); err == nil {
+					cal.Time("COMPLETED", at)
+				}
+			}
+		case models.GoalStatusPending, models.GoalStatusBlocked:
+			cal.Prop("STATUS", "NEEDS-ACTION")
+		default:
+			cal.Prop("STATUS", "IN-PROCESS")
+		}
+		if len(g.Tags) > 0 {
+			categories := make([]string, len(g.Tags))
+			for i, tag := range g.Tags {
+				categories[i] = util.ICalEscape(tag)
+			}
+			cal.Prop("CATEGORIES", strings.Join(categories, ","))
+		}
+		if g.Notes != nil && strings.TrimSpace(*g.Notes) != "" {
+			cal.Text("DESCRIPTION", *g.Notes)
+		}
+		cal.Prop("END", "VTODO")
+	}
+	cal.Prop("END", "VCALENDAR")
+	return cal.String(), nil
+}
+
+// ICSFileName names the calendar file of a workspace. It depends only on
+// the workspace and the range, so re-exporting updates a subscribed file.
+func ICSFileName(slug string, opts ICSOptions) string {
+	if opts.From != "" || opts.To != "" {
+		slug += "_" + opts.From + "_" + opts.To
+	}
+	return slug + ".ics"
+}
+
+// ExportICS writes the calendar of a workspace to the calendar folder of
+// the reports directory and returns its path.
+func ExportICS(ctx context.Context, db Database, opts ICSOptions, slug string) (string, error) {
+	path := filepath.Join(util.ReportsDir("sspt"), "calendar", ICSFileName(slug, opts))
+	if err := WriteICS(ctx, db, opts, path); err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// WriteICS writes the calendar of a workspace to path.
+func WriteICS(ctx context.Context, db Database, opts ICSOptions, path string) error {
+	content, err := BuildICS(ctx, db, opts, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
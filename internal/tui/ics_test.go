@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+func TestBuildICS(t *testing.T) {
+	m := setupTestDashboard(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	dayID, err := m.db.BackfillDay(m.ctx, wsID, "2024-03-01", 2)
+	if err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	sprints, err := m.db.GetSprints(m.ctx, dayID, wsID)
+	if err != nil || len(sprints) != 2 {
+		t.Fatalf("expected two sprints, got %d (%v)", len(sprints), err)
+	}
+	first, second := sprints[0], sprints[1]
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.Local)
+	if err := m.db.CorrectSprint(m.ctx, first.ID, database.SprintCorrection{Start: start, End: start.Add(30 * time.Minute), Focused: 25 * time.Minute}); err != nil {
+		t.Fatalf("CorrectSprint failed: %v", err)
+	}
+	for _, desc := range []string{"Write report", "Send report"} {
+		if err := m.db.AddGoal(m.ctx, wsID, desc, first.ID); err != nil {
+			t.Fatalf("AddGoal failed: %v", err)
+		}
+	}
+	done, err := m.db.GetLastGoalID(m.ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := m.db.UpdateGoalStatus(m.ctx, done, models.GoalStatusCompleted); err != nil {
+		t.Fatalf("UpdateGoalStatus failed: %v", err)
+	}
+	if err := m.db.AddGoalDetailed(m.ctx, wsID, 0, database.GoalSeed{Description: "File taxes", Tags: []string{"home"}, Priority: 1}); err != nil {
+		t.Fatalf("AddGoalDetailed failed: %v", err)
+	}
+	dueID, err := m.db.GetLastGoalID(m.ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	raw := m.db.(*database.Database).DB
+	if _, err := raw.ExecContext(m.ctx, "UPDATE goals SET due_date = '2024-03-05' WHERE id = ?", dueID); err != nil {
+		t.Fatalf("set due date: %v", err)
+	}
+
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	ics, err := BuildICS(m.ctx, m.db, ICSOptions{WorkspaceID: wsID, From: "2024-03-01", To: "2024-03-07"}, now)
+	if err != nil {
+		t.Fatalf("BuildICS failed: %v", err)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:SSPT Personal\r\n",
+		"UID:sspt-sprint-" + strconv.FormatInt(first.ID, 10) + "@",
+		"DTSTART:" + start.UTC().Format(util.ICalTimeLayout) + "\r\n",
+		"DTEND:" + start.Add(30*time.Minute).UTC().Format(util.ICalTimeLayout) + "\r\n",
+		"STATUS:CONFIRMED\r\n",
+		`DESCRIPTION:completed sprint in Personal\, focused 25m`,
+		`Goals:\n[ ] Write report\n[x] Send report`,
+		"UID:sspt-sprint-" + strconv.FormatInt(second.ID, 10) + "@",
+		"DTSTART:" + start.Add(30*time.Minute+config.BreakDuration).UTC().Format(util.ICalTimeLayout) + "\r\n",
+		"STATUS:TENTATIVE\r\n",
+		"BEGIN:VTODO\r\nUID:sspt-goal-" + strconv.FormatInt(dueID, 10) + "@",
+		"SUMMARY:File taxes\r\nDUE;VALUE=DATE:20240305\r\nPRIORITY:1\r\nSTATUS:NEEDS-ACTION\r\nCATEGORIES:home\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Fatalf("expected %q in calendar:\n%s", want, unfolded)
+		}
+	}
+
+	outside, err := BuildICS(m.ctx, m.db, ICSOptions{WorkspaceID: wsID, From: "2024-04-01", To: "2024-04-30"}, now)
+	if err != nil {
+		t.Fatalf("BuildICS failed: %v", err)
+	}
+	if strings.Contains(outside, "BEGIN:VEVENT") || strings.Contains(outside, "BEGIN:VTODO") {
+		t.Fatalf("expected an empty calendar outside the range:\n%s", outside)
+	}
+
+	t.Setenv("XDG_DOCUMENTS_DIR", t.TempDir())
+	path, err := ExportICS(m.ctx, m.db, ICSOptions{WorkspaceID: wsID}, "personal")
+	if err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	if filepath.Base(path) != "personal.ics" {
+		t.Fatalf("unexpected export path %s", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "BEGIN:VTODO") {
+		t.Fatalf("expected exported calendar: %v", err)
+	}
+}
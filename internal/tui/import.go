@@ -22,6 +22,11 @@ type seedConfig struct {
 		Number int                 `json:"number"`
 		Tasks  []database.GoalSeed `json:"tasks"`
 	} `json:"sprints"`
+	Templates []struct {
+		Name string                `json:"name"`
+		Goal database.TemplateGoal `json:"goal"`
+	} `json:"templates"`
+	DayTemplates []database.DayTemplate `json:"day_templates"`
 }
 
 // EnsureSeedFile returns the seed file path, creating a default template if needed.
@@ -44,17 +49,32 @@ func EnsureSeedFile() (string, error) {
 		"# + Sprint number",
 		"# * Task",
 		"# - Subtask",
-		"# Tags: #tag  Priority: !1..5  Effort: @S|@M|@L  Recurrence: ~daily|~weekly:mon,tue",
+		"# & Template name (then * goal, - subtask, -- nested subtask)",
+		"# % Day template days=mon sprints=4 [auto] (then + N Label, > template, * goal; bare % ends it)",
+		"# Tags: #tag  Priority: !1..5  Effort: @S|@M|@L  Recurrence: ~daily|~weekly:mon,tue  Links: <https://...>",
 		"",
 		"= Personal",
 		"+ 1",
 		"* Ship onboarding flow #focus @L !2",
 		"- Write checklist",
-		"* Draft project brief #docs !3",
+		"* Draft project brief #docs !3 <https://example.com/brief>",
 		"+ 2",
 		"* Review backlog #review",
 		"",
 		"* Unassigned backlog item #later",
+		"",
+		"& Weekly review",
+		"* Weekly review {{date}} #review @M",
+		"- Clear inbox",
+		"- Plan next week",
+		"-- Check calendar",
+		"",
+		"% Monday days=mon sprints=4",
+		"+ 1 Planning",
+		"> Weekly review",
+		"+ 4 Admin",
+		"* Expenses #admin",
+		"%",
 	}
 	if err := os.WriteFile(txtPath, []byte(strings.Join(skeleton, "\n")), 0o644); err != nil {
 		return "", err
@@ -109,6 +129,16 @@ func importSeedJSON(ctx context.Context, db Database, data []byte, workspaceID i
 	for _, s := range sprints {
 		sprintIDs[s.SprintNumber] = s.ID
 	}
+	for _, tpl := range cfg.Templates {
+		if err := db.SaveGoalTemplate(ctx, tpl.Name, tpl.Goal); err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, tpl := range cfg.DayTemplates {
+		if err := db.SaveDayTemplate(ctx, tpl); err != nil {
+			return 0, 0, err
+		}
+	}
 	imported := 0
 	backlogFallback := 0
 	for _, task := range cfg.Backlog {
@@ -212,6 +242,14 @@ func importSeedDSL(ctx context.Context, db Database, data []byte, defaultWorkspa
 	currentSprint := 0
 	var lastGoalID int64
 	imported := 0
+	var block seedBlock
+	flushBlock := func() error {
+		if block == nil {
+			return nil
+		}
+		defer func() { block = nil }()
+		return block.save(ctx, db)
+	}
 
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 	for scanner.Scan() {
@@ -219,7 +257,34 @@ func importSeedDSL(ctx context.Context, db Database, data []byte, defaultWorkspa
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
+		if block != nil {
+			handled, err := block.add(line)
+			if err != nil {
+				return imported, backlogFallback, err
+			}
+			if handled {
+				continue
+			}
+			if err := flushBlock(); err != nil {
+				return imported, backlogFallback, err
+			}
+		}
 		switch line[0] {
+		case '&':
+			name := strings.TrimSpace(strings.TrimPrefix(line, "&"))
+			if name == "" {
+				return imported, backlogFallback, fmt.Errorf("template name required")
+			}
+			block = &seedTemplate{name: name}
+		case '%':
+			if strings.TrimSpace(strings.TrimPrefix(line, "%")) == "" {
+				continue // a bare "%" only closes the previous day template
+			}
+			day, err := parseSeedDayTemplate(line)
+			if err != nil {
+				return imported, backlogFallback, err
+			}
+			block = day
 		case '=':
 			name := strings.TrimSpace(strings.TrimPrefix(line, "="))
 			if name == "" {
@@ -294,14 +359,197 @@ func importSeedDSL(ctx context.Context, db Database, data []byte, defaultWorkspa
 	if err := scanner.Err(); err != nil {
 		return imported, backlogFallback, err
 	}
+	if err := flushBlock(); err != nil {
+		return imported, backlogFallback, err
+	}
 	return imported, backlogFallback, nil
 }
 
+// seedBlock is a multi-line seed definition that is saved once its last
+// line has been read.
+type seedBlock interface {
+	// add consumes a line belonging to the block. It reports false for
+	// lines that end the block.
+	add(line string) (bool, error)
+	save(ctx context.Context, db Database) error
+}
+
+// seedGoalTree builds goals from "*" lines and their subtasks from dash
+// lines, where the number of leading dashes gives the nesting depth.
+type seedGoalTree struct {
+	owner string
+	roots []database.TemplateGoal
+	stack []*database.TemplateGoal
+}
+
+func (t *seedGoalTree) addGoal(line string) error {
+	seed, err := parseSeedTask(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return err
+	}
+	if seed.Description == "" {
+		return fmt.Errorf("%s: goal description required", t.owner)
+	}
+	t.roots = append(t.roots, database.TemplateGoal{GoalSeed: seed})
+	t.stack = []*database.TemplateGoal{&t.roots[len(t.roots)-1]}
+	return nil
+}
+
+func (t *seedGoalTree) addSubtask(line string) error {
+	depth := len(line) - len(strings.TrimLeft(line, "-"))
+	if len(t.stack) == 0 {
+		return fmt.Errorf("%s: subtask before goal: %q", t.owner, line)
+	}
+	if depth > len(t.stack) {
+		return fmt.Errorf("%s: subtask nested too deep: %q", t.owner, line)
+	}
+	seed, err := parseSeedTask(strings.TrimSpace(strings.TrimLeft(line, "-")))
+	if err != nil {
+		return err
+	}
+	if seed.Description == "" {
+		return nil
+	}
+	parent := t.stack[depth-1]
+	parent.Subtasks = append(parent.Subtasks, database.TemplateGoal{GoalSeed: seed})
+	t.stack = append(t.stack[:depth], &parent.Subtasks[len(parent.Subtasks)-1])
+	return nil
+}
+
+// seedTemplate collects the lines of a "& Name" block: one "*" goal followed
+// by its subtasks.
+type seedTemplate struct {
+	name string
+	tree seedGoalTree
+}
+
+func (t *seedTemplate) add(line string) (bool, error) {
+	t.tree.owner = fmt.Sprintf("template %q", t.name)
+	switch line[0] {
+	case '*':
+		if len(t.tree.roots) > 0 {
+			return false, nil
+		}
+		return true, t.tree.addGoal(line)
+	case '-':
+		return true, t.tree.addSubtask(line)
+	}
+	return false, nil
+}
+
+func (t *seedTemplate) save(ctx context.Context, db Database) error {
+	if len(t.tree.roots) == 0 {
+		return fmt.Errorf("template %q has no goal", t.name)
+	}
+	return db.SaveGoalTemplate(ctx, t.name, t.tree.roots[0])
+}
+
+// seedDayTemplate collects a "% Name days=mon,tue sprints=N [auto]" block.
+// Inside it, "+ N Label" selects and names a sprint, "> Name" adds a goal
+// template to it and "*"/"-" lines add goals. The block runs until the next
+// "=", "&" or "%" line.
+type seedDayTemplate struct {
+	tpl    database.DayTemplate
+	plans  map[int]*seedGoalTree
+	sprint int
+}
+
+func parseSeedDayTemplate(line string) (*seedDayTemplate, error) {
+	day := &seedDayTemplate{plans: make(map[int]*seedGoalTree)}
+	var name []string
+	for _, part := range strings.Fields(strings.TrimPrefix(line, "%")) {
+		key, value, hasValue := strings.Cut(part, "=")
+		switch {
+		case hasValue && strings.EqualFold(key, "days"):
+			for _, wd := range strings.Split(value, ",") {
+				if strings.TrimSpace(wd) != "" {
+					day.tpl.Weekdays = append(day.tpl.Weekdays, wd)
+				}
+			}
+		case hasValue && strings.EqualFold(key, "sprints"):
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sprint count in %q", line)
+			}
+			day.tpl.Sprints = n
+		case strings.EqualFold(part, "auto"):
+			day.tpl.Auto = true
+		default:
+			name = append(name, part)
+		}
+	}
+	day.tpl.Name = strings.Join(name, " ")
+	if day.tpl.Name == "" {
+		return nil, fmt.Errorf("day template name required")
+	}
+	return day, nil
+}
+
+func (t *seedDayTemplate) plan(number int) *database.DaySprintPlan {
+	for i := range t.tpl.Plans {
+		if t.tpl.Plans[i].Number == number {
+			return &t.tpl.Plans[i]
+		}
+	}
+	t.tpl.Plans = append(t.tpl.Plans, database.DaySprintPlan{Number: number})
+	t.plans[number] = &seedGoalTree{owner: fmt.Sprintf("day template %q sprint %d", t.tpl.Name, number)}
+	return &t.tpl.Plans[len(t.tpl.Plans)-1]
+}
+
+func (t *seedDayTemplate) add(line string) (bool, error) {
+	switch line[0] {
+	case '+':
+		num, label, ok := parseSprintLine(line)
+		if !ok {
+			return true, fmt.Errorf("invalid sprint line: %q", line)
+		}
+		t.sprint = num
+		plan := t.plan(num)
+		if label != "" {
+			plan.Label = label
+		}
+		return true, nil
+	case '>', '*', '-':
+		if t.sprint == 0 {
+			return true, fmt.Errorf("day template %q: choose a sprint with \"+ N\" before %q", t.tpl.Name, line)
+		}
+		plan := t.plan(t.sprint)
+		switch line[0] {
+		case '>':
+			if name := strings.TrimSpace(strings.TrimPrefix(line, ">")); name != "" {
+				plan.Templates = append(plan.Templates, name)
+			}
+			return true, nil
+		case '*':
+			return true, t.plans[t.sprint].addGoal(line)
+		default:
+			return true, t.plans[t.sprint].addSubtask(line)
+		}
+	}
+	return false, nil
+}
+
+func (t *seedDayTemplate) save(ctx context.Context, db Database) error {
+	maxSprint := 0
+	for i := range t.tpl.Plans {
+		plan := &t.tpl.Plans[i]
+		plan.Goals = t.plans[plan.Number].roots
+		if plan.Number > maxSprint {
+			maxSprint = plan.Number
+		}
+	}
+	if t.tpl.Sprints == 0 {
+		t.tpl.Sprints = maxSprint
+	}
+	return db.SaveDayTemplate(ctx, t.tpl)
+}
+
 func parseSeedTask(line string) (database.GoalSeed, error) {
 	var seed database.GoalSeed
 	if strings.TrimSpace(line) == "" {
 		return seed, nil
 	}
+	line, seed.Links = util.ExtractLinks(line)
 	parts := strings.Fields(line)
 	var desc []string
 	for _, part := range parts {
@@ -333,6 +581,24 @@ func parseSeedTask(line string) (database.GoalSeed, error) {
 	return seed, nil
 }
 
+// parseSprintLine reads "+ N Label", returning the sprint number and the
+// optional label.
+func parseSprintLine(line string) (int, string, bool) {
+	fields := strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "+")))
+	for i, part := range fields {
+		part = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(part), "sprint"))
+		if part == "" {
+			continue
+		}
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, "", false
+		}
+		return num, strings.Join(fields[i+1:], " "), true
+	}
+	return 0, "", false
+}
+
 func parseSprintNumber(line string) (int, bool) {
 	for _, part := range strings.Fields(line) {
 		part = strings.TrimSpace(part)
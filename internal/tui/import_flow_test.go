@@ -108,3 +108,89 @@ func TestImportSeedDSL(t *testing.T) {
 		t.Fatalf("expected backlog fallback 0, got %d", backlogFallback)
 	}
 }
+
+func TestImportSeedDSLTemplates(t *testing.T) {
+	db, ctx, wsID, dayID := setupImportDB(t)
+	dsl := `
+& Weekly review
+* Weekly review {{date}} #review @M
+- Clear inbox
+-- Archive newsletters
+- Plan next week
++ 1
+* Sprint task
+`
+	path := filepath.Join(t.TempDir(), "seed.txt")
+	if err := os.WriteFile(path, []byte(dsl), 0o600); err != nil {
+		t.Fatalf("write seed failed: %v", err)
+	}
+	imported, _, _, err := ImportSeed(ctx, db, path, wsID, dayID)
+	if err != nil {
+		t.Fatalf("ImportSeed DSL failed: %v", err)
+	}
+	if imported != 1 {
+		t.Fatalf("expected only the sprint task to be imported as a goal, got %d", imported)
+	}
+	tpl, ok, err := db.GetGoalTemplate(ctx, "Weekly review")
+	if err != nil || !ok {
+		t.Fatalf("expected template to be saved: ok=%v err=%v", ok, err)
+	}
+	if tpl.Goal.Description != "Weekly review {{date}}" || len(tpl.Goal.Tags) != 1 || tpl.Goal.Effort != "M" {
+		t.Fatalf("unexpected template goal: %+v", tpl.Goal)
+	}
+	if len(tpl.Goal.Subtasks) != 2 || len(tpl.Goal.Subtasks[0].Subtasks) != 1 || tpl.Goal.Subtasks[0].Subtasks[0].Description != "Archive newsletters" {
+		t.Fatalf("unexpected subtask tree: %+v", tpl.Goal.Subtasks)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.txt")
+	if err := os.WriteFile(bad, []byte("& Broken\n- orphan\n"), 0o600); err != nil {
+		t.Fatalf("write seed failed: %v", err)
+	}
+	if _, _, _, err := ImportSeed(ctx, db, bad, wsID, dayID); err == nil {
+		t.Fatalf("expected subtask before goal to fail")
+	}
+}
+
+func TestImportSeedDSLDayTemplates(t *testing.T) {
+	db, ctx, wsID, dayID := setupImportDB(t)
+	dsl := `
+% Monday days=mon sprints=4 auto
++ 1 Planning
+> Weekly review
+* Inbox zero
+- Reply to mail
++ 4 Admin
+%
++ 1
+* Regular task
+`
+	path := filepath.Join(t.TempDir(), "seed.txt")
+	if err := os.WriteFile(path, []byte(dsl), 0o600); err != nil {
+		t.Fatalf("write seed failed: %v", err)
+	}
+	imported, _, _, err := ImportSeed(ctx, db, path, wsID, dayID)
+	if err != nil {
+		t.Fatalf("ImportSeed DSL failed: %v", err)
+	}
+	if imported != 1 {
+		t.Fatalf("expected the regular task to be imported, got %d", imported)
+	}
+	templates, err := db.GetDayTemplates(ctx)
+	if err != nil {
+		t.Fatalf("GetDayTemplates failed: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("expected one day template, got %d", len(templates))
+	}
+	tpl := templates[0]
+	if tpl.Name != "Monday" || tpl.Sprints != 4 || !tpl.Auto || len(tpl.Weekdays) != 1 || len(tpl.Plans) != 2 {
+		t.Fatalf("unexpected day template: %+v", tpl)
+	}
+	plan := tpl.Plans[0]
+	if plan.Label != "Planning" || len(plan.Templates) != 1 || len(plan.Goals) != 1 || len(plan.Goals[0].Subtasks) != 1 {
+		t.Fatalf("unexpected sprint plan: %+v", plan)
+	}
+	if tpl.Plans[1].Number != 4 || tpl.Plans[1].Label != "Admin" {
+		t.Fatalf("unexpected second plan: %+v", tpl.Plans[1])
+	}
+}
@@ -46,6 +46,22 @@ func TestParseSeedTask(t *testing.T) {
 	}
 }
 
+func TestParseSeedTaskLinks(t *testing.T) {
+	seed, err := parseSeedTask("Read spec <https://example.com/spec#intro> #docs < ~/notes/spec draft.md >")
+	if err != nil {
+		t.Fatalf("parseSeedTask failed: %v", err)
+	}
+	if seed.Description != "Read spec" {
+		t.Fatalf("expected description %q, got %q", "Read spec", seed.Description)
+	}
+	if len(seed.Links) != 2 || seed.Links[0] != "https://example.com/spec#intro" || seed.Links[1] != "~/notes/spec draft.md" {
+		t.Fatalf("unexpected links: %#v", seed.Links)
+	}
+	if len(seed.Tags) != 1 || seed.Tags[0] != "docs" {
+		t.Fatalf("expected link fragment not to become a tag, got %#v", seed.Tags)
+	}
+}
+
 func TestParseSprintNumber(t *testing.T) {
 	if num, ok := parseSprintNumber("+ 2"); !ok || num != 2 {
 		t.Fatalf("expected sprint number 2, got %d (ok=%v)", num, ok)
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// kanbanColumnNumber marks status columns in m.sprints so sprint handlers,
+// which only act on positive sprint numbers, leave them alone.
+const kanbanColumnNumber = -3
+
+func (m DashboardModel) kanbanMode() bool {
+	return m.activeWorkspaceIdx < len(m.workspaces) && m.workspaces[m.activeWorkspaceIdx].BoardMode == BoardModeKanban
+}
+
+// onBoard reports whether column idx belongs to the current board mode.
+func (m DashboardModel) onBoard(idx int) bool {
+	return m.validSprintIndex(idx) && (m.sprints[idx].Column != "") == m.kanbanMode()
+}
+
+// wipLimit returns the WIP limit of a status column, or 0 for none.
+func (m DashboardModel) wipLimit(status models.GoalStatus) int {
+	if len(m.workspaces) == 0 {
+		return 0
+	}
+	return m.workspaces[m.activeWorkspaceIdx].WIPLimits[status]
+}
+
+// kanbanRoots gathers the top-level goals shown on the Kanban board: today's
+// sprints, the backlog and the goals completed today.
+func (m *DashboardModel) kanbanRoots(workspaceID, dayID int64, sprints []models.Sprint) ([]GoalView, error) {
+	var roots []GoalView
+	add := func(key string, fetch func() ([]models.Goal, error)) error {
+		tree, err := m.getGoalTree(key, fetch)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, cloneGoals(tree)...)
+		return nil
+	}
+	for i := range sprints {
+		sprintID := sprints[i].ID
+		if err := add(fmt.Sprintf("sprint:%d", sprintID), func() ([]models.Goal, error) {
+			return m.db.GetGoalsForSprint(m.ctx, sprintID)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(fmt.Sprintf("backlog:%d", workspaceID), func() ([]models.Goal, error) {
+		return m.db.GetBacklogGoals(m.ctx, workspaceID)
+	}); err != nil {
+		return nil, err
+	}
+	if err := add(fmt.Sprintf("completed:%d:%d", workspaceID, dayID), func() ([]models.Goal, error) {
+		return m.db.GetCompletedGoalsForDay(m.ctx, dayID, workspaceID)
+	}); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// buildKanbanColumns groups goals into one column per workflow status.
+// Goals whose status is not in the workflow wait in the first column, and
+// blocked goals get a column of their own when there are any.
+func buildKanbanColumns(workflow models.Workflow, roots []GoalView, expanded map[int64]bool) []SprintView {
+	index := make(map[models.GoalStatus]int, len(workflow))
+	for i, status := range workflow {
+		index[status] = i
+	}
+	grouped := make([][]GoalView, len(workflow))
+	var blocked []GoalView
+	seen := make(map[int64]bool)
+	for _, g := range roots {
+		if seen[g.ID] || g.Status == models.GoalStatusArchived {
+			continue
+		}
+		seen[g.ID] = true
+		if g.Status == models.GoalStatusBlocked {
+			blocked = append(blocked, g)
+			continue
+		}
+		grouped[index[g.Status]] = append(grouped[index[g.Status]], g)
+	}
+	column := func(status models.GoalStatus, goals []GoalView) SprintView {
+		return SprintView{
+			Sprint: models.Sprint{SprintNumber: kanbanColumnNumber},
+			Goals:  Flatten(goals, 0, expanded, 0),
+			Column: status,
+		}
+	}
+	columns := make([]SprintView, 0, len(workflow)+1)
+	for i, status := range workflow {
+		columns = append(columns, column(status, grouped[i]))
+	}
+	if len(blocked) > 0 {
+		columns = append(columns, column(models.GoalStatusBlocked, blocked))
+	}
+	return columns
+}
+
+// cardCount counts the top-level goals in a column.
+func cardCount(column SprintView) int {
+	n := 0
+	for _, g := range column.Goals {
+		if g.Level == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// kanbanTitle renders a column header such as "Review 2/3".
+func (m DashboardModel) kanbanTitle(column SprintView) string {
+	label := column.Column.Label()
+	title := strings.ToUpper(label[:1]) + label[1:]
+	count := cardCount(column)
+	if limit := m.wipLimit(column.Column); limit > 0 {
+		return fmt.Sprintf("%s %d/%d", title, count, limit)
+	}
+	return fmt.Sprintf("%s %d", title, count)
+}
+
+// focusKanbanColumn keeps the cursor on a column of the current board mode.
+func (m *DashboardModel) focusKanbanColumn() {
+	if !m.kanbanMode() || m.onBoard(m.view.focusedColIdx) {
+		return
+	}
+	for i := range m.sprints {
+		if m.sprints[i].Column != "" {
+			m.view.focusedColIdx, m.view.focusedGoalIdx = i, 0
+			m.view.colScrollOffset = 0
+			return
+		}
+	}
+}
+
+// scrollToKanbanColumn scrolls the board so the focused status column is
+// visible.
+func (m *DashboardModel) scrollToKanbanColumn() {
+	pos := 0
+	for i := 0; i < m.view.focusedColIdx && i < len(m.sprints); i++ {
+		if m.sprints[i].Column != "" {
+			pos++
+		}
+	}
+	displayCount := m.buildBoardLayout().displayCount
+	if pos < m.view.colScrollOffset {
+		m.view.colScrollOffset = pos
+	} else if pos >= m.view.colScrollOffset+displayCount {
+		m.view.colScrollOffset = pos - displayCount + 1
+	}
+}
+
+// kanbanColumnOf returns the index of the status column holding status.
+func (m DashboardModel) kanbanColumnOf(status models.GoalStatus) (int, bool) {
+	for i := range m.sprints {
+		if m.sprints[i].Column == status {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (m DashboardModel) handleBoardMode(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "K" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	activeWS := &m.workspaces[m.activeWorkspaceIdx]
+	mode := BoardModeKanban
+	if activeWS.BoardMode == BoardModeKanban {
+		mode = BoardModeSprints
+	}
+	if err := m.db.UpdateWorkspaceBoardMode(m.ctx, activeWS.ID, mode); err != nil {
+		m.setStatusError(fmt.Sprintf("Error updating board mode: %v", err))
+		return m, nil, true
+	}
+	activeWS.BoardMode = mode
+	m.refreshData(m.day.ID)
+	m.view.focusedGoalIdx, m.view.colScrollOffset = 0, 0
+	if mode == BoardModeKanban {
+		m.Message = "Kanban board"
+	} else {
+		m.view.focusedColIdx = config.DefaultFocusColumn
+		if !m.validSprintIndex(m.view.focusedColIdx) {
+			m.view.focusedColIdx = 0
+		}
+		m.Message = "Sprint board"
+	}
+	return m, nil, true
+}
+
+// handleKanbanWIPLimit changes the WIP limit of the focused status column;
+// outside the Kanban board "+" and "-" keep changing the sprint count.
+func (m DashboardModel) handleKanbanWIPLimit(key string) (DashboardModel, tea.Cmd, bool) {
+	if (key != "+" && key != "-") || !m.kanbanMode() || !m.onBoard(m.view.focusedColIdx) {
+		return m, nil, false
+	}
+	status := m.sprints[m.view.focusedColIdx].Column
+	limit := m.wipLimit(status) + 1
+	if key == "-" {
+		limit = m.wipLimit(status) - 1
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	activeWS := &m.workspaces[m.activeWorkspaceIdx]
+	limits := make(map[models.GoalStatus]int, len(activeWS.WIPLimits)+1)
+	for s, n := range activeWS.WIPLimits {
+		limits[s] = n
+	}
+	limits[status] = limit
+	if err := m.db.UpdateWorkspaceWIPLimits(m.ctx, activeWS.ID, limits); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving WIP limit: %v", err))
+		return m, nil, true
+	}
+	activeWS.WIPLimits = limits
+	if limit == 0 {
+		m.Message = fmt.Sprintf("No WIP limit for %s", status.Label())
+	} else {
+		m.Message = fmt.Sprintf("WIP limit for %s: %d", status.Label(), limit)
+	}
+	return m, nil, true
+}
+
+// kanbanWIPFull reports whether moving a card into status would exceed the
+// column's WIP limit.
+func (m DashboardModel) kanbanWIPFull(status models.GoalStatus) (int, bool) {
+	limit := m.wipLimit(status)
+	idx, ok := m.kanbanColumnOf(status)
+	if limit <= 0 || !ok {
+		return limit, false
+	}
+	return limit, cardCount(m.sprints[idx]) >= limit
+}
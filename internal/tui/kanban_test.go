@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+)
+
+func TestKanbanBoard(t *testing.T) {
+	m, idA, idB, _, _ := setupTwoGoalsInSprint(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+
+	m, _, _ = m.handleBoardMode("K")
+	if !m.kanbanMode() {
+		t.Fatalf("expected Kanban mode")
+	}
+	workspaces, err := m.db.GetWorkspaces(m.ctx)
+	if err != nil {
+		t.Fatalf("GetWorkspaces failed: %v", err)
+	}
+	for _, ws := range workspaces {
+		if ws.ID == wsID && ws.BoardMode != BoardModeKanban {
+			t.Fatalf("expected board mode to be stored, got %d", ws.BoardMode)
+		}
+	}
+	layout := m.buildBoardLayout()
+	for _, idx := range layout.visibleIndices {
+		if m.sprints[idx].Column == "" {
+			t.Fatalf("sprint column %d shown on the Kanban board", idx)
+		}
+	}
+	if col := m.sprints[m.view.focusedColIdx].Column; col != models.GoalStatusPending {
+		t.Fatalf("expected focus on pending column, got %q", col)
+	}
+
+	focus := func(id int64) {
+		t.Helper()
+		var ok bool
+		if m, ok = m.focusGoalOnBoard(id); !ok {
+			t.Fatalf("goal %d not on the board", id)
+		}
+	}
+
+	// WIP limit of one for in progress.
+	m.view.focusedColIdx, _ = m.kanbanColumnOf(models.GoalStatusInProgress)
+	m, _, _ = m.handleKanbanWIPLimit("+")
+	if m.Message != "WIP limit for in progress: 1" {
+		t.Fatalf("unexpected message %q", m.Message)
+	}
+
+	focus(idA)
+	m, _, _ = m.handleGoalStatusStep("shift+right")
+	goal, err := m.db.GetGoalByID(m.ctx, idA)
+	if err != nil || goal.Status != models.GoalStatusInProgress {
+		t.Fatalf("expected goal A in progress, got %v (%v)", goal, err)
+	}
+	if col := m.sprints[m.view.focusedColIdx].Column; col != models.GoalStatusInProgress {
+		t.Fatalf("expected focus to follow the card, got %q", col)
+	}
+
+	focus(idB)
+	m, _, _ = m.handleGoalStatusStep("shift+right")
+	if !strings.HasPrefix(m.Message, "WIP limit reached") {
+		t.Fatalf("expected WIP limit message, got %q", m.Message)
+	}
+	goal, err = m.db.GetGoalByID(m.ctx, idB)
+	if err != nil || goal.Status != models.GoalStatusPending {
+		t.Fatalf("expected goal B to stay pending, got %v (%v)", goal, err)
+	}
+
+	m.width, m.height = 160, 40
+	if !strings.Contains(m.renderBoard(30, m.buildBoardLayout()), "In progress 1/1") {
+		t.Fatalf("expected WIP count in column header")
+	}
+
+	m, _, _ = m.handleBoardMode("K")
+	if m.kanbanMode() {
+		t.Fatalf("expected sprint mode")
+	}
+	if m.sprints[m.view.focusedColIdx].Column != "" {
+		t.Fatalf("expected focus on a sprint column")
+	}
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	mdBoldRegex   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalicRegex = regexp.MustCompile(`(^|[^\w*])[*_]([^*_\s][^*_]*)[*_]`)
+	mdCodeRegex   = regexp.MustCompile("`([^`]+)`")
+	mdLinkRegex   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdOrderedList = regexp.MustCompile(`^(\d+)[.)]\s+`)
+)
+
+// renderMarkdown renders the common subset of markdown used in goal notes
+// (headings, lists, task boxes, quotes, rules, code and inline emphasis)
+// with the theme's styles, wrapped to width.
+func renderMarkdown(text string, width int, theme Theme) string {
+	if width < 1 {
+		width = 1
+	}
+	bold := lipgloss.NewStyle().Bold(true)
+	var out []string
+	inCode := false
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, theme.Dim.Render("  "+truncateLabel(raw, width-2)))
+			continue
+		}
+		indent := strings.Repeat(" ", len(raw)-len(strings.TrimLeft(raw, " \t")))
+		var line string
+		switch {
+		case trimmed == "":
+			line = ""
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			heading := renderMarkdownInline(strings.TrimSpace(trimmed[level:]), theme)
+			switch level {
+			case 1:
+				line = theme.Focused.Bold(true).Render(heading)
+			case 2:
+				line = theme.Highlight.Bold(true).Render(heading)
+			default:
+				line = bold.Render(heading)
+			}
+		case trimmed == "---" || trimmed == "***" || trimmed == "___":
+			line = theme.Dim.Render(strings.Repeat("─", width))
+		case strings.HasPrefix(trimmed, ">"):
+			line = theme.Dim.Render("│ ") + renderMarkdownInline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">")), theme)
+		case strings.HasPrefix(trimmed, "- [ ] "), strings.HasPrefix(trimmed, "* [ ] "):
+			line = indent + "☐ " + renderMarkdownInline(trimmed[6:], theme)
+		case strings.HasPrefix(strings.ToLower(trimmed), "- [x] "), strings.HasPrefix(strings.ToLower(trimmed), "* [x] "):
+			line = indent + theme.Dim.Render("☑ "+trimmed[6:])
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			line = indent + "• " + renderMarkdownInline(trimmed[2:], theme)
+		case mdOrderedList.MatchString(trimmed):
+			num := mdOrderedList.FindStringSubmatch(trimmed)[1]
+			line = indent + num + ". " + renderMarkdownInline(mdOrderedList.ReplaceAllString(trimmed, ""), theme)
+		default:
+			line = indent + renderMarkdownInline(trimmed, theme)
+		}
+		out = append(out, ansi.Wordwrap(line, width, ""))
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+func renderMarkdownInline(text string, theme Theme) string {
+	bold := lipgloss.NewStyle().Bold(true)
+	italic := lipgloss.NewStyle().Italic(true)
+	// Code spans are rendered first and kept out of the other replacements.
+	var codes []string
+	text = mdCodeRegex.ReplaceAllStringFunc(text, func(match string) string {
+		codes = append(codes, theme.Highlight.Render(mdCodeRegex.FindStringSubmatch(match)[1]))
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	})
+	text = mdLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdLinkRegex.FindStringSubmatch(match)
+		return lipgloss.NewStyle().Underline(true).Render(parts[1]) + theme.Dim.Render(" ("+parts[2]+")")
+	})
+	text = mdBoldRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdBoldRegex.FindStringSubmatch(match)
+		return bold.Render(parts[1] + parts[2])
+	})
+	text = mdItalicRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdItalicRegex.FindStringSubmatch(match)
+		return parts[1] + italic.Render(parts[2])
+	})
+	for i, code := range codes {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", code, 1)
+	}
+	return text
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+// calendarFileSetting stores the path of the local .ics file meetings are
+// read from.
+const calendarFileSetting = "calendar_file"
+
+// maxSuggestedSprints matches the range the initializing screen accepts.
+const maxSuggestedSprints = 8
+
+// readCalendarFile parses the events of a local .ics file.
+func readCalendarFile(path string) ([]util.ICalEvent, error) {
+	f, err := os.Open(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return util.ParseICal(f)
+}
+
+// CalendarFile returns the path of the calendar file, empty when none is set.
+func CalendarFile(ctx context.Context, db Database) string {
+	path, _ := db.GetSetting(ctx, calendarFileSetting)
+	return strings.TrimSpace(path)
+}
+
+// SetCalendarFile saves the path of the calendar file after checking that
+// it can be read; an empty path stops reading meetings.
+func SetCalendarFile(ctx context.Context, db Database, path string) error {
+	path = strings.TrimSpace(path)
+	if path != "" {
+		if _, err := readCalendarFile(path); err != nil {
+			return err
+		}
+	}
+	return db.SetSetting(ctx, calendarFileSetting, path)
+}
+
+// LoadMeetings returns the meetings on date (YYYY-MM-DD) from the calendar
+// file in the settings, or none when no file is set.
+func LoadMeetings(ctx context.Context, db Database, date string) ([]util.ICalEvent, error) {
+	path := CalendarFile(ctx, db)
+	if path == "" {
+		return nil, nil
+	}
+	day, err := time.ParseInLocation(util.DateLayout, date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	events, err := readCalendarFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return util.ICalEventsOn(events, day), nil
+}
+
+// refreshMeetings loads the meetings of date from the calendar file once;
+// they are kept until another day is shown or the file setting changes.
+func (m *DashboardModel) refreshMeetings(date string) {
+	if m.meetingsDate == date {
+		return
+	}
+	meetings, err := LoadMeetings(m.ctx, m.db, date)
+	if err != nil {
+		util.LogError("load calendar file", err)
+	}
+	m.meetings, m.meetingsDate = meetings, date
+}
+
+// meetingOverlap returns the first meeting that overlaps start to end.
+func meetingOverlap(meetings []util.ICalEvent, start, end time.Time) (util.ICalEvent, bool) {
+	for _, meeting := range meetings {
+		if meeting.Start.Before(end) && meeting.End.After(start) {
+			return meeting, true
+		}
+	}
+	return util.ICalEvent{}, false
+}
+
+// FormatMeeting renders a meeting as "10:00-10:30 Standup".
+func FormatMeeting(meeting util.ICalEvent) string {
+	return fmt.Sprintf("%s-%s %s", meeting.Start.Local().Format("15:04"), meeting.End.Local().Format("15:04"), meeting.Summary)
+}
+
+// sprintSlot is when a sprint ran, or when it is planned to run. Meeting is
+// set when a sprint that has not finished overlaps a meeting.
+type sprintSlot struct {
+	Sprint  models.Sprint
+	Start   time.Time
+	End     time.Time
+	Planned bool
+	Meeting *util.ICalEvent
+}
+
+// scheduleSprints places the sprints of a day in time. Sprints with a
+// recorded start keep it, and running sprints end when their remaining time
+// is up. The others follow the previous sprint after a break, from
+// PlannedDayStart or, today, from now on, and are moved past any meeting
+// they would overlap.
+func scheduleSprints(date string, sprints []models.Sprint, meetings []util.ICalEvent, now time.Time) []sprintSlot {
+	day, err := time.ParseInLocation(util.DateLayout, date, time.Local)
+	if err != nil {
+		return nil
+	}
+	next := day.Add(config.PlannedDayStart)
+	if now.Format(util.DateLayout) == date && now.After(next) {
+		next = now
+	}
+	var slots []sprintSlot
+	for _, s := range sprints {
+		if s.SprintNumber <= 0 {
+			continue
+		}
+		slot := sprintSlot{Sprint: s}
+		switch {
+		case s.StartTime == nil:
+			slot.Start, slot.Planned = next, true
+			for {
+				meeting, ok := meetingOverlap(meetings, slot.Start, slot.Start.Add(config.SprintDuration))
+				if !ok {
+					break
+				}
+				slot.Start = meeting.End
+			}
+			slot.End = slot.Start.Add(config.SprintDuration)
+		case s.EndTime != nil:
+			slot.Start, slot.End = *s.StartTime, *s.EndTime
+		case s.Status == models.StatusActive:
+			slot.Start = *s.StartTime
+			slot.End = slot.Start.Add(config.SprintDuration - time.Duration(s.ElapsedSeconds)*time.Second)
+		default:
+			slot.Start = *s.StartTime
+			slot.End = slot.Start.Add(config.SprintDuration)
+		}
+		if !slot.Planned && s.Status != models.StatusCompleted && s.Status != models.StatusInterrupted {
+			if meeting, ok := meetingOverlap(meetings, slot.Start, slot.End); ok {
+				slot.Meeting = &meeting
+			}
+		}
+		if after := slot.End.Add(config.BreakDuration); slot.Planned || after.After(next) {
+			next = after
+		}
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+// SuggestSprintCount returns how many sprints fit on date before
+// PlannedDayEnd when they are planned around meetings.
+func SuggestSprintCount(date string, meetings []util.ICalEvent, now time.Time) int {
+	day, err := time.ParseInLocation(util.DateLayout, date, time.Local)
+	if err != nil {
+		return 0
+	}
+	sprints := make([]models.Sprint, maxSuggestedSprints)
+	for i := range sprints {
+		sprints[i].SprintNumber = i + 1
+	}
+	count := 0
+	for _, slot := range scheduleSprints(date, sprints, meetings, now) {
+		if slot.End.After(day.Add(config.PlannedDayEnd)) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// sprintSlots schedules the day's sprints on the board around its meetings,
+// by sprint ID.
+func (m DashboardModel) sprintSlots() map[int64]sprintSlot {
+	var sprints []models.Sprint
+	for _, s := range m.sprints {
+		if s.SprintNumber > 0 {
+			sprints = append(sprints, s.Sprint)
+		}
+	}
+	slots := make(map[int64]sprintSlot)
+	for _, slot := range scheduleSprints(m.day.Date, sprints, m.meetings, time.Now()) {
+		slots[slot.Sprint.ID] = slot
+	}
+	return slots
+}
+
+// meetingWarning describes the meeting a sprint started now would run into.
+func (m DashboardModel) meetingWarning(s models.Sprint, now time.Time) string {
+	end := now.Add(config.SprintDuration - time.Duration(s.ElapsedSeconds)*time.Second)
+	meeting, ok := meetingOverlap(m.meetings, now, end)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("⚠ Sprint %d overlaps %s", s.SprintNumber, FormatMeeting(meeting))
+}
+
+// formatClock renders a time of day given as an offset from midnight.
+func formatClock(offset time.Duration) string {
+	return time.Time{}.Add(offset).Format("15:04")
+}
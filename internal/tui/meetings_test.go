@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestScheduleSprints(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	at := func(h, min int) time.Time {
+		return day.Add(time.Duration(h)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	meetings := []util.ICalEvent{
+		{Summary: "Standup", Start: at(9, 0), End: at(9, 30)},
+		{Summary: "Review", Start: at(15, 0), End: at(16, 0)},
+	}
+	start, end := at(12, 0), at(12, 20)
+	active := at(14, 0)
+	now := day.AddDate(0, 0, 1)
+	slots := scheduleSprints("2024-03-01", []models.Sprint{
+		{ID: 1, SprintNumber: 0},
+		{ID: 2, SprintNumber: 1},
+		{ID: 3, SprintNumber: 2, StartTime: &start, EndTime: &end, Status: models.StatusCompleted},
+		{ID: 4, SprintNumber: 3, StartTime: &active, Status: models.StatusActive},
+		{ID: 5, SprintNumber: 4},
+	}, meetings, now)
+	if len(slots) != 4 {
+		t.Fatalf("expected the backlog to be skipped, got %d slots", len(slots))
+	}
+	if !slots[0].Planned || !slots[0].Start.Equal(at(9, 30)) || !slots[0].End.Equal(at(9, 30).Add(config.SprintDuration)) {
+		t.Fatalf("expected sprint 1 planned after the standup, got %+v", slots[0])
+	}
+	if slots[1].Planned || !slots[1].Start.Equal(start) || !slots[1].End.Equal(end) || slots[1].Meeting != nil {
+		t.Fatalf("expected sprint 2 at its recorded times, got %+v", slots[1])
+	}
+	if slots[2].Meeting == nil || slots[2].Meeting.Summary != "Review" {
+		t.Fatalf("expected the running sprint 3 to warn about the review, got %+v", slots[2])
+	}
+	if want := at(16, 0); !slots[3].Planned || !slots[3].Start.Equal(want) || slots[3].Meeting != nil {
+		t.Fatalf("expected sprint 4 after the review, got %v", slots[3].Start)
+	}
+
+	// Today, planned sprints start no earlier than now.
+	today := time.Now()
+	slots = scheduleSprints(today.Format(util.DateLayout), []models.Sprint{{ID: 1, SprintNumber: 1}}, nil, today)
+	if dayStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local).Add(config.PlannedDayStart); slots[0].Start.Before(dayStart) || slots[0].Start.Before(today) {
+		t.Fatalf("expected today's sprint to start from now, got %v", slots[0].Start)
+	}
+}
+
+func TestSuggestSprintCount(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	later := day.AddDate(0, 0, 2)
+	if got := SuggestSprintCount("2024-03-01", nil, later); got != 4 {
+		t.Fatalf("expected 4 sprints in a free day, got %d", got)
+	}
+	meetings := []util.ICalEvent{{Summary: "Workshop", Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)}}
+	if got := SuggestSprintCount("2024-03-01", meetings, later); got != 3 {
+		t.Fatalf("expected 3 sprints around the workshop, got %d", got)
+	}
+	if got := SuggestSprintCount("2024-03-01", nil, day.Add(17*time.Hour)); got != 0 {
+		t.Fatalf("expected no sprint to fit late in the day, got %d", got)
+	}
+}
+
+func TestDashboardMeetings(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width, m.height = 160, 40
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "work.ics")
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:sync",
+		"SUMMARY:Team sync",
+		"DTSTART:" + now.Add(-5*time.Minute).UTC().Format(util.ICalTimeLayout),
+		"DTEND:" + now.Add(30*time.Minute).UTC().Format(util.ICalTimeLayout),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	m, _, _ = m.handleCalendarFile("ctrl+o")
+	if !m.modal.Is(ModalCalendarFile) {
+		t.Fatalf("expected calendar file modal")
+	}
+	m.inputs.textInput.SetValue(filepath.Join(t.TempDir(), "missing.ics"))
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.modal.Is(ModalCalendarFile) || !m.statusIsError {
+		t.Fatalf("expected a missing file to be refused")
+	}
+	m.inputs.textInput.SetValue(path)
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.Is(ModalCalendarFile) || CalendarFile(m.ctx, m.db) != path {
+		t.Fatalf("expected calendar file to be saved, message %q", m.statusMessage)
+	}
+	if len(m.meetings) != 1 || !strings.Contains(m.Message, "1 meeting(s)") {
+		t.Fatalf("expected today's meeting, got %+v (%q)", m.meetings, m.Message)
+	}
+	if header := m.renderHeader(); !strings.Contains(header, "Blocked: ") || !strings.Contains(header, "Team sync") {
+		t.Fatalf("expected blocked time in the header:\n%s", header)
+	}
+
+	for i, s := range m.sprints {
+		if s.SprintNumber == 1 {
+			m.view.focusedColIdx = i
+		}
+	}
+	m, _, _ = m.handleSprintStart("s")
+	if m.timer.ActiveSprint == nil || !strings.Contains(m.Message, "⚠ Sprint 1 overlaps") {
+		t.Fatalf("expected an overlap warning when starting, got %q", m.Message)
+	}
+	if board := m.renderBoard(30, m.buildBoardLayout()); !strings.Contains(board, "⚠") {
+		t.Fatalf("expected the sprint column to warn:\n%s", board)
+	}
+	plan, err := m.buildAutoPlan(config.AutoPlanSprintCapacity)
+	if err != nil {
+		t.Fatalf("buildAutoPlan failed: %v", err)
+	}
+	if len(plan.Sprints) != 1 || !strings.HasPrefix(plan.Sprints[0].Blocked, "overlaps ") {
+		t.Fatalf("expected auto-plan to avoid the sprint, got %+v", plan.Sprints)
+	}
+
+	// The file is read once for the day, not on every refresh.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	m.refreshData(m.day.ID)
+	if len(m.meetings) != 1 {
+		t.Fatalf("expected the day's meetings to be kept, got %+v", m.meetings)
+	}
+
+	m, _, _ = m.handleCalendarFile("ctrl+o")
+	m.inputs.textInput.SetValue("")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.meetings) != 0 || strings.Contains(m.renderHeader(), "Blocked: ") {
+		t.Fatalf("expected no meetings without a calendar file")
+	}
+}
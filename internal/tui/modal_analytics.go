@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// analyticsPeriods maps the period keys of the analytics view to days.
+var analyticsPeriods = map[string]int{"1": 7, "2": 30, "3": 90}
+
+// parseAnalyticsRange parses "YYYY-MM-DD YYYY-MM-DD" (or "..") into an
+// ordered date range.
+func parseAnalyticsRange(value string) (string, string, error) {
+	fields := strings.Fields(strings.ReplaceAll(value, "..", " "))
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("use YYYY-MM-DD YYYY-MM-DD")
+	}
+	for _, f := range fields {
+		if _, err := time.Parse(util.DateLayout, f); err != nil {
+			return "", "", fmt.Errorf("invalid date %q", f)
+		}
+	}
+	if fields[1] < fields[0] {
+		fields[0], fields[1] = fields[1], fields[0]
+	}
+	return fields[0], fields[1], nil
+}
+
+func (m DashboardModel) handleAnalyticsView(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "M" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	state := &AnalyticsState{Hidden: make(map[int64]bool), WorkspaceIdx: m.activeWorkspaceIdx}
+	m = m.loadAnalyticsPeriod(state, 7)
+	m = m.loadHeatmap(state)
+	m = m.loadAnalyticsForecast(state)
+	m.modal.Open(state)
+	m.showAnalytics = false
+	m.showDetails = false
+	return m, nil, true
+}
+
+// loadAnalyticsPeriod shows the last days days, including today.
+func (m DashboardModel) loadAnalyticsPeriod(state *AnalyticsState, days int) DashboardModel {
+	now := time.Now()
+	state.Days = days
+	state.From = now.AddDate(0, 0, -(days - 1)).Format(util.DateLayout)
+	state.To = now.Format(util.DateLayout)
+	return m.loadAnalytics(state)
+}
+
+func (m DashboardModel) loadAnalytics(state *AnalyticsState) DashboardModel {
+	data, err := m.db.GetAnalytics(m.ctx, m.workspaces[m.activeWorkspaceIdx].ID, state.From, state.To)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading analytics: %v", err))
+		return m
+	}
+	state.Data = data
+	return m.loadTimeReport(state)
+}
+
+// shownWorkspaceIDs lists the workspaces not hidden in the analytics view.
+func (m DashboardModel) shownWorkspaceIDs(state *AnalyticsState) []int64 {
+	var ids []int64
+	for _, ws := range m.workspaces {
+		if !state.Hidden[ws.ID] {
+			ids = append(ids, ws.ID)
+		}
+	}
+	return ids
+}
+
+// loadTimeReport reloads the task timer time of the range for the shown
+// workspaces.
+func (m DashboardModel) loadTimeReport(state *AnalyticsState) DashboardModel {
+	report, err := m.db.GetTimeReport(m.ctx, m.shownWorkspaceIDs(state), state.From, state.To)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading time report: %v", err))
+		return m
+	}
+	state.Time = report
+	return m
+}
+
+// loadHeatmap reloads the heatmap of the last year for the shown workspaces.
+func (m DashboardModel) loadHeatmap(state *AnalyticsState) DashboardModel {
+	ids := m.shownWorkspaceIDs(state)
+	now := time.Now()
+	days, err := m.db.GetHeatmap(m.ctx, ids, now.AddDate(0, 0, -(HeatmapDays-1)).Format(util.DateLayout), now.Format(util.DateLayout))
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading heatmap: %v", err))
+		return m
+	}
+	state.Heatmap = days
+	return m
+}
+
+func (m DashboardModel) loadAnalyticsForecast(state *AnalyticsState) DashboardModel {
+	f, err := m.loadForecast(state.ForecastTag)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading forecast: %v", err))
+		return m
+	}
+	state.Forecast = f
+	return m
+}
+
+// handleModalConfirmAnalytics applies a typed custom range or forecast tag.
+func (m DashboardModel) handleModalConfirmAnalytics() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.AnalyticsState()
+	if !ok {
+		return m, nil, false
+	}
+	switch state.Editing {
+	case analyticsInputRange:
+		from, to, err := parseAnalyticsRange(m.inputs.textInput.Value())
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Invalid range: %v", err))
+			return m, nil, true
+		}
+		state.Days, state.From, state.To = 0, from, to
+		m = m.loadAnalytics(state)
+	case analyticsInputTag:
+		state.ForecastTag = strings.TrimPrefix(strings.TrimSpace(m.inputs.textInput.Value()), "#")
+		m = m.loadAnalyticsForecast(state)
+	default:
+		return m, nil, true
+	}
+	state.Editing = analyticsInputNone
+	m.inputs.textInput.Reset()
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputAnalytics(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.AnalyticsState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Editing != analyticsInputNone {
+		var cmd tea.Cmd
+		m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+		return m, cmd, true
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	if days, ok := analyticsPeriods[keyMsg.String()]; ok {
+		return m.loadAnalyticsPeriod(state, days), nil, true
+	}
+	switch keyMsg.String() {
+	case "c":
+		state.Editing = analyticsInputRange
+		m.inputs.textInput.Reset()
+		m.inputs.textInput.Placeholder = "YYYY-MM-DD YYYY-MM-DD"
+		m.inputs.textInput.SetValue(state.From + " " + state.To)
+		m.inputs.textInput.Focus()
+	case "t":
+		state.Editing = analyticsInputTag
+		m.inputs.textInput.Reset()
+		m.inputs.textInput.Placeholder = "#tag (empty for the whole backlog)"
+		if state.ForecastTag != "" {
+			m.inputs.textInput.SetValue("#" + state.ForecastTag)
+		}
+		m.inputs.textInput.Focus()
+	case "r":
+		state.ShowTime = !state.ShowTime
+	case "g":
+		state.TimeGroup = database.TimeGroups[(int(state.TimeGroup)+1)%len(database.TimeGroups)]
+	case "x":
+		if !state.ShowTime {
+			break
+		}
+		path, err := ExportTimeReport(state.Time, state.TimeGroup)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Error exporting time report: %v", err))
+			break
+		}
+		m.Message = fmt.Sprintf("Time report saved to %s (and .csv)", path)
+	case "m":
+		state.Metric = (state.Metric + 1) % 2
+	case "w":
+		if len(m.workspaces) > 0 {
+			state.WorkspaceIdx = (state.WorkspaceIdx + 1) % len(m.workspaces)
+		}
+	case " ":
+		if state.WorkspaceIdx < len(m.workspaces) {
+			id := m.workspaces[state.WorkspaceIdx].ID
+			state.Hidden[id] = !state.Hidden[id]
+			m = m.loadHeatmap(state)
+			m = m.loadTimeReport(state)
+		}
+	}
+	return m, nil, true
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAnalyticsViewPeriods(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width, m.height = 120, 40
+
+	m, _, _ = m.handleAnalyticsView("M")
+	state, ok := m.modal.AnalyticsState()
+	if !ok {
+		t.Fatalf("expected analytics view")
+	}
+	if state.Days != 7 || len(state.Data.Days) != 7 || state.To != time.Now().Format(util.DateLayout) {
+		t.Fatalf("expected the last 7 days, got %+v", state)
+	}
+	if view := m.renderAnalytics(state); !strings.Contains(view, "last 7 days") || !strings.Contains(view, "Focus/day") {
+		t.Fatalf("unexpected analytics view:\n%s", view)
+	}
+
+	if len(state.Heatmap) != HeatmapDays || !strings.Contains(m.renderAnalytics(state), "Heatmap: focus minutes") {
+		t.Fatalf("expected a year of heatmap, got %d days", len(state.Heatmap))
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if state.Metric != HeatmapGoals {
+		t.Fatalf("expected heatmap metric to switch to goals")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !state.Hidden[m.workspaces[m.activeWorkspaceIdx].ID] {
+		t.Fatalf("expected active workspace to be hidden from the heatmap")
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if state.Days != 90 || len(state.Data.Days) != 90 {
+		t.Fatalf("expected 90 days, got %d", len(state.Data.Days))
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if state.Editing != analyticsInputRange {
+		t.Fatalf("expected custom range input")
+	}
+	m.inputs.textInput.SetValue("2024-01-10 2024-01-01")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if state.Editing != analyticsInputNone || state.Days != 0 || state.From != "2024-01-01" || len(state.Data.Days) != 10 {
+		t.Fatalf("expected custom range, got %+v", state)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.modal.Is(ModalAnalytics) {
+		t.Fatalf("expected analytics view to close")
+	}
+}
+
+func TestAnalyticsTimeReport(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width, m.height = 120, 40
+	docDir := t.TempDir()
+	t.Setenv("XDG_DOCUMENTS_DIR", docDir)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	if err := m.db.AddGoal(m.ctx, wsID, "Write proposal #client", 0); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	goalID, err := m.db.GetLastGoalID(m.ctx)
+	if err != nil {
+		t.Fatalf("GetLastGoalID failed: %v", err)
+	}
+	if err := m.db.StartTaskTimer(m.ctx, goalID); err != nil {
+		t.Fatalf("StartTaskTimer failed: %v", err)
+	}
+	db := m.db.(*database.Database)
+	if _, err := db.DB.ExecContext(m.ctx, "UPDATE goals SET task_started_at = datetime('now', '-25 minutes') WHERE id = ?", goalID); err != nil {
+		t.Fatalf("backdating timer failed: %v", err)
+	}
+
+	m, _, _ = m.handleAnalyticsView("M")
+	state, _ := m.modal.AnalyticsState()
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if !state.ShowTime || len(state.Time.Entries) != 1 || state.Time.Entries[0].GoalID != goalID {
+		t.Fatalf("expected the running timer in the time report, got %+v", state.Time)
+	}
+	if view := m.renderAnalytics(state); !strings.Contains(view, "Time report by goal") || !strings.Contains(view, "Write proposal") {
+		t.Fatalf("unexpected time report view:\n%s", view)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if view := m.renderAnalytics(state); state.TimeGroup != database.TimeByTag || !strings.Contains(view, "#client") {
+		t.Fatalf("expected the report by tag:\n%s", view)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	base := filepath.Join(util.ReportsDir("sspt"), fmt.Sprintf("time_%s_%s_tag", state.From, state.To))
+	for _, ext := range []string{".md", ".csv"} {
+		if _, err := os.Stat(base + ext); err != nil {
+			t.Fatalf("expected exported %s report: %v", ext, err)
+		}
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if len(state.Time.Entries) != 0 {
+		t.Fatalf("expected hidden workspace to leave the time report")
+	}
+}
+
+func TestHeatmapLevel(t *testing.T) {
+	for _, tc := range []struct{ value, max, want int }{{0, 10, 0}, {1, 10, 1}, {5, 10, 2}, {10, 10, 4}, {3, 0, 0}} {
+		if got := heatmapLevel(tc.value, tc.max); got != tc.want {
+			t.Fatalf("heatmapLevel(%d, %d) = %d, want %d", tc.value, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]float64{0, 50, 100, -1}, 100); got != "▁▄█ " {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoPlanCapacitySetting stores the sprint capacity last used by auto-plan.
+const autoPlanCapacitySetting = "autoplan_capacity"
+
+func (m DashboardModel) handleAutoPlan(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "S" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	capacity := config.AutoPlanSprintCapacity
+	if value, ok := m.db.GetSetting(m.ctx, autoPlanCapacitySetting); ok {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			capacity = n
+		}
+	}
+	plan, err := m.buildAutoPlan(capacity)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error planning sprints: %v", err))
+		return m, nil, true
+	}
+	if len(plan.Sprints) == 0 {
+		m.Message = "No open sprints to plan"
+		return m, nil, true
+	}
+	if len(plan.Items) == 0 {
+		m.Message = "Backlog is empty, nothing to plan"
+		return m, nil, true
+	}
+	m.modal.Open(&AutoPlanState{Plan: plan})
+	m.showAnalytics = false
+	m.showDetails = false
+	return m, nil, true
+}
+
+// buildAutoPlan gathers the day's open sprints, the backlog and the
+// workspace dependencies and proposes a plan.
+func (m DashboardModel) buildAutoPlan(capacity int) (autoPlan, error) {
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	var sprints []planSprint
+	scheduled := make(map[int64]int)
+	var slots map[int64]sprintSlot
+	if len(m.meetings) > 0 {
+		slots = m.sprintSlots()
+	}
+	dayEnd := time.Time{}
+	if day, err := time.ParseInLocation(util.DateLayout, m.day.Date, time.Local); err == nil {
+		dayEnd = day.Add(config.PlannedDayEnd)
+	}
+	for _, s := range m.sprints {
+		if s.SprintNumber <= 0 || s.Status == models.StatusCompleted || s.Status == models.StatusInterrupted {
+			continue
+		}
+		goals, err := m.db.GetGoalsForSprint(m.ctx, s.ID)
+		if err != nil {
+			return autoPlan{}, err
+		}
+		ps := planSprint{ID: s.ID, Number: s.SprintNumber, Label: s.Label}
+		// Meetings take time out of the day: sprints that run into one, or
+		// that they push past the end of the day, are left empty.
+		if slot, ok := slots[s.ID]; ok {
+			if slot.Meeting != nil {
+				ps.Blocked = "overlaps " + FormatMeeting(*slot.Meeting)
+			} else if slot.Planned && slot.End.After(dayEnd) {
+				ps.Blocked = "after " + formatClock(config.PlannedDayEnd) + " around meetings"
+			}
+		}
+		for _, g := range goals {
+			scheduled[g.ID] = len(sprints)
+			if g.ParentID != nil || g.Status == models.GoalStatusCompleted {
+				continue
+			}
+			ps.Load += models.EffortPoints(g.Effort)
+			ps.HasFocus = ps.HasFocus || hasFocusTag(g)
+		}
+		sprints = append(sprints, ps)
+	}
+	backlog, err := m.db.GetBacklogGoals(m.ctx, wsID)
+	if err != nil {
+		return autoPlan{}, err
+	}
+	today := time.Now().Format(util.DateLayout)
+	var candidates []models.Goal
+	for _, g := range backlog {
+		if g.ParentID != nil || (g.DeferredUntil != nil && *g.DeferredUntil > today) {
+			continue
+		}
+		candidates = append(candidates, g)
+	}
+	edges, err := m.db.GetDependencyEdges(m.ctx, wsID)
+	if err != nil {
+		return autoPlan{}, err
+	}
+	linked, err := m.db.GetDependencyGoals(m.ctx, wsID)
+	if err != nil {
+		return autoPlan{}, err
+	}
+	done := make(map[int64]bool)
+	for _, g := range linked {
+		if g.Status == models.GoalStatusCompleted {
+			done[g.ID] = true
+		}
+	}
+	return buildAutoPlan(sprints, candidates, edges, scheduled, done, capacity), nil
+}
+
+func (m DashboardModel) handleModalConfirmAutoPlan() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.AutoPlanState()
+	if !ok {
+		return m, nil, false
+	}
+	var moves []database.GoalMove
+	for _, item := range state.Plan.Items {
+		if item.Target >= 0 {
+			moves = append(moves, database.GoalMove{GoalID: item.Goal.ID, SprintID: state.Plan.Sprints[item.Target].ID})
+		}
+	}
+	// The plan stays open when it cannot be applied; nothing has moved.
+	if err := m.db.MoveGoals(m.ctx, moves); err != nil {
+		m.setStatusError(fmt.Sprintf("Error applying plan: %v", err))
+		return m, nil, true
+	}
+	m.modal.Close()
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if len(moves) > 0 {
+		m.Message = fmt.Sprintf("Planned %d goal(s) into sprints", len(moves))
+	}
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputAutoPlan(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.AutoPlanState()
+	if !ok {
+		return m, nil, false
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	items := state.Plan.Items
+	key := keyMsg.String()
+	switch key {
+	case "up", "k":
+		if state.Cursor > 0 {
+			state.Cursor--
+		}
+	case "down", "j":
+		if state.Cursor < len(items)-1 {
+			state.Cursor++
+		}
+	case "left", "h":
+		if items[state.Cursor].Target >= 0 {
+			items[state.Cursor].Target--
+		}
+	case "right", "l":
+		if items[state.Cursor].Target < len(state.Plan.Sprints)-1 {
+			items[state.Cursor].Target++
+		}
+	case "b", "0":
+		items[state.Cursor].Target = -1
+	case "+", "-":
+		capacity := state.Plan.Capacity + 1
+		if key == "-" {
+			capacity = state.Plan.Capacity - 1
+		}
+		capacity = util.Clamp(capacity, 1, config.AutoPlanMaxCapacity)
+		plan, err := m.buildAutoPlan(capacity)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Error planning sprints: %v", err))
+			return m, nil, true
+		}
+		if err := m.db.SetSetting(m.ctx, autoPlanCapacitySetting, strconv.Itoa(capacity)); err != nil {
+			m.setStatusError(fmt.Sprintf("Error saving setting: %v", err))
+		}
+		state.Plan = plan
+		state.Cursor = util.Clamp(state.Cursor, 0, len(plan.Items)-1)
+	default:
+		if n, err := strconv.Atoi(key); err == nil {
+			for i, s := range state.Plan.Sprints {
+				if s.Number == n {
+					items[state.Cursor].Target = i
+				}
+			}
+		}
+	}
+	return m, nil, true
+}
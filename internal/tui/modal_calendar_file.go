@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m DashboardModel) handleCalendarFile(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "ctrl+o" {
+		return m, nil, false
+	}
+	path := CalendarFile(m.ctx, m.db)
+	m.modal.Open(&CalendarFileState{Path: path})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "path/to/calendar.ics"
+	m.inputs.textInput.SetValue(path)
+	m.inputs.textInput.Focus()
+	m.showAnalytics = false
+	m.showDetails = false
+	return m, nil, true
+}
+
+// handleModalConfirmCalendarFile saves the calendar file and reloads the
+// day's meetings.
+func (m DashboardModel) handleModalConfirmCalendarFile() (DashboardModel, tea.Cmd, bool) {
+	if _, ok := m.modal.CalendarFileState(); !ok {
+		return m, nil, false
+	}
+	path := strings.TrimSpace(m.inputs.textInput.Value())
+	if err := SetCalendarFile(m.ctx, m.db, path); err != nil {
+		m.setStatusError(fmt.Sprintf("Cannot use calendar file: %v", err))
+		return m, nil, true
+	}
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	m.meetingsDate = ""
+	m.refreshData(m.day.ID)
+	if path == "" {
+		m.Message = "Calendar file cleared"
+	} else {
+		m.Message = fmt.Sprintf("Calendar file set: %d meeting(s) on %s", len(m.meetings), m.day.Date)
+	}
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputCalendarFile(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	if _, ok := m.modal.CalendarFileState(); !ok {
+		return m, nil, false
+	}
+	var cmd tea.Cmd
+	m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+	return m, cmd, true
+}
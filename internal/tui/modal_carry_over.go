@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type carryOverAction int
+
+const (
+	carryOverToSprint carryOverAction = iota
+	carryOverToBacklog
+	carryOverDefer
+	carryOverArchive
+	carryOverDelete
+	carryOverSkip
+)
+
+type carryOverOption struct {
+	Label    string
+	Action   carryOverAction
+	SprintID int64
+}
+
+// carryOverOptions lists today's unfinished sprints followed by the other
+// ways to resolve a carried-over goal.
+func (m DashboardModel) carryOverOptions() []carryOverOption {
+	var opts []carryOverOption
+	for _, s := range m.sprints {
+		if s.SprintNumber > 0 && s.Status != models.StatusCompleted {
+			opts = append(opts, carryOverOption{Label: FormatSprintTitle(s.SprintNumber, s.Label), Action: carryOverToSprint, SprintID: s.ID})
+		}
+	}
+	return append(opts,
+		carryOverOption{Label: "Backlog", Action: carryOverToBacklog},
+		carryOverOption{Label: "Defer to date...", Action: carryOverDefer},
+		carryOverOption{Label: "Archive", Action: carryOverArchive},
+		carryOverOption{Label: "Delete", Action: carryOverDelete},
+		carryOverOption{Label: "Skip for now", Action: carryOverSkip},
+	)
+}
+
+// openCarryOverReview starts the review of goals left unfinished on the
+// previous day. When quiet is set, nothing is reported if there is nothing
+// to review.
+func (m DashboardModel) openCarryOverReview(quiet bool) DashboardModel {
+	if len(m.workspaces) == 0 {
+		return m
+	}
+	today := time.Now().Format(util.DateLayout)
+	if m.day.Date != today {
+		if !quiet {
+			m.Message = "Carry-over review is only available for today"
+		}
+		return m
+	}
+	goals, err := m.db.GetCarryOverGoals(m.ctx, m.workspaces[m.activeWorkspaceIdx].ID, today)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading carry-over goals: %v", err))
+		return m
+	}
+	if len(goals) == 0 {
+		if !quiet {
+			m.Message = "Nothing to carry over"
+		}
+		return m
+	}
+	m.modal.Open(&CarryOverState{Goals: goals})
+	return m
+}
+
+func (m DashboardModel) handleCarryOverReview(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "O" {
+		return m, nil, false
+	}
+	return m.openCarryOverReview(false), nil, true
+}
+
+func (m DashboardModel) handleModalConfirmCarryOver() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.CarryOverState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Deferring {
+		date, err := parseDeferDate(m.inputs.textInput.Value(), time.Now())
+		if err != nil {
+			m.setStatusError(err.Error())
+			return m, nil, true
+		}
+		goal := state.Goals[state.Index]
+		if err := m.db.DeferGoal(m.ctx, goal.ID, date); err != nil {
+			m.setStatusError(fmt.Sprintf("Error deferring goal: %v", err))
+			return m, nil, true
+		}
+		state.Deferring = false
+		m.inputs.textInput.Reset()
+		return m.advanceCarryOver(state), nil, true
+	}
+	opts := m.carryOverOptions()
+	if state.Cursor >= len(opts) {
+		state.Cursor = len(opts) - 1
+	}
+	return m.applyCarryOverOption(state, opts[state.Cursor]), nil, true
+}
+
+func (m DashboardModel) applyCarryOverOption(state *CarryOverState, opt carryOverOption) DashboardModel {
+	goal := state.Goals[state.Index]
+	var err error
+	switch opt.Action {
+	case carryOverToSprint:
+		err = m.db.CarryOverGoal(m.ctx, goal.ID, opt.SprintID)
+	case carryOverToBacklog:
+		err = m.db.CarryOverGoal(m.ctx, goal.ID, 0)
+	case carryOverDefer:
+		state.Deferring = true
+		m.inputs.textInput.Reset()
+		m.inputs.textInput.Placeholder = "YYYY-MM-DD or +days"
+		m.inputs.textInput.SetValue(time.Now().AddDate(0, 0, 1).Format(util.DateLayout))
+		m.inputs.textInput.Focus()
+		return m
+	case carryOverArchive:
+		err = m.db.ArchiveGoal(m.ctx, goal.ID)
+	case carryOverDelete:
+		err = m.db.DeleteGoal(m.ctx, goal.ID)
+	case carryOverSkip:
+		err = m.db.SkipCarryOver(m.ctx, goal.ID)
+	}
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error carrying over goal: %v", err))
+		return m
+	}
+	return m.advanceCarryOver(state)
+}
+
+// advanceCarryOver moves to the next goal, closing the review after the last.
+func (m DashboardModel) advanceCarryOver(state *CarryOverState) DashboardModel {
+	state.Index++
+	state.Cursor = 0
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if state.Index >= len(state.Goals) {
+		m.modal.Close()
+		m.Message = fmt.Sprintf("Reviewed %d carried-over goal(s)", len(state.Goals))
+	}
+	return m
+}
+
+func (m DashboardModel) handleModalInputCarryOver(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.CarryOverState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Deferring {
+		var cmd tea.Cmd
+		m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+		return m, cmd, true
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	opts := m.carryOverOptions()
+	key := keyMsg.String()
+	switch key {
+	case "up", "k":
+		if state.Cursor > 0 {
+			state.Cursor--
+		}
+		return m, nil, true
+	case "down", "j":
+		if state.Cursor < len(opts)-1 {
+			state.Cursor++
+		}
+		return m, nil, true
+	}
+	shortcuts := map[string]carryOverAction{
+		"b": carryOverToBacklog,
+		"f": carryOverDefer,
+		"a": carryOverArchive,
+		"d": carryOverDelete,
+		"s": carryOverSkip,
+	}
+	if action, ok := shortcuts[key]; ok {
+		for _, opt := range opts {
+			if opt.Action == action {
+				return m.applyCarryOverOption(state, opt), nil, true
+			}
+		}
+	}
+	if n, err := strconv.Atoi(key); err == nil && n > 0 {
+		for _, s := range m.sprints {
+			if s.SprintNumber == n && s.Status != models.StatusCompleted {
+				return m.applyCarryOverOption(state, carryOverOption{Action: carryOverToSprint, SprintID: s.ID}), nil, true
+			}
+		}
+	}
+	return m, nil, true
+}
+
+// parseDeferDate accepts YYYY-MM-DD or "+N" days from now; the date must be
+// after today.
+func parseDeferDate(value string, now time.Time) (string, error) {
+	value = strings.TrimSpace(value)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var date time.Time
+	if strings.HasPrefix(value, "+") {
+		days, err := strconv.Atoi(strings.TrimPrefix(value, "+"))
+		if err != nil {
+			return "", fmt.Errorf("invalid day offset %q", value)
+		}
+		date = today.AddDate(0, 0, days)
+	} else {
+		parsed, err := time.ParseInLocation(util.DateLayout, value, time.Local)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q, use YYYY-MM-DD or +days", value)
+		}
+		date = parsed
+	}
+	if !date.After(today) {
+		return "", fmt.Errorf("date must be after today")
+	}
+	return date.Format(util.DateLayout), nil
+}
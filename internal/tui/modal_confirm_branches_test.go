@@ -2,7 +2,9 @@ package tui
 
 import (
 	"testing"
+	"time"
 
+	"github.com/akyairhashvil/SSPT/internal/database"
 	"github.com/akyairhashvil/SSPT/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -150,3 +152,185 @@ func TestHandleModalConfirmRecurrenceWeekly(t *testing.T) {
 		t.Fatalf("expected goal with recurrence")
 	}
 }
+
+func TestHandleModalConfirmRecurrenceInterval(t *testing.T) {
+	m, goalID, _, _, _ := setupTwoGoalsInSprint(t)
+	m.modal.Open(&RecurrenceState{
+		GoalID:         goalID,
+		Mode:           "weekly",
+		Selected:       map[string]bool{"fri": true},
+		WeekdayOptions: []string{"mon", "fri"},
+		Rule:           "weekly:mon;until=2030-01-01",
+	})
+	next, _, _ := m.handleModalInputRecurrence(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m, _, handled := next.handleModalConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	if !handled {
+		t.Fatalf("expected handled")
+	}
+	goal, err := m.db.GetGoalByID(m.ctx, goalID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	want := "weekly:fri;interval=2;start=" + time.Now().Format("2006-01-02") + ";until=2030-01-01"
+	if goal.RecurrenceRule == nil || *goal.RecurrenceRule != want {
+		t.Fatalf("expected rule %q, got %v", want, goal.RecurrenceRule)
+	}
+}
+
+func TestHandleModalConfirmTemplateFromCreate(t *testing.T) {
+	m, _, _, sprintID, sprintIdx := setupTwoGoalsInSprint(t)
+	goal := database.TemplateGoal{
+		GoalSeed: database.GoalSeed{Description: "Onboard {{date}}"},
+		Subtasks: []database.TemplateGoal{{GoalSeed: database.GoalSeed{Description: "Create accounts"}}},
+	}
+	for _, name := range []string{"Onboarding", "Release"} {
+		if err := m.db.SaveGoalTemplate(m.ctx, name, goal); err != nil {
+			t.Fatalf("SaveGoalTemplate failed: %v", err)
+		}
+	}
+	m.view.focusedColIdx = sprintIdx
+	m.modal.Open(&GoalCreateState{})
+	m.inputs.textInput.SetValue("onb")
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyCtrlT})
+	state, ok := m.modal.TemplateState()
+	if !ok {
+		t.Fatalf("expected template picker to open")
+	}
+	if len(state.Templates) != 1 || state.Templates[0].Name != "Onboarding" {
+		t.Fatalf("expected filtered templates, got %+v", state.Templates)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected template picker closed")
+	}
+	goals, err := m.db.GetGoalsForSprint(m.ctx, sprintID)
+	if err != nil {
+		t.Fatalf("GetGoalsForSprint failed: %v", err)
+	}
+	want := "Onboard " + m.day.Date
+	for _, g := range goals {
+		if g.Description == want {
+			found := false
+			for _, sub := range goals {
+				if sub.ParentID != nil && *sub.ParentID == g.ID && sub.Description == "Create accounts" {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected template subtask under %d, got %+v", g.ID, goals)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected goal %q in sprint, got %+v", want, goals)
+}
+
+func TestCarryOverReviewFlow(t *testing.T) {
+	m, idA, idB, sprintID, _ := setupTwoGoalsInSprint(t)
+	for _, id := range []int64{idA, idB} {
+		if err := m.db.DeferGoal(m.ctx, id, m.day.Date); err != nil {
+			t.Fatalf("DeferGoal failed: %v", err)
+		}
+	}
+
+	m, _, _ = m.handleCarryOverReview("O")
+	state, ok := m.modal.CarryOverState()
+	if !ok || len(state.Goals) != 2 {
+		t.Fatalf("expected carry-over review with 2 goals, got %+v", state)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	goal, err := m.db.GetGoalByID(m.ctx, state.Goals[0].ID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.SprintID == nil || *goal.SprintID != sprintID || goal.CarryOverCount != 2 || goal.DeferredUntil != nil {
+		t.Fatalf("expected goal carried into sprint 1, got %+v", goal)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if !state.Deferring {
+		t.Fatalf("expected defer prompt")
+	}
+	m.inputs.textInput.SetValue("+2")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected review closed after the last goal")
+	}
+	goal, err = m.db.GetGoalByID(m.ctx, state.Goals[1].ID)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	want := time.Now().AddDate(0, 0, 2).Format(util.DateLayout)
+	if goal.DeferredUntil == nil || *goal.DeferredUntil != want || goal.SprintID != nil {
+		t.Fatalf("expected goal deferred to %s, got %+v", want, goal)
+	}
+}
+
+func TestParseDeferDate(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.Local)
+	if got, err := parseDeferDate("+3", now); err != nil || got != "2026-10-19" {
+		t.Fatalf("parseDeferDate(+3) = %q, %v", got, err)
+	}
+	if got, err := parseDeferDate("2026-11-01", now); err != nil || got != "2026-11-01" {
+		t.Fatalf("parseDeferDate(date) = %q, %v", got, err)
+	}
+	for _, bad := range []string{"2026-10-16", "+0", "tomorrow"} {
+		if _, err := parseDeferDate(bad, now); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestGoalLinksModal(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	var opened []string
+	orig := openLink
+	openLink = func(target string) error {
+		opened = append(opened, target)
+		return nil
+	}
+	t.Cleanup(func() { openLink = orig })
+
+	m.view.focusedColIdx = sprintIdx
+	for i, g := range m.sprints[sprintIdx].Goals {
+		if g.ID == idA {
+			m.view.focusedGoalIdx = i
+		}
+	}
+	m, _, _ = m.handleGoalLinks("o")
+	state, ok := m.modal.LinksState()
+	if !ok || !state.Editing {
+		t.Fatalf("expected links modal to prompt for the first link")
+	}
+	m.inputs.textInput.SetValue("https://b.example")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.inputs.textInput.SetValue("https://a.example")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(state.Links) != 2 || state.Links[0] != "https://a.example" {
+		t.Fatalf("expected two stored links, got %v", state.Links)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m.inputs.textInput.SetValue("/tmp/notes.md")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if len(state.Links) != 1 || state.Links[0] != "https://b.example" {
+		t.Fatalf("expected edit then removal to leave one link, got %v", state.Links)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(opened) != 1 || opened[0] != "https://b.example" {
+		t.Fatalf("expected selected link to be opened, got %v", opened)
+	}
+	goal, err := m.db.GetGoalByID(m.ctx, idA)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Links == nil || *goal.Links != `["https://b.example"]` {
+		t.Fatalf("unexpected stored links: %v", goal.Links)
+	}
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// parseClock parses HH:MM on date in local time.
+func parseClock(date, value string) (time.Time, error) {
+	t, err := time.ParseInLocation(util.DateLayout+" 15:04", date+" "+strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("use HH:MM")
+	}
+	return t, nil
+}
+
+// parseSprintCorrection parses "HH:MM-HH:MM [focused minutes]" for a sprint
+// of the day date.
+func parseSprintCorrection(date, value string) (database.SprintCorrection, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 2 {
+		return database.SprintCorrection{}, fmt.Errorf("use HH:MM-HH:MM [minutes]")
+	}
+	bounds := strings.SplitN(fields[0], "-", 2)
+	if len(bounds) != 2 {
+		return database.SprintCorrection{}, fmt.Errorf("use HH:MM-HH:MM [minutes]")
+	}
+	start, err := parseClock(date, bounds[0])
+	if err != nil {
+		return database.SprintCorrection{}, err
+	}
+	end, err := parseClock(date, bounds[1])
+	if err != nil {
+		return database.SprintCorrection{}, err
+	}
+	c := database.SprintCorrection{Start: start, End: end}
+	if len(fields) == 2 {
+		mins, err := strconv.Atoi(strings.TrimSuffix(fields[1], "m"))
+		if err != nil || mins <= 0 {
+			return database.SprintCorrection{}, fmt.Errorf("focused minutes must be a positive number")
+		}
+		c.Focused = time.Duration(mins) * time.Minute
+	}
+	return c, nil
+}
+
+// parseCompletionTime parses HH:MM on date, or a full YYYY-MM-DD HH:MM.
+func parseCompletionTime(date, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, " ") {
+		t, err := time.ParseInLocation(util.DateLayout+" 15:04", value, time.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("use HH:MM or YYYY-MM-DD HH:MM")
+		}
+		return t, nil
+	}
+	return parseClock(date, value)
+}
+
+// handleSprintCorrect asks for the real times of the focused sprint, for
+// sprints done while the app was not running.
+func (m DashboardModel) handleSprintCorrect(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "B" {
+		return m, nil, false
+	}
+	if !m.validSprintIndex(m.view.focusedColIdx) || m.sprints[m.view.focusedColIdx].SprintNumber <= 0 {
+		return m, nil, true
+	}
+	target := m.sprints[m.view.focusedColIdx]
+	if isFutureDay(m.day.Date) {
+		m.Message = fmt.Sprintf("Sprint is planned for %s", m.day.Date)
+		return m, nil, true
+	}
+	if target.Status == models.StatusActive || target.Status == models.StatusPaused {
+		m.Message = "Stop the running sprint before correcting it"
+		return m, nil, true
+	}
+	m.modal.Open(&CorrectionState{SprintID: target.ID, SprintNumber: target.SprintNumber})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "HH:MM-HH:MM [focused minutes]"
+	if target.StartTime != nil && target.EndTime != nil {
+		m.inputs.textInput.SetValue(target.StartTime.Local().Format("15:04") + "-" + target.EndTime.Local().Format("15:04"))
+	}
+	m.inputs.textInput.Focus()
+	return m, nil, true
+}
+
+// handleGoalCompleteAt asks when the focused goal was actually completed.
+func (m DashboardModel) handleGoalCompleteAt(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "X" {
+		return m, nil, false
+	}
+	goal, ok := m.focusedGoal()
+	if !ok {
+		return m, nil, true
+	}
+	blocked, err := m.db.IsGoalBlocked(m.ctx, goal.ID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error checking dependencies: %v", err))
+		return m, nil, true
+	}
+	if blocked {
+		m.Message = "Blocked by dependency. Complete dependencies first."
+		return m, nil, true
+	}
+	if goal.Status != models.GoalStatusCompleted {
+		for _, sub := range goal.Subtasks {
+			if sub.Status != models.GoalStatusCompleted {
+				m.Message = "Cannot complete task with pending subtasks!"
+				return m, nil, true
+			}
+		}
+	}
+	m.modal.Open(&CorrectionState{GoalID: goal.ID})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "HH:MM or YYYY-MM-DD HH:MM"
+	if goal.CompletedAt != nil {
+		m.inputs.textInput.SetValue(goal.CompletedAt.Local().Format(util.DateLayout + " 15:04"))
+	}
+	m.inputs.textInput.Focus()
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalConfirmCorrection() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.CorrectionState()
+	if !ok {
+		return m, nil, false
+	}
+	value := m.inputs.textInput.Value()
+	if state.SprintID != 0 {
+		c, err := parseSprintCorrection(m.day.Date, value)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Invalid sprint times: %v", err))
+			return m, nil, true
+		}
+		if err := m.db.CorrectSprint(m.ctx, state.SprintID, c); err != nil {
+			m.setStatusError(fmt.Sprintf("Error correcting sprint: %v", err))
+			return m, nil, true
+		}
+		m.Message = fmt.Sprintf("Sprint %d corrected", state.SprintNumber)
+	} else {
+		at, err := parseCompletionTime(m.day.Date, value)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Invalid completion time: %v", err))
+			return m, nil, true
+		}
+		if err := m.db.CompleteGoalAt(m.ctx, state.GoalID, at); err != nil {
+			m.setStatusError(fmt.Sprintf("Error completing goal: %v", err))
+			return m, nil, true
+		}
+		m.Message = fmt.Sprintf("Completed #%d at %s", state.GoalID, at.Format(util.DateLayout+" 15:04"))
+	}
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	m.invalidateGoalCache()
+	m.forecastKey = "" // Past velocity may have changed
+	m.refreshData(m.day.ID)
+	return m, nil, true
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBackfillAndCorrectPastSprint(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	yesterday := time.Now().AddDate(0, 0, -1).Format(util.DateLayout)
+
+	m.view.focusedColIdx, m.view.focusedGoalIdx = sprintIdx, 0
+	m, _, _ = m.handleGoalCompleteAt("X")
+	if !m.modal.Is(ModalCorrection) {
+		t.Fatalf("expected completion time prompt")
+	}
+	m.inputs.textInput.SetValue(yesterday + " 23:30")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	goal, err := m.db.GetGoalByID(m.ctx, idA)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Status != models.GoalStatusCompleted || goal.CompletedAt == nil || goal.CompletedAt.Local().Format(util.DateLayout+" 15:04") != yesterday+" 23:30" {
+		t.Fatalf("unexpected goal after correction %+v", goal)
+	}
+
+	// Before the first day, '<' offers to backfill yesterday.
+	m, _, _ = handleNormalPrevDay(m, "<")
+	if state, ok := m.modal.PlanDayState(); !ok || !state.Backfill || state.Date != yesterday {
+		t.Fatalf("expected backfill prompt for %s", yesterday)
+	}
+	m.inputs.textInput.SetValue("2")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.day.Date != yesterday || !strings.HasPrefix(m.Message, "Backfilled") {
+		t.Fatalf("expected backfilled day, got %s %q", m.day.Date, m.Message)
+	}
+
+	m, _, _ = m.handleSprintStart("s")
+	if !strings.HasPrefix(m.Message, "Past sprint") {
+		t.Fatalf("expected past sprint start to be refused, got %q", m.Message)
+	}
+	m, _, _ = m.handleSprintCorrect("B")
+	if state, ok := m.modal.CorrectionState(); !ok || state.SprintNumber != 1 {
+		t.Fatalf("expected sprint correction prompt")
+	}
+	m.inputs.textInput.SetValue("10:00-09:00")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.modal.Is(ModalCorrection) {
+		t.Fatalf("expected end before start to be refused")
+	}
+	m.inputs.textInput.SetValue("09:00-10:00 45m")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Message != "Sprint 1 corrected" {
+		t.Fatalf("unexpected message %q", m.Message)
+	}
+	sprint := m.sprints[m.view.focusedColIdx]
+	if sprint.Status != models.StatusCompleted || !sprint.Corrected || sprint.FocusedDuration(time.Now()) != 45*time.Minute {
+		t.Fatalf("unexpected corrected sprint %+v", sprint.Sprint)
+	}
+}
+
+func TestGoalCompleteAtRefusesBlockedGoal(t *testing.T) {
+	m, idA, idB, _, sprintIdx := setupTwoGoalsInSprint(t)
+	if err := m.db.SetGoalDependencies(m.ctx, idB, []int64{idA}); err != nil {
+		t.Fatalf("SetGoalDependencies failed: %v", err)
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	m.view.focusedColIdx = sprintIdx
+	for i, g := range m.sprints[sprintIdx].Goals {
+		if g.ID == idB {
+			m.view.focusedGoalIdx = i
+		}
+	}
+	m, _, _ = m.handleGoalCompleteAt("X")
+	if m.modal.Is(ModalCorrection) {
+		t.Fatalf("expected blocked goal to be refused")
+	}
+	if !strings.HasPrefix(m.Message, "Blocked by dependency") {
+		t.Fatalf("unexpected message %q", m.Message)
+	}
+}
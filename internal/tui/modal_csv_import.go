@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// expandHome replaces a leading "~/" with the home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+func (m DashboardModel) handleCSVImport(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "U" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	m.modal.Open(&CSVImportState{})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "path/to/goals.csv"
+	m.inputs.textInput.Focus()
+	return m, nil, true
+}
+
+// backCSVImport returns to the previous step of a CSV import.
+func (m DashboardModel) backCSVImport(state *CSVImportState) DashboardModel {
+	switch state.Step {
+	case csvImportPreview:
+		state.Step = csvImportMapping
+		state.Preview = nil
+	case csvImportMapping:
+		state.Step = csvImportPath
+		m.inputs.textInput.SetValue(state.Path)
+		m.inputs.textInput.Focus()
+	}
+	return m
+}
+
+func (m DashboardModel) handleModalConfirmCSVImport() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.CSVImportState()
+	if !ok {
+		return m, nil, false
+	}
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	switch state.Step {
+	case csvImportPath:
+		path := expandHome(strings.TrimSpace(m.inputs.textInput.Value()))
+		f, err := os.Open(path)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Cannot open CSV: %v", err))
+			return m, nil, true
+		}
+		defer f.Close()
+		c, err := ParseCSVImport(f)
+		if err != nil {
+			m.setStatusError(err.Error())
+			return m, nil, true
+		}
+		state.Path, state.Import, state.FieldIdx = path, c, 0
+		state.Step = csvImportMapping
+		m.inputs.textInput.Reset()
+	case csvImportMapping:
+		if state.Import.Mapping["description"] < 0 {
+			m.setStatusError("Map a column to the description first")
+			return m, nil, true
+		}
+		preview, err := PreviewCSVImport(m.ctx, m.db, wsID, state.Import)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("Error previewing CSV: %v", err))
+			return m, nil, true
+		}
+		state.Preview, state.Offset = preview, 0
+		state.Step = csvImportPreview
+	case csvImportPreview:
+		imported, err := ImportCSVRows(m.ctx, m.db, wsID, state.Preview)
+		if err != nil {
+			m.setStatusError(fmt.Sprintf("CSV import failed after %d goal(s): %v", imported, err))
+			return m, nil, true
+		}
+		counts := CountCSVRows(state.Preview)
+		m.modal.Close()
+		m.invalidateGoalCache()
+		m.refreshData(m.day.ID)
+		m.Message = fmt.Sprintf("Imported %d goal(s) into the backlog, skipped %d duplicate(s) and %d invalid row(s)",
+			imported, counts[CSVRowDuplicate], counts[CSVRowInvalid])
+	}
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputCSVImport(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.CSVImportState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Step == csvImportPath {
+		var cmd tea.Cmd
+		m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+		return m, cmd, true
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	if state.Step == csvImportPreview {
+		switch keyMsg.String() {
+		case "up", "k":
+			if state.Offset > 0 {
+				state.Offset--
+			}
+		case "down", "j":
+			if state.Offset < len(state.Preview)-1 {
+				state.Offset++
+			}
+		}
+		return m, nil, true
+	}
+	field := CSVImportFields[state.FieldIdx]
+	columns := len(state.Import.Header)
+	switch keyMsg.String() {
+	case "up", "k":
+		if state.FieldIdx > 0 {
+			state.FieldIdx--
+		}
+	case "down", "j":
+		if state.FieldIdx < len(CSVImportFields)-1 {
+			state.FieldIdx++
+		}
+	case "right", "l":
+		// Columns cycle through -1, which leaves the field empty.
+		state.Import.Mapping[field] = (state.Import.Mapping[field]+2)%(columns+1) - 1
+	case "left", "h":
+		state.Import.Mapping[field] = (state.Import.Mapping[field]+columns+1)%(columns+1) - 1
+	case "backspace", "delete":
+		state.Import.Mapping[field] = -1
+	}
+	return m, nil, true
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m DashboardModel) handleDependencyGraph(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "g" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	state := &DepGraphState{}
+	if goal, ok := m.focusedGoal(); ok {
+		state.FocusID = goal.ID
+	}
+	m = m.loadDependencyGraph(state)
+	if len(state.Graph.Nodes) == 0 && state.FocusID != 0 {
+		// The focused goal has no dependencies; fall back to the workspace.
+		state.FocusID = 0
+		m = m.loadDependencyGraph(state)
+	}
+	if len(state.Graph.Nodes) == 0 {
+		m.Message = "No dependencies in this workspace"
+		return m, nil, true
+	}
+	m.modal.Open(state)
+	m.showAnalytics = false
+	m.showDetails = false
+	return m, nil, true
+}
+
+// loadDependencyGraph rebuilds the graph for the state's scope, keeping the
+// cursor on the same goal when it is still shown.
+func (m DashboardModel) loadDependencyGraph(state *DepGraphState) DashboardModel {
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	goals, err := m.db.GetDependencyGoals(m.ctx, wsID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading dependency graph: %v", err))
+		return m
+	}
+	edges, err := m.db.GetDependencyEdges(m.ctx, wsID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading dependency graph: %v", err))
+		return m
+	}
+	var selected int64
+	if order := state.Graph.Order(); state.Cursor < len(order) {
+		selected = order[state.Cursor]
+	} else {
+		selected = state.FocusID
+	}
+	state.Graph = buildDepGraph(goals, edges, state.FocusID)
+	state.Cursor = 0
+	for i, id := range state.Graph.Order() {
+		if id == selected {
+			state.Cursor = i
+		}
+	}
+	return m
+}
+
+func (m DashboardModel) handleModalConfirmDepGraph() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.DepGraphState()
+	if !ok {
+		return m, nil, false
+	}
+	order := state.Graph.Order()
+	if state.Cursor >= len(order) {
+		return m, nil, true
+	}
+	goalID := order[state.Cursor]
+	m.modal.Close()
+	if next, ok := m.focusGoalOnBoard(goalID); ok {
+		next.Message = fmt.Sprintf("Jumped to goal #%d", goalID)
+		return next, nil, true
+	}
+	m.Message = fmt.Sprintf("Goal #%d is not on the board", goalID)
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputDepGraph(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.DepGraphState()
+	if !ok {
+		return m, nil, false
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	count := len(state.Graph.Order())
+	switch keyMsg.String() {
+	case "up", "k", "left", "h":
+		if state.Cursor > 0 {
+			state.Cursor--
+		}
+	case "down", "j", "right", "l":
+		if state.Cursor < count-1 {
+			state.Cursor++
+		}
+	case "w":
+		if state.FocusID != 0 {
+			state.FocusID = 0
+		} else if order := state.Graph.Order(); state.Cursor < count {
+			state.FocusID = order[state.Cursor]
+		}
+		m = m.loadDependencyGraph(state)
+	}
+	return m, nil, true
+}
@@ -65,6 +65,18 @@ func (m DashboardModel) handleModalConfirmJournaling() (DashboardModel, tea.Cmd,
 		return m, nil, false
 	}
 	text := m.inputs.journalInput.Value()
+	if state.EntryID > 0 {
+		if strings.TrimSpace(text) != "" {
+			if err := m.db.UpdateJournalEntry(m.ctx, state.EntryID, text); err != nil {
+				m.setStatusError(fmt.Sprintf("Error saving journal entry: %v", err))
+			} else {
+				m.refreshData(m.day.ID)
+			}
+		}
+		m.inputs.journalInput.Reset()
+		m.modal.Open(&JournalSelectState{Cursor: state.ReturnCursor})
+		return m, nil, true
+	}
 	if strings.TrimSpace(text) != "" {
 		var sID, gID *int64
 		if m.timer.ActiveSprint != nil {
@@ -89,9 +101,19 @@ func (m DashboardModel) handleModalConfirmJournaling() (DashboardModel, tea.Cmd,
 
 func (m DashboardModel) handleModalInputJournaling(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
 	var cmd tea.Cmd
-	if !m.modal.Is(ModalJournaling) {
+	state, ok := m.modal.JournalState()
+	if !ok {
 		return m, nil, false
 	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+e" {
+		pending := journalEditedMsg{EntryID: state.EntryID, GoalID: state.GoalID, Original: m.inputs.journalInput.Value(), Reselect: state.EntryID > 0}
+		if m.timer.ActiveSprint != nil {
+			id := m.timer.ActiveSprint.ID
+			pending.SprintID = &id
+		}
+		next, cmd := m.launchJournalEditor(pending)
+		return next, cmd, true
+	}
 	m.inputs.journalInput, cmd = m.inputs.journalInput.Update(msg)
 	return m, cmd, true
 }
@@ -185,7 +207,7 @@ func (m DashboardModel) handleModalConfirmGoalEdit() (DashboardModel, tea.Cmd, b
 
 func (m DashboardModel) handleModalInputGoalText(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
 	var cmd tea.Cmd
-	if m.modal.Is(ModalGoalCreate) || m.modal.Is(ModalGoalEdit) || m.modal.Is(ModalWorkspaceCreate) || m.modal.Is(ModalWorkspaceInit) {
+	if m.modal.Is(ModalGoalCreate) || m.modal.Is(ModalGoalEdit) || m.modal.Is(ModalWorkspaceCreate) || m.modal.Is(ModalWorkspaceInit) || m.modal.Is(ModalWorkflow) || m.modal.Is(ModalPlanDay) || m.modal.Is(ModalCorrection) {
 		m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
 		return m, cmd, true
 	}
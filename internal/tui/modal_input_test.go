@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/akyairhashvil/SSPT/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -79,3 +81,54 @@ func TestHandleModalInputRecurrenceNavigation(t *testing.T) {
 		t.Fatalf("expected recurrenceItemCursor to advance")
 	}
 }
+
+func TestHandleModalInputRecurrenceMissedPolicy(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.modal.Open(&RecurrenceState{
+		Mode:     "daily",
+		Focus:    "mode",
+		Selected: make(map[string]bool),
+	})
+
+	next, _ := m.handleModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	state, ok := next.modal.RecurrenceState()
+	if !ok {
+		t.Fatalf("expected recurrence modal state")
+	}
+	if state.Missed != util.MissedCatchUp {
+		t.Fatalf("expected catch-up after collapse, got %q", state.Missed)
+	}
+	if got := recurrenceWithOptions("daily", state); got != "daily:missed=catchup" {
+		t.Fatalf("expected missed policy in rule, got %q", got)
+	}
+}
+
+func TestHandleModalInputRecurrenceSprint(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.modal.Open(&RecurrenceState{
+		Mode:     "daily",
+		Focus:    "mode",
+		Selected: make(map[string]bool),
+		Rule:     "daily:sprint=8",
+		Sprint:   8,
+	})
+
+	// Cycling past the last sprint returns to the backlog.
+	next, _ := m.handleModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	state, ok := next.modal.RecurrenceState()
+	if !ok {
+		t.Fatalf("expected recurrence modal state")
+	}
+	if state.Sprint != 0 || recurrenceWithOptions("daily", state) != "daily" {
+		t.Fatalf("expected backlog after the last sprint, got %d %q", state.Sprint, recurrenceWithOptions("daily", state))
+	}
+	next, _ = next.handleModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	next, _ = next.handleModalInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	rule, err := util.ParseRecurrence(recurrenceWithOptions("daily", state))
+	if err != nil || rule.Sprint != 2 {
+		t.Fatalf("expected sprint 2 in rule, got %+v err %v", rule, err)
+	}
+	if view := next.renderJournalPane(); !strings.Contains(view, "Into: sprint 2") {
+		t.Fatalf("expected chosen sprint shown, got %q", view)
+	}
+}
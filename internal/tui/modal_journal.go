@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// journalEditedMsg reports that the external editor for a journal entry
+// exited. EntryID is zero for a new entry.
+type journalEditedMsg struct {
+	EntryID  int64
+	GoalID   int64
+	SprintID *int64
+	Path     string
+	Original string
+	Cleanup  string
+	Reselect bool // return to the journal selection afterwards
+	Err      error
+}
+
+func (m DashboardModel) handleJournalSelect(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "V" {
+		return m, nil, false
+	}
+	if len(m.journalEntries) == 0 {
+		m.Message = "No journal entries for this day"
+		return m, nil, true
+	}
+	m.showAnalytics = false
+	m.showDetails = false
+	m.modal.Open(&JournalSelectState{Cursor: len(m.journalEntries) - 1})
+	return m, nil, true
+}
+
+// startJournalEdit edits the selected entry inline, or in the external
+// editor when it spans several lines.
+func (m DashboardModel) startJournalEdit(state *JournalSelectState, external bool) (DashboardModel, tea.Cmd) {
+	if state.Cursor < 0 || state.Cursor >= len(m.journalEntries) {
+		return m, nil
+	}
+	entry := m.journalEntries[state.Cursor]
+	if external || strings.Contains(entry.Content, "\n") {
+		return m.launchJournalEditor(journalEditedMsg{EntryID: entry.ID, Original: entry.Content, Reselect: true})
+	}
+	m.modal.Open(&JournalState{EntryID: entry.ID, ReturnCursor: state.Cursor})
+	m.inputs.journalInput.SetValue(entry.Content)
+	m.inputs.journalInput.CursorEnd()
+	m.inputs.journalInput.Focus()
+	return m, nil
+}
+
+// launchJournalEditor writes the entry text to a private temp file and hands
+// the terminal to the external editor; pending describes what to save.
+func (m DashboardModel) launchJournalEditor(pending journalEditedMsg) (DashboardModel, tea.Cmd) {
+	path, cleanup, err := writeEditorFile("journal-*.md", pending.Original, m.db.EncryptionStatus().DatabaseEncrypted)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error preparing journal entry: %v", err))
+		return m, nil
+	}
+	pending.Path = path
+	pending.Cleanup = cleanup
+	m.modal.Close()
+	m.inputs.journalInput.Reset()
+	return m, tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		pending.Err = err
+		return pending
+	})
+}
+
+func (m DashboardModel) handleJournalEdited(msg journalEditedMsg) (DashboardModel, tea.Cmd) {
+	defer removeEditorFile(msg.Path, msg.Cleanup)
+	if msg.Err != nil {
+		m.setStatusError(fmt.Sprintf("Editor failed: %v", msg.Err))
+		return m, nil
+	}
+	content, err := readEditorFile(msg.Path)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error reading journal entry: %v", err))
+		return m, nil
+	}
+	switch {
+	case strings.TrimSpace(content) == "":
+		m.Message = "Empty journal entry discarded"
+	case msg.EntryID > 0 && content == strings.TrimRight(msg.Original, "\n"):
+		m.Message = "Journal entry unchanged"
+	case msg.EntryID > 0:
+		if err := m.db.UpdateJournalEntry(m.ctx, msg.EntryID, content); err != nil {
+			m.setStatusError(fmt.Sprintf("Error saving journal entry: %v", err))
+			return m, nil
+		}
+		m.Message = "Journal entry updated"
+	default:
+		var gID *int64
+		if msg.GoalID > 0 {
+			id := msg.GoalID
+			gID = &id
+		}
+		activeWS := m.workspaces[m.activeWorkspaceIdx]
+		if err := m.db.AddJournalEntry(m.ctx, m.day.ID, activeWS.ID, msg.SprintID, gID, content); err != nil {
+			m.setStatusError(fmt.Sprintf("Error saving journal entry: %v", err))
+			return m, nil
+		}
+		m.Message = "Journal entry saved"
+	}
+	m.refreshData(m.day.ID)
+	if msg.Reselect && len(m.journalEntries) > 0 {
+		cursor := len(m.journalEntries) - 1
+		for i, e := range m.journalEntries {
+			if e.ID == msg.EntryID {
+				cursor = i
+			}
+		}
+		m.modal.Open(&JournalSelectState{Cursor: cursor})
+	}
+	return m, nil
+}
+
+func (m DashboardModel) handleModalConfirmJournalSelect() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.JournalSelectState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.ConfirmDelete {
+		return m.deleteSelectedJournalEntry(state), nil, true
+	}
+	next, cmd := m.startJournalEdit(state, false)
+	return next, cmd, true
+}
+
+func (m DashboardModel) deleteSelectedJournalEntry(state *JournalSelectState) DashboardModel {
+	state.ConfirmDelete = false
+	if state.Cursor < 0 || state.Cursor >= len(m.journalEntries) {
+		return m
+	}
+	if err := m.db.DeleteJournalEntry(m.ctx, m.journalEntries[state.Cursor].ID); err != nil {
+		m.setStatusError(fmt.Sprintf("Error deleting journal entry: %v", err))
+		return m
+	}
+	m.refreshData(m.day.ID)
+	if len(m.journalEntries) == 0 {
+		m.modal.Close()
+		m.Message = "Journal entry deleted"
+		return m
+	}
+	if state.Cursor >= len(m.journalEntries) {
+		state.Cursor = len(m.journalEntries) - 1
+	}
+	return m
+}
+
+func (m DashboardModel) handleModalInputJournalSelect(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.JournalSelectState()
+	if !ok {
+		return m, nil, false
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	key := keyMsg.String()
+	if state.ConfirmDelete {
+		if key == "y" {
+			return m.deleteSelectedJournalEntry(state), nil, true
+		}
+		state.ConfirmDelete = false
+		return m, nil, true
+	}
+	switch key {
+	case "up", "k":
+		if state.Cursor > 0 {
+			state.Cursor--
+		}
+	case "down", "j":
+		if state.Cursor < len(m.journalEntries)-1 {
+			state.Cursor++
+		}
+	case "e":
+		next, cmd := m.startJournalEdit(state, false)
+		return next, cmd, true
+	case "E":
+		next, cmd := m.startJournalEdit(state, true)
+		return next, cmd, true
+	case "d", "x", "delete", "backspace":
+		state.ConfirmDelete = true
+	}
+	return m, nil, true
+}
+
+// formatJournalContent indents continuation lines of a multi-line entry so
+// they line up under the first.
+func formatJournalContent(content string, indent int) string {
+	return strings.ReplaceAll(strings.TrimRight(content, "\n"), "\n", "\n"+strings.Repeat(" ", indent))
+}
@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// journalBrowserLimit caps how many entries the browser loads per query.
+const journalBrowserLimit = 500
+
+func (m DashboardModel) handleJournalBrowser(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "H" {
+		return m, nil, false
+	}
+	state := &JournalBrowserState{}
+	m.modal.Open(state)
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "workspace:slug #tag goal:ID sprint:N from:YYYY-MM-DD to:YYYY-MM-DD text"
+	m.inputs.textInput.Focus()
+	m.showAnalytics = false
+	m.showDetails = false
+	return m.queryJournalBrowser(state), nil, true
+}
+
+// journalFilter turns the browser query into a database filter, resolving
+// workspace slugs or names against the loaded workspaces.
+func (m DashboardModel) journalFilter(raw string) database.JournalFilter {
+	q := util.ParseJournalQuery(raw)
+	filter := database.JournalFilter{
+		Tags:         q.Tags,
+		GoalID:       q.GoalID,
+		SprintNumber: q.Sprint,
+		From:         q.From,
+		To:           q.To,
+		Text:         q.Text,
+		Limit:        journalBrowserLimit,
+	}
+	for _, name := range q.Workspace {
+		matched := false
+		for _, ws := range m.workspaces {
+			if strings.EqualFold(ws.Slug, name) || strings.EqualFold(ws.Name, name) {
+				filter.WorkspaceIDs = append(filter.WorkspaceIDs, ws.ID)
+				matched = true
+			}
+		}
+		if !matched {
+			filter.WorkspaceIDs = append(filter.WorkspaceIDs, -1)
+		}
+	}
+	return filter
+}
+
+func (m DashboardModel) queryJournalBrowser(state *JournalBrowserState) DashboardModel {
+	results, err := m.db.SearchJournal(m.ctx, m.journalFilter(m.inputs.textInput.Value()))
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error searching journal: %v", err))
+		return m
+	}
+	state.Results = results
+	if state.Cursor >= len(results) {
+		state.Cursor = len(results) - 1
+	}
+	if state.Cursor < 0 {
+		state.Cursor = 0
+	}
+	return m
+}
+
+func (m DashboardModel) handleModalConfirmJournalBrowser() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.JournalBrowserState()
+	if !ok {
+		return m, nil, false
+	}
+	if len(state.Results) == 0 {
+		return m, nil, true
+	}
+	return m.jumpToJournalRecord(state.Results[state.Cursor], true), nil, true
+}
+
+// jumpToJournalRecord opens the day (and workspace) of a journal entry and,
+// when toGoal is set, focuses the goal it is linked to.
+func (m DashboardModel) jumpToJournalRecord(record database.JournalRecord, toGoal bool) DashboardModel {
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	if record.WorkspaceID != nil {
+		for i, ws := range m.workspaces {
+			if ws.ID == *record.WorkspaceID {
+				m.activeWorkspaceIdx = i
+			}
+		}
+	}
+	m.refreshData(record.DayID)
+	m.view.focusedColIdx = config.DefaultFocusColumn
+	m.view.focusedGoalIdx = 0
+	if !toGoal || record.GoalID == nil {
+		m.Message = "Jumped to " + record.Date
+		return m
+	}
+	if next, ok := m.focusGoalOnBoard(*record.GoalID); ok {
+		next.Message = fmt.Sprintf("Jumped to goal #%d on %s", *record.GoalID, record.Date)
+		return next
+	}
+	m.Message = fmt.Sprintf("Jumped to %s; goal #%d is not on the board", record.Date, *record.GoalID)
+	return m
+}
+
+func (m DashboardModel) handleModalInputJournalBrowser(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.JournalBrowserState()
+	if !ok {
+		return m, nil, false
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up":
+			if state.Cursor > 0 {
+				state.Cursor--
+			}
+			return m, nil, true
+		case "down":
+			if state.Cursor < len(state.Results)-1 {
+				state.Cursor++
+			}
+			return m, nil, true
+		case "ctrl+d":
+			if len(state.Results) > 0 {
+				return m.jumpToJournalRecord(state.Results[state.Cursor], false), nil, true
+			}
+			return m, nil, true
+		case "ctrl+e":
+			if len(state.Results) == 0 {
+				m.Message = "No journal entries to export"
+				return m, nil, true
+			}
+			path, err := exportJournalMarkdown(state.Results)
+			if err != nil {
+				m.setStatusError(fmt.Sprintf("Error exporting journal: %v", err))
+			} else {
+				m.Message = "Journal exported to " + path
+			}
+			return m, nil, true
+		}
+	}
+	var cmd tea.Cmd
+	m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m = m.queryJournalBrowser(state)
+	}
+	return m, cmd, true
+}
+
+// exportJournalMarkdown writes the entries to a markdown file in the reports
+// directory and returns its path.
+func exportJournalMarkdown(records []database.JournalRecord) (string, error) {
+	reportRoot := util.ReportsDir("sspt")
+	if err := os.MkdirAll(reportRoot, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(reportRoot, fmt.Sprintf("journal_%s.md", time.Now().Format("20060102_150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := writeJournalMarkdown(f, records); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// writeJournalMarkdown renders entries oldest first, grouped by day.
+func writeJournalMarkdown(w io.Writer, records []database.JournalRecord) error {
+	if _, err := fmt.Fprintf(w, "# Journal\n"); err != nil {
+		return err
+	}
+	lastDate := ""
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		if r.Date != lastDate {
+			if _, err := fmt.Fprintf(w, "\n## %s\n\n", r.Date); err != nil {
+				return err
+			}
+			lastDate = r.Date
+		}
+		if _, err := fmt.Fprintf(w, "- **%s**%s: %s\n", r.CreatedAt.Format("15:04"), journalRecordContext(r, true), formatJournalContent(r.Content, 2)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// journalRecordContext describes where an entry was logged, e.g.
+// " [Work | S2 | #12 Ship release]".
+func journalRecordContext(r database.JournalRecord, withWorkspace bool) string {
+	var parts []string
+	if withWorkspace && r.Workspace != "" {
+		parts = append(parts, r.Workspace)
+	}
+	if r.SprintNumber > 0 {
+		parts = append(parts, fmt.Sprintf("S%d", r.SprintNumber))
+	}
+	if r.GoalID != nil {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("#%d %s", *r.GoalID, r.GoalDescription)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(parts, " | ") + "]"
+}
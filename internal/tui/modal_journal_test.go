@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestJournalSelectEditDelete(t *testing.T) {
+	m := setupTestDashboard(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	for _, text := range []string{"First", "Second"} {
+		if err := m.db.AddJournalEntry(m.ctx, m.day.ID, wsID, nil, nil, text); err != nil {
+			t.Fatalf("AddJournalEntry failed: %v", err)
+		}
+	}
+	m.refreshData(m.day.ID)
+
+	m, _, _ = m.handleJournalSelect("V")
+	state, ok := m.modal.JournalSelectState()
+	if !ok || state.Cursor != 1 {
+		t.Fatalf("expected selection on the latest entry, got %+v", state)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if js, ok := m.modal.JournalState(); !ok || js.EntryID != m.journalEntries[0].ID {
+		t.Fatalf("expected inline edit of the first entry")
+	}
+	m.inputs.journalInput.SetValue("First edited #win")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.journalEntries[0].Content != "First edited #win" || m.journalEntries[0].Tags == nil || *m.journalEntries[0].Tags != `["win"]` {
+		t.Fatalf("expected edited entry with tags, got %+v", m.journalEntries[0])
+	}
+	state, ok = m.modal.JournalSelectState()
+	if !ok || state.Cursor != 0 {
+		t.Fatalf("expected to return to the selection")
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if len(m.journalEntries) != 2 {
+		t.Fatalf("expected delete to be cancelled")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if len(m.journalEntries) != 1 || m.journalEntries[0].Content != "Second" {
+		t.Fatalf("expected first entry deleted, got %+v", m.journalEntries)
+	}
+}
+
+func TestJournalMultiLineViaEditor(t *testing.T) {
+	m := setupTestDashboard(t)
+	var editedPath string
+	orig := editorCommand
+	editorCommand = func(path string) *exec.Cmd {
+		editedPath = path
+		return exec.Command("true")
+	}
+	t.Cleanup(func() { editorCommand = orig })
+
+	m, _, _ = m.handleGoalJournalStart("ctrl+j")
+	m.inputs.journalInput.SetValue("Draft")
+	m, cmd := m.handleModalState(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if cmd == nil || m.modal.IsOpen() {
+		t.Fatalf("expected editor to take over from the journal input")
+	}
+	data, err := os.ReadFile(editedPath)
+	if err != nil || string(data) != "Draft" {
+		t.Fatalf("expected draft in editor file, got %q (%v)", data, err)
+	}
+	if err := os.WriteFile(editedPath, []byte("Line one #deep\nLine two\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	next, _ := m.Update(journalEditedMsg{Path: editedPath, Original: "Draft"})
+	m = next.(DashboardModel)
+	if len(m.journalEntries) != 1 || m.journalEntries[0].Content != "Line one #deep\nLine two" {
+		t.Fatalf("expected multi-line entry, got %+v", m.journalEntries)
+	}
+	m.width = 80
+	pane := m.renderJournalPane()
+	if !strings.Contains(pane, "Line two") {
+		t.Fatalf("expected both lines in the journal pane, got %q", pane)
+	}
+}
+
+func TestJournalBrowserFilterJumpExport(t *testing.T) {
+	m, idA, _, _, _ := setupTwoGoalsInSprint(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	goalID := idA
+	if err := m.db.AddJournalEntry(m.ctx, m.day.ID, wsID, nil, &goalID, "Progress on A #win"); err != nil {
+		t.Fatalf("AddJournalEntry failed: %v", err)
+	}
+	if err := m.db.AddJournalEntry(m.ctx, m.day.ID, wsID, nil, nil, "General\nsecond line"); err != nil {
+		t.Fatalf("AddJournalEntry failed: %v", err)
+	}
+
+	m, _, _ = m.handleJournalBrowser("H")
+	state, ok := m.modal.JournalBrowserState()
+	if !ok || len(state.Results) != 2 {
+		t.Fatalf("expected browser with 2 entries, got %+v", state)
+	}
+	for _, r := range "#win" {
+		m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(state.Results) != 1 || state.Results[0].GoalID == nil {
+		t.Fatalf("expected tag filter to leave the goal entry, got %+v", state.Results)
+	}
+
+	docDir := t.TempDir()
+	t.Setenv("XDG_DOCUMENTS_DIR", docDir)
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if !strings.HasPrefix(m.Message, "Journal exported to ") {
+		t.Fatalf("expected export message, got %q (status %q)", m.Message, m.statusMessage)
+	}
+	data, err := os.ReadFile(strings.TrimPrefix(m.Message, "Journal exported to "))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(data), "## "+m.day.Date) || !strings.Contains(string(data), "Progress on A #win") {
+		t.Fatalf("unexpected export:\n%s", data)
+	}
+
+	m.Message = ""
+	m.view.focusedColIdx = 0
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected browser closed after jump")
+	}
+	goal, ok := m.focusedGoal()
+	if !ok || goal.ID != idA {
+		t.Fatalf("expected focus on goal %d, got %+v (ok=%v)", idA, goal, ok)
+	}
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openLink launches the system opener for a link; tests replace it.
+var openLink = util.OpenLink
+
+func (m DashboardModel) handleGoalLinks(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "o" {
+		return m, nil, false
+	}
+	if !m.validSprintIndex(m.view.focusedColIdx) || len(m.sprints[m.view.focusedColIdx].Goals) <= m.view.focusedGoalIdx {
+		return m, nil, false
+	}
+	target := m.sprints[m.view.focusedColIdx].Goals[m.view.focusedGoalIdx]
+	state := &LinksState{GoalID: target.ID, Description: target.Description, EditIdx: -1}
+	if target.Links != nil {
+		state.Links = util.JSONToLinks(*target.Links)
+	}
+	m.modal.Open(state)
+	if len(state.Links) == 0 {
+		m = m.startLinkEdit(state, -1)
+	}
+	return m, nil, true
+}
+
+// startLinkEdit prompts for a new link (idx -1) or for a replacement of the
+// link at idx.
+func (m DashboardModel) startLinkEdit(state *LinksState, idx int) DashboardModel {
+	state.Editing = true
+	state.EditIdx = idx
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "https://... or /path/to/file"
+	if idx >= 0 && idx < len(state.Links) {
+		m.inputs.textInput.SetValue(state.Links[idx])
+	}
+	m.inputs.textInput.Focus()
+	return m
+}
+
+func (m DashboardModel) handleModalConfirmLinks() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.LinksState()
+	if !ok {
+		return m, nil, false
+	}
+	if !state.Editing {
+		if len(state.Links) == 0 {
+			return m, nil, true
+		}
+		link := state.Links[state.Cursor]
+		if err := openLink(link); err != nil {
+			m.setStatusError(fmt.Sprintf("Error opening link: %v", err))
+		} else {
+			m.Message = "Opened " + link
+		}
+		return m, nil, true
+	}
+	value := strings.TrimSpace(m.inputs.textInput.Value())
+	links := append([]string(nil), state.Links...)
+	switch {
+	case state.EditIdx >= 0 && state.EditIdx < len(links) && value == "":
+		links = append(links[:state.EditIdx], links[state.EditIdx+1:]...)
+	case state.EditIdx >= 0 && state.EditIdx < len(links):
+		links[state.EditIdx] = value
+	case value != "":
+		links = append(links, value)
+	}
+	m = m.saveGoalLinks(state, links, value)
+	state.Editing = false
+	state.EditIdx = -1
+	m.inputs.textInput.Reset()
+	return m, nil, true
+}
+
+// saveGoalLinks stores links for the goal and reloads them in their stored
+// order, moving the cursor to focus when it is among them.
+func (m DashboardModel) saveGoalLinks(state *LinksState, links []string, focus string) DashboardModel {
+	if err := m.db.SetGoalLinks(m.ctx, state.GoalID, links); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving links: %v", err))
+		return m
+	}
+	goal, err := m.db.GetGoalByID(m.ctx, state.GoalID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading links: %v", err))
+		return m
+	}
+	state.Links = nil
+	if goal.Links != nil {
+		state.Links = util.JSONToLinks(*goal.Links)
+	}
+	for i, link := range state.Links {
+		if link == focus {
+			state.Cursor = i
+		}
+	}
+	if state.Cursor >= len(state.Links) {
+		state.Cursor = util.Clamp(len(state.Links)-1, 0, len(state.Links))
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	return m
+}
+
+func (m DashboardModel) handleModalInputLinks(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.LinksState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Editing {
+		var cmd tea.Cmd
+		m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+		return m, cmd, true
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if state.Cursor > 0 {
+			state.Cursor--
+		}
+	case "down", "j":
+		if state.Cursor < len(state.Links)-1 {
+			state.Cursor++
+		}
+	case "a", "n":
+		m = m.startLinkEdit(state, -1)
+	case "e":
+		if len(state.Links) > 0 {
+			m = m.startLinkEdit(state, state.Cursor)
+		}
+	case "d", "x", "backspace":
+		if len(state.Links) > 0 {
+			links := append([]string(nil), state.Links[:state.Cursor]...)
+			links = append(links, state.Links[state.Cursor+1:]...)
+			m = m.saveGoalLinks(state, links, "")
+		}
+	}
+	return m, nil, true
+}
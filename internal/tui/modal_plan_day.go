@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// isFutureDay reports whether date (YYYY-MM-DD) is after today.
+func isFutureDay(date string) bool {
+	return date > time.Now().Format(util.DateLayout)
+}
+
+// isPastDay reports whether date (YYYY-MM-DD) is before today.
+func isPastDay(date string) bool {
+	return date < time.Now().Format(util.DateLayout)
+}
+
+// openPlanDay asks for the sprint count of a day planned ahead of time, or
+// of a past day to backfill, defaulting to the number of sprints of the
+// current day.
+func (m DashboardModel) openPlanDay(date string) DashboardModel {
+	count := 0
+	for _, sprint := range m.sprints {
+		if sprint.SprintNumber > 0 {
+			count++
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+	m.modal.Open(&PlanDayState{Date: date, Backfill: isPastDay(date)})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = "How many sprints?"
+	m.inputs.textInput.SetValue(strconv.Itoa(count))
+	m.inputs.textInput.Focus()
+	return m
+}
+
+func (m DashboardModel) handleModalConfirmPlanDay() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.PlanDayState()
+	if !ok {
+		return m, nil, false
+	}
+	num, err := strconv.Atoi(strings.TrimSpace(m.inputs.textInput.Value()))
+	if err != nil || num < 1 || num > 8 {
+		m.setStatusError("Enter a sprint count between 1 and 8")
+		return m, nil, true
+	}
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	var dayID int64
+	if state.Backfill {
+		dayID, err = m.db.BackfillDay(m.ctx, wsID, state.Date, num)
+	} else {
+		dayID, err = m.db.PlanDay(m.ctx, wsID, state.Date, num)
+	}
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error planning day: %v", err))
+		return m, nil, true
+	}
+	m.refreshData(dayID)
+	m.view.focusedColIdx, m.view.focusedGoalIdx, m.view.colScrollOffset = 0, 0, 0
+	for i, sprint := range m.sprints {
+		if sprint.SprintNumber > 0 && m.onBoard(i) {
+			m.view.focusedColIdx = i
+			break
+		}
+	}
+	m.focusKanbanColumn()
+	if state.Backfill {
+		m.Message = fmt.Sprintf("Backfilled %s with %d sprint(s), press B to record their times", state.Date, num)
+	} else {
+		m.Message = fmt.Sprintf("Planned %s with %d sprint(s)", state.Date, num)
+	}
+	return m, nil, true
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPlanFutureDayAndMoveGoalToTomorrow(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	today := m.day
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(util.DateLayout)
+
+	m.view.focusedColIdx, m.view.focusedGoalIdx = sprintIdx, 0
+	m, _, _ = m.handleGoalMove("m")
+	m, _ = m.handleMoveMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !strings.HasPrefix(m.Message, "Moved #") || !strings.Contains(m.Message, tomorrow) {
+		t.Fatalf("unexpected message %q", m.Message)
+	}
+	if m.modal.Is(ModalGoalMove) {
+		t.Fatalf("expected move modal to close")
+	}
+
+	// Tomorrow now exists, so '>' navigates to it.
+	m, _, _ = handleNormalNextDay(m, ">")
+	if m.day.Date != tomorrow {
+		t.Fatalf("expected to navigate to %s, got %s", tomorrow, m.day.Date)
+	}
+	found := false
+	for _, sprint := range m.sprints {
+		for _, g := range sprint.Goals {
+			found = found || (g.ID == idA && sprint.SprintNumber == 1)
+		}
+	}
+	if !found {
+		t.Fatalf("expected goal #%d in tomorrow's sprint 1", idA)
+	}
+	for i, sprint := range m.sprints {
+		if sprint.SprintNumber == 1 {
+			m.view.focusedColIdx = i
+		}
+	}
+	m, _, _ = m.handleSprintStart("s")
+	if !strings.HasPrefix(m.Message, "Sprint is planned for") {
+		t.Fatalf("expected future sprint start to be refused, got %q", m.Message)
+	}
+
+	// Past the last day, '>' asks for the sprint count of the next one.
+	m, _, _ = handleNormalNextDay(m, ">")
+	state, ok := m.modal.PlanDayState()
+	if !ok {
+		t.Fatalf("expected plan day prompt")
+	}
+	m.inputs.textInput.SetValue("3")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.day.Date != state.Date || !m.day.Planned {
+		t.Fatalf("expected planned day %s, got %+v", state.Date, m.day)
+	}
+	count := 0
+	for _, sprint := range m.sprints {
+		if sprint.SprintNumber > 0 {
+			count++
+		}
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 sprints, got %d", count)
+	}
+	if _, date, err := m.db.GetAdjacentDay(m.ctx, today.ID, 1); err != nil || date != tomorrow {
+		t.Fatalf("expected tomorrow after today, got %s (%v)", date, err)
+	}
+}
@@ -3,64 +3,140 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/akyairhashvil/SSPT/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+const maxRecurrenceInterval = 99
+
+// maxRecurrenceSprint is the highest sprint number new instances can be
+// placed in.
+const maxRecurrenceSprint = 8
+
+// recurrenceBaseRule builds the rule selected in the modal without interval
+// or bounds. The second value explains why the selection is incomplete.
+func recurrenceBaseRule(state *RecurrenceState) (string, string) {
+	switch state.Mode {
+	case "daily":
+		return "daily", ""
+	case "weekly":
+		var days []string
+		for _, d := range state.WeekdayOptions {
+			if state.Selected[d] {
+				days = append(days, d)
+			}
+		}
+		if len(days) == 0 {
+			return "", "Select at least one weekday."
+		}
+		return "weekly:" + strings.Join(days, ","), ""
+	case "monthly":
+		var months []string
+		var days []string
+		for _, mo := range state.MonthOptions {
+			if state.Selected[mo] {
+				months = append(months, mo)
+			}
+		}
+		for _, d := range state.MonthDayOptions {
+			if state.Selected["day:"+d] {
+				days = append(days, d)
+			}
+		}
+		switch {
+		case len(months) == 0:
+			return "", "Select at least one month."
+		case len(days) == 0:
+			return "", "Select at least one day."
+		}
+		return fmt.Sprintf("monthly:months=%s;days=%s", strings.Join(months, ","), strings.Join(days, ",")), ""
+	}
+	return "", ""
+}
+
+func recurrenceMissed(state *RecurrenceState) util.MissedPolicy {
+	if state.Missed == "" {
+		return util.MissedCollapse
+	}
+	return state.Missed
+}
+
+func recurrenceInterval(state *RecurrenceState) int {
+	if state.Interval < 1 {
+		return 1
+	}
+	return state.Interval
+}
+
+// recurrenceMissedPolicies is the cycle order of the missed-occurrence policy.
+var recurrenceMissedPolicies = []util.MissedPolicy{util.MissedCollapse, util.MissedCatchUp, util.MissedSkip}
+
+// recurrenceWithOptions applies the modal's interval, missed policy and
+// sprint to a base rule and keeps the start, end date and count of the rule
+// being edited, which the modal does not expose.
+func recurrenceWithOptions(rule string, state *RecurrenceState) string {
+	if rule == "" {
+		return ""
+	}
+	parsed, err := util.ParseRecurrence(rule)
+	if err != nil {
+		return rule
+	}
+	parsed.Interval = recurrenceInterval(state)
+	if previous, err := util.ParseRecurrence(state.Rule); err == nil {
+		parsed.Start = previous.Start
+		parsed.Until = previous.Until
+		parsed.Count = previous.Count
+	}
+	parsed.Sprint = state.Sprint
+	if state.Missed != util.MissedCollapse {
+		parsed.Missed = state.Missed
+	}
+	return parsed.String()
+}
+
+// recurrenceSprintLabel names where new instances of the rule are placed.
+func recurrenceSprintLabel(state *RecurrenceState) string {
+	if state.Sprint <= 0 {
+		return "backlog"
+	}
+	return fmt.Sprintf("sprint %d", state.Sprint)
+}
+
+// recurrencePreview describes the next occurrence of the rule being edited,
+// counted from today.
+func recurrencePreview(state *RecurrenceState) string {
+	rule, problem := recurrenceBaseRule(state)
+	if problem != "" {
+		return problem
+	}
+	parsed, err := util.ParseRecurrence(recurrenceWithOptions(rule, state))
+	if err != nil {
+		return ""
+	}
+	next, ok := parsed.Next(time.Now(), time.Now())
+	if !ok {
+		return "Series has ended"
+	}
+	return "Next: " + next.Format("Mon 2006-01-02")
+}
+
 func (m DashboardModel) handleModalConfirmRecurrence() (DashboardModel, tea.Cmd, bool) {
 	state, ok := m.modal.RecurrenceState()
 	if !ok {
 		return m, nil, false
 	}
 	if state.GoalID > 0 {
-		rule := state.Mode
-		switch rule {
-		case "none":
-			if err := m.db.UpdateGoalRecurrence(m.ctx, state.GoalID, ""); err != nil {
+		rule, problem := recurrenceBaseRule(state)
+		if problem != "" {
+			m.Message = problem
+		} else {
+			rule = recurrenceWithOptions(rule, state)
+			if err := m.db.UpdateGoalRecurrence(m.ctx, state.GoalID, rule); err != nil {
 				m.setStatusError(fmt.Sprintf("Error saving recurrence: %v", err))
 			}
-		case "daily":
-			if err := m.db.UpdateGoalRecurrence(m.ctx, state.GoalID, "daily"); err != nil {
-				m.setStatusError(fmt.Sprintf("Error saving recurrence: %v", err))
-			}
-		case "weekly":
-			var days []string
-			for _, d := range state.WeekdayOptions {
-				if state.Selected[d] {
-					days = append(days, d)
-				}
-			}
-			if len(days) == 0 {
-				m.Message = "Select at least one weekday."
-			} else {
-				if err := m.db.UpdateGoalRecurrence(m.ctx, state.GoalID, "weekly:"+strings.Join(days, ",")); err != nil {
-					m.setStatusError(fmt.Sprintf("Error saving recurrence: %v", err))
-				}
-			}
-		case "monthly":
-			var months []string
-			var days []string
-			for _, mo := range state.MonthOptions {
-				if state.Selected[mo] {
-					months = append(months, mo)
-				}
-			}
-			for _, d := range state.MonthDayOptions {
-				if state.Selected["day:"+d] {
-					days = append(days, d)
-				}
-			}
-			switch {
-			case len(months) == 0:
-				m.Message = "Select at least one month."
-			case len(days) == 0:
-				m.Message = "Select at least one day."
-			default:
-				rule := fmt.Sprintf("monthly:months=%s;days=%s", strings.Join(months, ","), strings.Join(days, ","))
-				if err := m.db.UpdateGoalRecurrence(m.ctx, state.GoalID, rule); err != nil {
-					m.setStatusError(fmt.Sprintf("Error saving recurrence: %v", err))
-				}
-			}
 		}
 		m.invalidateGoalCache()
 		m.refreshData(m.day.ID)
@@ -117,6 +193,28 @@ func (m DashboardModel) handleModalInputRecurrence(msg tea.Msg) (DashboardModel,
 				}
 			}
 			return m, nil, true
+		case "+", "=":
+			if state.Mode != "none" && state.Interval < maxRecurrenceInterval {
+				state.Interval = recurrenceInterval(state) + 1
+			}
+			return m, nil, true
+		case "-":
+			if state.Interval > 1 {
+				state.Interval--
+			}
+			return m, nil, true
+		case "p":
+			current := 0
+			for i, policy := range recurrenceMissedPolicies {
+				if policy == recurrenceMissed(state) {
+					current = i
+				}
+			}
+			state.Missed = recurrenceMissedPolicies[(current+1)%len(recurrenceMissedPolicies)]
+			return m, nil, true
+		case "n":
+			state.Sprint = (state.Sprint + 1) % (maxRecurrenceSprint + 1)
+			return m, nil, true
 		case "tab":
 			if state.Focus == "items" && state.Mode == "monthly" {
 				state.Focus = "days"
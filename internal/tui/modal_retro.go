@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// retroPromptSetting turns the automatic retrospective prompt off when set
+// to "off"; the r key still opens it, and Ctrl+O there turns it back on.
+const retroPromptSetting = "retro_prompt"
+
+// Retrospective questions, in the order they are asked.
+const (
+	retroFieldFocus = iota
+	retroFieldEnergy
+	retroFieldWentWell
+	retroFieldBlockers
+	retroFieldCount
+)
+
+var retroQuestions = [retroFieldCount]string{
+	"Focus quality (1-5)",
+	"Energy level (1-5)",
+	"What went well?",
+	"What blocked progress?",
+}
+
+func (m DashboardModel) retroPromptEnabled() bool {
+	value, ok := m.db.GetSetting(m.ctx, retroPromptSetting)
+	return !ok || value != "off"
+}
+
+// toggleRetroPrompt switches the automatic prompt off, closing the retro, or
+// back on from a retro opened with r.
+func (m DashboardModel) toggleRetroPrompt() DashboardModel {
+	value := "off"
+	if !m.retroPromptEnabled() {
+		value = "on"
+	}
+	if err := m.db.SetSetting(m.ctx, retroPromptSetting, value); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving setting: %v", err))
+		return m
+	}
+	if value == "on" {
+		m.Message = "Retrospective prompt turned on, it opens when a sprint completes"
+		return m
+	}
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	m.Message = "Retrospective prompt turned off, press r on a completed sprint to add one"
+	return m
+}
+
+// openRetro starts a retrospective for sprint, prefilled with any answers
+// already recorded.
+func (m DashboardModel) openRetro(sprint models.Sprint) DashboardModel {
+	state := &RetroState{SprintID: sprint.ID, SprintNumber: sprint.SprintNumber}
+	if sprint.Retro != nil {
+		state.Retro = *sprint.Retro
+	}
+	m.modal.Open(state)
+	m.showAnalytics = false
+	m.showDetails = false
+	return m.setRetroField(state, retroFieldFocus)
+}
+
+func (m DashboardModel) handleSprintRetro(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "r" {
+		return m, nil, false
+	}
+	if !m.validSprintIndex(m.view.focusedColIdx) {
+		return m, nil, true
+	}
+	sprint := m.sprints[m.view.focusedColIdx]
+	if sprint.SprintNumber <= 0 || sprint.Status != models.StatusCompleted {
+		m.Message = "Retrospectives are available for completed sprints"
+		return m, nil, true
+	}
+	return m.openRetro(sprint.Sprint), nil, true
+}
+
+// setRetroField moves to field, loading its current answer into the text
+// input for the free-text questions.
+func (m DashboardModel) setRetroField(state *RetroState, field int) DashboardModel {
+	state.Field = field
+	m.inputs.textInput.Reset()
+	switch field {
+	case retroFieldWentWell:
+		m.inputs.textInput.Placeholder = "What went well"
+		m.inputs.textInput.SetValue(state.Retro.WentWell)
+		m.inputs.textInput.Focus()
+	case retroFieldBlockers:
+		m.inputs.textInput.Placeholder = "What blocked progress"
+		m.inputs.textInput.SetValue(state.Retro.Blockers)
+		m.inputs.textInput.Focus()
+	default:
+		m.inputs.textInput.Blur()
+	}
+	return m
+}
+
+// storeRetroField copies the text input into the current free-text answer.
+func (m DashboardModel) storeRetroField(state *RetroState) {
+	value := strings.TrimSpace(m.inputs.textInput.Value())
+	switch state.Field {
+	case retroFieldWentWell:
+		state.Retro.WentWell = value
+	case retroFieldBlockers:
+		state.Retro.Blockers = value
+	}
+}
+
+func (m DashboardModel) handleModalConfirmRetro() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.RetroState()
+	if !ok {
+		return m, nil, false
+	}
+	m.storeRetroField(state)
+	if (state.Field == retroFieldFocus && state.Retro.FocusQuality == 0) ||
+		(state.Field == retroFieldEnergy && state.Retro.Energy == 0) {
+		m.Message = "Rate from 1 to 5"
+		return m, nil, true
+	}
+	if state.Field < retroFieldCount-1 {
+		return m.setRetroField(state, state.Field+1), nil, true
+	}
+	return m.saveRetro(state), nil, true
+}
+
+func (m DashboardModel) saveRetro(state *RetroState) DashboardModel {
+	if state.Retro.FocusQuality == 0 {
+		return m.setRetroField(state, retroFieldFocus)
+	}
+	if state.Retro.Energy == 0 {
+		return m.setRetroField(state, retroFieldEnergy)
+	}
+	if err := m.db.SaveSprintRetro(m.ctx, state.SprintID, state.Retro); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving retrospective: %v", err))
+		return m
+	}
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	m.Message = fmt.Sprintf("Saved retrospective for sprint %d", state.SprintNumber)
+	m.refreshData(m.day.ID)
+	return m
+}
+
+func (m DashboardModel) handleModalInputRetro(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.RetroState()
+	if !ok {
+		return m, nil, false
+	}
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey {
+		switch keyMsg.String() {
+		case "tab", "down":
+			m.storeRetroField(state)
+			return m.setRetroField(state, (state.Field+1)%retroFieldCount), nil, true
+		case "shift+tab", "up":
+			m.storeRetroField(state)
+			return m.setRetroField(state, (state.Field+retroFieldCount-1)%retroFieldCount), nil, true
+		case "ctrl+s":
+			m.storeRetroField(state)
+			return m.saveRetro(state), nil, true
+		case "ctrl+o":
+			return m.toggleRetroPrompt(), nil, true
+		}
+	}
+	if state.Field == retroFieldFocus || state.Field == retroFieldEnergy {
+		if !isKey {
+			return m, nil, true
+		}
+		n, err := strconv.Atoi(keyMsg.String())
+		if err != nil || n < 1 || n > 5 {
+			return m, nil, true
+		}
+		if state.Field == retroFieldFocus {
+			state.Retro.FocusQuality = n
+		} else {
+			state.Retro.Energy = n
+		}
+		return m.setRetroField(state, state.Field+1), nil, true
+	}
+	var cmd tea.Cmd
+	m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+	return m, cmd, true
+}
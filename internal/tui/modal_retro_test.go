@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSprintRetroPromptFlow(t *testing.T) {
+	m, _, _, sprintID, sprintIdx := setupTwoGoalsInSprint(t)
+	if err := m.db.StartSprint(m.ctx, sprintID); err != nil {
+		t.Fatalf("StartSprint failed: %v", err)
+	}
+	m.refreshData(m.day.ID)
+	m.timer.ActiveSprint = &m.sprints[sprintIdx]
+
+	m, _ = m.handleSprintCompletion()
+	state, ok := m.modal.RetroState()
+	if !ok || state.SprintID != sprintID {
+		t.Fatalf("expected retro prompt for the completed sprint, got %+v", m.modal.Current())
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if state.Field != retroFieldFocus {
+		t.Fatalf("expected Enter without a rating to stay on focus")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if state.Retro.FocusQuality != 4 || state.Retro.Energy != 3 || state.Field != retroFieldWentWell {
+		t.Fatalf("expected ratings recorded, got %+v", state)
+	}
+	m.inputs.textInput.SetValue("Shipped the parser")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	m.inputs.textInput.SetValue("Meetings")
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.IsOpen() {
+		t.Fatalf("expected retro closed after the last question")
+	}
+
+	retro := m.sprints[sprintIdx].Retro
+	if retro == nil || retro.FocusQuality != 4 || retro.WentWell != "Shipped the parser" || retro.Blockers != "Meetings" {
+		t.Fatalf("expected retro stored on the sprint, got %+v", retro)
+	}
+	if len(m.journalEntries) != 1 || !strings.Contains(m.journalEntries[0].Content, "#retro") {
+		t.Fatalf("expected linked retro journal entry, got %+v", m.journalEntries)
+	}
+
+	docDir := t.TempDir()
+	t.Setenv("XDG_DOCUMENTS_DIR", docDir)
+	path, err := GenerateReport(m.ctx, m.db, m.day.ID, m.workspaces[m.activeWorkspaceIdx].ID)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read report failed: %v", err)
+	}
+	if !strings.Contains(string(data), "**Retro:** focus 4/5, energy 3/5") {
+		t.Fatalf("expected retro in report, got: %s", data)
+	}
+
+	// Reopening with r prefills the answers; Ctrl+O turns the prompt off.
+	m.view.focusedColIdx = sprintIdx
+	m, _, _ = m.handleSprintRetro("r")
+	if state, ok := m.modal.RetroState(); !ok || state.Retro.Energy != 3 {
+		t.Fatalf("expected prefilled retro, got %+v", m.modal.Current())
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.modal.IsOpen() || m.retroPromptEnabled() {
+		t.Fatalf("expected prompt disabled and modal closed")
+	}
+
+	// A retro opened with r offers Ctrl+O to turn the prompt back on.
+	m, _, _ = m.handleSprintRetro("r")
+	m.Message = ""
+	if footer := m.renderFooter(); !strings.Contains(footer, "[Ctrl+O] Ask after each sprint") {
+		t.Fatalf("expected footer to offer turning the prompt on, got %q", footer)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !m.modal.IsOpen() || !m.retroPromptEnabled() {
+		t.Fatalf("expected prompt enabled with the retro still open")
+	}
+	m.Message = ""
+	if footer := m.renderFooter(); !strings.Contains(footer, "[Ctrl+O] Don't ask again") {
+		t.Fatalf("expected footer to offer turning the prompt off, got %q", footer)
+	}
+}
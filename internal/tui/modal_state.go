@@ -1,6 +1,11 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type ModalType int
 
@@ -20,6 +25,21 @@ const (
 	ModalPassphrase
 	ModalSearch
 	ModalClearDB
+	ModalTemplate
+	ModalCarryOver
+	ModalLinks
+	ModalJournalSelect
+	ModalJournalBrowser
+	ModalRetro
+	ModalDepGraph
+	ModalAutoPlan
+	ModalWorkflow
+	ModalWeek
+	ModalPlanDay
+	ModalCorrection
+	ModalAnalytics
+	ModalCSVImport
+	ModalCalendarFile
 )
 
 type ModalState interface {
@@ -54,7 +74,10 @@ func (s *GoalDeleteState) HandleKey(key string) (ModalState, tea.Cmd) {
 	return s, nil
 }
 
-type GoalMoveState struct{}
+// GoalMoveState moves the focused goal; Dating asks for a target date.
+type GoalMoveState struct {
+	Dating bool
+}
 
 func (s *GoalMoveState) Type() ModalType { return ModalGoalMove }
 func (s *GoalMoveState) HandleKey(key string) (ModalState, tea.Cmd) {
@@ -121,6 +144,10 @@ type RecurrenceState struct {
 	ItemCursor      int
 	DayCursor       int
 	MonthDayOptions []string
+	Interval        int
+	Missed          util.MissedPolicy
+	Sprint          int // sprint new instances are placed in, 0 = backlog
+	Rule            string
 }
 
 func (s *RecurrenceState) Type() ModalType { return ModalRecurrence }
@@ -129,10 +156,233 @@ func (s *RecurrenceState) HandleKey(key string) (ModalState, tea.Cmd) {
 }
 
 type JournalState struct {
-	GoalID int64
+	GoalID       int64
+	EntryID      int64 // entry being edited, 0 for a new entry
+	ReturnCursor int   // journal selection to return to after an edit
 }
 
 func (s *JournalState) Type() ModalType { return ModalJournaling }
 func (s *JournalState) HandleKey(key string) (ModalState, tea.Cmd) {
 	return s, nil
 }
+
+type TemplateState struct {
+	Templates []database.GoalTemplate
+	Cursor    int
+}
+
+func (s *TemplateState) Type() ModalType { return ModalTemplate }
+func (s *TemplateState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+type CarryOverState struct {
+	Goals     []models.Goal
+	Index     int
+	Cursor    int
+	Deferring bool
+}
+
+func (s *CarryOverState) Type() ModalType { return ModalCarryOver }
+func (s *CarryOverState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+type LinksState struct {
+	GoalID      int64
+	Description string
+	Links       []string
+	Cursor      int
+	Editing     bool
+	EditIdx     int // link being edited, -1 when adding
+}
+
+func (s *LinksState) Type() ModalType { return ModalLinks }
+func (s *LinksState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+type JournalSelectState struct {
+	Cursor        int
+	ConfirmDelete bool
+}
+
+func (s *JournalSelectState) Type() ModalType { return ModalJournalSelect }
+func (s *JournalSelectState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+type JournalBrowserState struct {
+	Results []database.JournalRecord
+	Cursor  int
+}
+
+func (s *JournalBrowserState) Type() ModalType { return ModalJournalBrowser }
+func (s *JournalBrowserState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// RetroState walks through the retrospective questions for a sprint; Field
+// is the question being answered (see retroField).
+type RetroState struct {
+	SprintID     int64
+	SprintNumber int
+	Field        int
+	Retro        models.SprintRetro
+}
+
+func (s *RetroState) Type() ModalType { return ModalRetro }
+func (s *RetroState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// DepGraphState shows the dependency graph of FocusID, or of the whole
+// workspace when FocusID is 0.
+type DepGraphState struct {
+	FocusID int64
+	Graph   depGraph
+	Cursor  int
+}
+
+func (s *DepGraphState) Type() ModalType { return ModalDepGraph }
+func (s *DepGraphState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// AutoPlanState previews an auto-plan; Cursor selects the item to tweak.
+type AutoPlanState struct {
+	Plan   autoPlan
+	Cursor int
+}
+
+func (s *AutoPlanState) Type() ModalType { return ModalAutoPlan }
+func (s *AutoPlanState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// WeekState shows the seven days from Start as rows of sprint cells. Row
+// and Col select a cell, Goals lists its open goals with GoalIdx selected,
+// and Carry holds a goal picked up to move to another cell.
+type WeekState struct {
+	Start   string
+	Days    []database.DaySummary
+	Row     int
+	Col     int
+	Goals   []models.Goal
+	GoalIdx int
+	Carry   *models.Goal
+}
+
+func (s *WeekState) Type() ModalType { return ModalWeek }
+func (s *WeekState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// PlanDayState asks for the sprint count of a day planned ahead of time, or
+// of a past day being backfilled.
+type PlanDayState struct {
+	Date     string
+	Backfill bool
+}
+
+func (s *PlanDayState) Type() ModalType { return ModalPlanDay }
+func (s *PlanDayState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// analyticsInput is the text field being typed in the analytics view.
+type analyticsInput int
+
+const (
+	analyticsInputNone analyticsInput = iota
+	analyticsInputRange
+	analyticsInputTag
+)
+
+// AnalyticsState shows the workspace analytics of the Days days up to today,
+// or of the custom range From to To when Days is 0. The heatmap covers the
+// last year of the workspaces not in Hidden; WorkspaceIdx selects the
+// workspace to toggle. Forecast covers the backlog goals tagged ForecastTag,
+// or the whole backlog when it is empty. With ShowTime, the view shows the
+// task timer time of the range and shown workspaces grouped by TimeGroup.
+type AnalyticsState struct {
+	Days         int
+	From         string
+	To           string
+	Data         database.Analytics
+	Editing      analyticsInput
+	Heatmap      []database.HeatmapDay
+	Metric       HeatmapMetric
+	Hidden       map[int64]bool
+	WorkspaceIdx int
+	Forecast     Forecast
+	ForecastTag  string
+	ShowTime     bool
+	TimeGroup    database.TimeGroup
+	Time         database.TimeReport
+}
+
+func (s *AnalyticsState) Type() ModalType { return ModalAnalytics }
+func (s *AnalyticsState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// CorrectionState asks for the times of a past sprint (SprintID) or the
+// completion time of a goal (GoalID).
+type CorrectionState struct {
+	SprintID     int64
+	SprintNumber int
+	GoalID       int64
+}
+
+func (s *CorrectionState) Type() ModalType { return ModalCorrection }
+func (s *CorrectionState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// WorkflowState edits the goal status workflow of a workspace.
+type WorkflowState struct {
+	WorkspaceID int64
+}
+
+func (s *WorkflowState) Type() ModalType { return ModalWorkflow }
+func (s *WorkflowState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// csvImportStep is the stage of a CSV import.
+type csvImportStep int
+
+const (
+	csvImportPath csvImportStep = iota
+	csvImportMapping
+	csvImportPreview
+)
+
+// CSVImportState walks through a CSV import: the file path, the column
+// each goal field is read from (FieldIdx selects the field), and a preview
+// of the rows scrolled to Offset.
+type CSVImportState struct {
+	Step     csvImportStep
+	Path     string
+	Import   *CSVImport
+	FieldIdx int
+	Preview  []CSVPreviewRow
+	Offset   int
+}
+
+func (s *CSVImportState) Type() ModalType { return ModalCSVImport }
+func (s *CSVImportState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// CalendarFileState edits the path of the local .ics file meetings are read
+// from; Path is the one saved when the modal opened.
+type CalendarFileState struct {
+	Path string
+}
+
+func (s *CalendarFileState) Type() ModalType { return ModalCalendarFile }
+func (s *CalendarFileState) HandleKey(key string) (ModalState, tea.Cmd) {
+	return s, nil
+}
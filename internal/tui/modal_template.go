@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openTemplatePicker lists the templates whose name contains the text typed
+// so far in the create modal.
+func (m DashboardModel) openTemplatePicker() (DashboardModel, tea.Cmd, bool) {
+	templates, err := m.db.GetGoalTemplates(m.ctx)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading templates: %v", err))
+		return m, nil, true
+	}
+	filter := strings.ToLower(strings.TrimSpace(m.inputs.textInput.Value()))
+	var matches []database.GoalTemplate
+	for _, tpl := range templates {
+		if filter == "" || strings.Contains(strings.ToLower(tpl.Name), filter) {
+			matches = append(matches, tpl)
+		}
+	}
+	m.modal.Open(&TemplateState{Templates: matches})
+	m.inputs.textInput.Reset()
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalConfirmTemplate() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.TemplateState()
+	if !ok {
+		return m, nil, false
+	}
+	if state.Cursor < len(state.Templates) && len(m.sprints) > 0 {
+		tpl := state.Templates[state.Cursor]
+		at, err := time.ParseInLocation(util.DateLayout, m.day.Date, time.Local)
+		if err != nil {
+			at = time.Now()
+		}
+		sprintID := m.sprints[m.view.focusedColIdx].ID
+		if _, err := m.db.InstantiateGoalTemplate(m.ctx, tpl, m.workspaces[m.activeWorkspaceIdx].ID, sprintID, at); err != nil {
+			m.setStatusError(fmt.Sprintf("Error creating from template: %v", err))
+		} else {
+			m.Message = fmt.Sprintf("Created %d goal(s) from %q", tpl.Goal.Count(), tpl.Name)
+		}
+		m.invalidateGoalCache()
+		m.refreshData(m.day.ID)
+	}
+	m.modal.Close()
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputTemplate(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	if state, ok := m.modal.GoalCreateState(); ok {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+t" && state.ParentID == 0 {
+			return m.openTemplatePicker()
+		}
+		return m, nil, false
+	}
+	state, ok := m.modal.TemplateState()
+	if !ok {
+		return m, nil, false
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up", "k":
+			if state.Cursor > 0 {
+				state.Cursor--
+			}
+		case "down", "j":
+			if state.Cursor < len(state.Templates)-1 {
+				state.Cursor++
+			}
+		}
+	}
+	return m, nil, true
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// weekStart returns the Monday of the week containing date (YYYY-MM-DD).
+func weekStart(date string) string {
+	day, err := time.Parse(util.DateLayout, date)
+	if err != nil {
+		day = time.Now()
+	}
+	return day.AddDate(0, 0, -weekdayIndex(day)).Format(util.DateLayout)
+}
+
+// weekdayIndex numbers weekdays from Monday (0) to Sunday (6).
+func weekdayIndex(day time.Time) int {
+	return (int(day.Weekday()) + 6) % 7
+}
+
+// weekColumns is the number of sprint columns in the week grid: the most
+// sprints any day of the week has.
+func weekColumns(days []database.DaySummary) int {
+	n := 0
+	for _, day := range days {
+		if len(day.Sprints) > n {
+			n = len(day.Sprints)
+		}
+	}
+	return n
+}
+
+// weekCell returns the sprint in the selected cell, if the day has one.
+func (s *WeekState) weekCell() (database.SprintSummary, bool) {
+	if s.Row >= len(s.Days) || s.Col >= len(s.Days[s.Row].Sprints) {
+		return database.SprintSummary{}, false
+	}
+	return s.Days[s.Row].Sprints[s.Col], true
+}
+
+func (m DashboardModel) handleWeekView(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "ctrl+w" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	state := &WeekState{Start: weekStart(m.day.Date)}
+	if day, err := time.Parse(util.DateLayout, m.day.Date); err == nil {
+		state.Row = weekdayIndex(day)
+	}
+	if m.validSprintIndex(m.view.focusedColIdx) && m.sprints[m.view.focusedColIdx].SprintNumber > 0 {
+		state.Col = m.sprints[m.view.focusedColIdx].SprintNumber - 1
+	}
+	m = m.loadWeek(state)
+	m.modal.Open(state)
+	m.showAnalytics = false
+	m.showDetails = false
+	return m, nil, true
+}
+
+// loadWeek reloads the week and the open goals of the selected cell.
+func (m DashboardModel) loadWeek(state *WeekState) DashboardModel {
+	days, err := m.db.GetWeekSummary(m.ctx, m.workspaces[m.activeWorkspaceIdx].ID, state.Start)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading week: %v", err))
+		return m
+	}
+	state.Days = days
+	if cols := weekColumns(days); state.Col >= cols {
+		state.Col = cols - 1
+	}
+	if state.Col < 0 {
+		state.Col = 0
+	}
+	return m.loadWeekCell(state)
+}
+
+func (m DashboardModel) loadWeekCell(state *WeekState) DashboardModel {
+	state.Goals, state.GoalIdx = nil, 0
+	cell, ok := state.weekCell()
+	if !ok {
+		return m
+	}
+	goals, err := m.db.GetGoalsForSprint(m.ctx, cell.ID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading goals: %v", err))
+		return m
+	}
+	for _, g := range goals {
+		if g.ParentID == nil && g.Status != models.GoalStatusCompleted {
+			state.Goals = append(state.Goals, g)
+		}
+	}
+	return m
+}
+
+// handleModalConfirmWeek opens the selected day on the board.
+func (m DashboardModel) handleModalConfirmWeek() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.WeekState()
+	if !ok {
+		return m, nil, false
+	}
+	day := state.Days[state.Row]
+	if day.DayID == 0 {
+		m.Message = fmt.Sprintf("No record for %s", day.Date)
+		return m, nil, true
+	}
+	cell, hasCell := state.weekCell()
+	m.modal.Close()
+	m.refreshData(day.DayID)
+	m.view.focusedGoalIdx = 0
+	if hasCell {
+		for i, sprint := range m.sprints {
+			if sprint.ID == cell.ID && m.onBoard(i) {
+				m.view.focusedColIdx = i
+			}
+		}
+	}
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalInputWeek(msg tea.Msg) (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.WeekState()
+	if !ok {
+		return m, nil, false
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, true
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if state.Row > 0 {
+			state.Row--
+			m = m.loadWeekCell(state)
+		}
+	case "down", "j":
+		if state.Row < len(state.Days)-1 {
+			state.Row++
+			m = m.loadWeekCell(state)
+		}
+	case "left", "h":
+		if state.Col > 0 {
+			state.Col--
+			m = m.loadWeekCell(state)
+		}
+	case "right", "l":
+		if state.Col < weekColumns(state.Days)-1 {
+			state.Col++
+			m = m.loadWeekCell(state)
+		}
+	case "[", "]":
+		start, _ := time.Parse(util.DateLayout, state.Start)
+		if keyMsg.String() == "[" {
+			start = start.AddDate(0, 0, -7)
+		} else {
+			start = start.AddDate(0, 0, 7)
+		}
+		state.Start = start.Format(util.DateLayout)
+		m = m.loadWeek(state)
+	case "i":
+		return m.exportWeekICS(state), nil, true
+	case "p":
+		date := state.Days[state.Row].Date
+		if !isFutureDay(date) && !isPastDay(date) {
+			m.Message = "Only future days can be planned or past days backfilled"
+			return m, nil, true
+		}
+		return m.openPlanDay(date), nil, true
+	case "tab":
+		if len(state.Goals) > 0 {
+			state.GoalIdx = (state.GoalIdx + 1) % len(state.Goals)
+		}
+	case " ":
+		if state.Carry == nil {
+			if state.GoalIdx >= len(state.Goals) {
+				m.Message = "No open goal in this sprint"
+				return m, nil, true
+			}
+			goal := state.Goals[state.GoalIdx]
+			state.Carry = &goal
+			m.Message = fmt.Sprintf("Moving #%d: choose a sprint and press Space", goal.ID)
+			return m, nil, true
+		}
+		return m.dropWeekGoal(state), nil, true
+	}
+	return m, nil, true
+}
+
+// exportWeekICS writes the shown week of the active workspace as a calendar.
+func (m DashboardModel) exportWeekICS(state *WeekState) DashboardModel {
+	start, err := time.Parse(util.DateLayout, state.Start)
+	if err != nil {
+		return m
+	}
+	ws := m.workspaces[m.activeWorkspaceIdx]
+	opts := ICSOptions{WorkspaceID: ws.ID, From: state.Start, To: start.AddDate(0, 0, 6).Format(util.DateLayout)}
+	path, err := ExportICS(m.ctx, m.db, opts, ws.Slug)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Calendar export failed: %v", err))
+		return m
+	}
+	m.Message = fmt.Sprintf("Calendar exported to %s", path)
+	return m
+}
+
+// dropWeekGoal moves the carried goal into the selected sprint.
+func (m DashboardModel) dropWeekGoal(state *WeekState) DashboardModel {
+	cell, ok := state.weekCell()
+	if !ok {
+		m.Message = "No sprint here"
+		return m
+	}
+	if cell.Status == models.StatusCompleted {
+		m.Message = "Sprint already completed"
+		return m
+	}
+	if isPastDay(state.Days[state.Row].Date) {
+		m.Message = "Goals cannot be moved to a past day"
+		return m
+	}
+	goal := *state.Carry
+	if err := m.db.MoveGoal(m.ctx, goal.ID, cell.ID); err != nil {
+		m.setStatusError(fmt.Sprintf("Error moving goal: %v", err))
+		return m
+	}
+	state.Carry = nil
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	m = m.loadWeek(state)
+	for i, g := range state.Goals {
+		if g.ID == goal.ID {
+			state.GoalIdx = i
+		}
+	}
+	m.Message = fmt.Sprintf("Moved #%d to %s sprint %d", goal.ID, state.Days[state.Row].Date, cell.SprintNumber)
+	return m
+}
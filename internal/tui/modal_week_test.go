@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWeekViewMovesGoalBetweenSprints(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	if err := m.db.AppendSprint(m.ctx, m.day.ID, wsID); err != nil {
+		t.Fatalf("AppendSprint failed: %v", err)
+	}
+	m.refreshData(m.day.ID)
+	m.view.focusedColIdx = sprintIdx
+
+	m, _, _ = m.handleWeekView("ctrl+w")
+	state, ok := m.modal.WeekState()
+	if !ok {
+		t.Fatalf("expected week view")
+	}
+	if state.Days[state.Row].Date != m.day.Date || state.Col != 0 {
+		t.Fatalf("expected today's first sprint selected, got row %d col %d", state.Row, state.Col)
+	}
+	if len(state.Goals) != 2 || state.Goals[0].ID != idA {
+		t.Fatalf("expected both goals in the cell, got %+v", state.Goals)
+	}
+	m.width, m.height = 120, 40
+	if view := m.renderWeek(state); !strings.Contains(view, "Week of "+state.Start) || !strings.Contains(view, "0/2") {
+		t.Fatalf("unexpected week view:\n%s", view)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if state.Carry == nil || state.Carry.ID != idA {
+		t.Fatalf("expected goal A to be picked up")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if state.Carry != nil {
+		t.Fatalf("expected goal to be dropped")
+	}
+	goal, err := m.db.GetGoalByID(m.ctx, idA)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if target := state.Days[state.Row].Sprints[1]; goal.SprintID == nil || *goal.SprintID != target.ID || target.Total != 1 {
+		t.Fatalf("expected goal A in sprint 2, got %v", goal.SprintID)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.modal.Is(ModalWeek) {
+		t.Fatalf("expected week view to close")
+	}
+	if m.sprints[m.view.focusedColIdx].SprintNumber != 2 {
+		t.Fatalf("expected sprint 2 focused, got %d", m.sprints[m.view.focusedColIdx].SprintNumber)
+	}
+}
+
+func TestWeekViewRejectsPastDayAndCancelsMove(t *testing.T) {
+	m, idA, _, sprintID, sprintIdx := setupTwoGoalsInSprint(t)
+	wsID := m.workspaces[m.activeWorkspaceIdx].ID
+	yesterday := time.Now().AddDate(0, 0, -1).Format(util.DateLayout)
+	if _, err := m.db.BackfillDay(m.ctx, wsID, yesterday, 1); err != nil {
+		t.Fatalf("BackfillDay failed: %v", err)
+	}
+	m.view.focusedColIdx = sprintIdx
+
+	m, _, _ = m.handleWeekView("ctrl+w")
+	state, ok := m.modal.WeekState()
+	if !ok {
+		t.Fatalf("expected week view")
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if state.Carry == nil || state.Carry.ID != idA {
+		t.Fatalf("expected goal A to be picked up")
+	}
+	if state.Row == 0 {
+		m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+		state.Row = len(state.Days) - 1
+	} else {
+		state.Row--
+	}
+	if state.Days[state.Row].Date != yesterday {
+		t.Fatalf("expected yesterday selected, got %s", state.Days[state.Row].Date)
+	}
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if state.Carry == nil || m.Message != "Goals cannot be moved to a past day" {
+		t.Fatalf("expected the drop on a past day to be refused, got %q", m.Message)
+	}
+	if goal, err := m.db.GetGoalByID(m.ctx, idA); err != nil || goal.SprintID == nil || *goal.SprintID != sprintID {
+		t.Fatalf("expected goal A to stay in its sprint, got %+v err %v", goal, err)
+	}
+
+	m, _ = m.handleModalState(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.modal.Is(ModalWeek) || state.Carry != nil || m.Message != "Move cancelled" {
+		t.Fatalf("expected Esc to cancel the move and keep the week view, got %q", m.Message)
+	}
+	m.Message = ""
+	m.width, m.height = 120, 40
+	if footer := m.renderFooter(); !strings.Contains(footer, "[Space] Pick up") {
+		t.Fatalf("expected the browsing footer after cancelling, got %q", footer)
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akyairhashvil/SSPT/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// workflow returns the goal status workflow of the active workspace.
+func (m DashboardModel) workflow() models.Workflow {
+	if len(m.workspaces) == 0 || len(m.workspaces[m.activeWorkspaceIdx].Workflow) == 0 {
+		return models.DefaultWorkflow
+	}
+	return m.workspaces[m.activeWorkspaceIdx].Workflow
+}
+
+func formatWorkflow(wf models.Workflow) string {
+	labels := make([]string, len(wf))
+	for i, status := range wf {
+		labels[i] = status.Label()
+	}
+	return strings.Join(labels, " → ")
+}
+
+func (m DashboardModel) handleWorkspaceWorkflow(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "F" {
+		return m, nil, false
+	}
+	if len(m.workspaces) == 0 {
+		return m, nil, true
+	}
+	m.modal.Open(&WorkflowState{WorkspaceID: m.workspaces[m.activeWorkspaceIdx].ID})
+	m.inputs.textInput.Reset()
+	m.inputs.textInput.Placeholder = models.DefaultWorkflow.String()
+	m.inputs.textInput.SetValue(m.workflow().String())
+	m.inputs.textInput.Focus()
+	return m, nil, true
+}
+
+func (m DashboardModel) handleModalConfirmWorkflow() (DashboardModel, tea.Cmd, bool) {
+	state, ok := m.modal.WorkflowState()
+	if !ok {
+		return m, nil, false
+	}
+	raw := m.inputs.textInput.Value()
+	m.modal.Close()
+	m.inputs.textInput.Reset()
+	wf, err := models.ParseWorkflow(raw)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Invalid workflow: %v", err))
+		return m, nil, true
+	}
+	if err := m.db.UpdateWorkspaceWorkflow(m.ctx, state.WorkspaceID, wf); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving workflow: %v", err))
+		return m, nil, true
+	}
+	if err := m.loadWorkspaces(); err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading workspaces: %v", err))
+		return m, nil, true
+	}
+	m.refreshData(m.day.ID)
+	m.Message = "Workflow: " + formatWorkflow(wf)
+	return m, nil, true
+}
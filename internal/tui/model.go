@@ -4,6 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,6 +33,13 @@ type MainModel struct {
 	err       error
 	width     int // Store window dimensions
 	height    int
+
+	dayTemplates []database.DayTemplate
+	templateIdx  int // selected day template, -1 for none
+
+	meetings  []util.ICalEvent // today's meetings from the calendar file
+	suggested int              // sprints that fit today around the meetings
+	notice    string           // shown on the sprint prompt, e.g. a failed auto template
 }
 
 func NewMainModel(ctx context.Context, db Database) MainModel {
@@ -47,12 +60,52 @@ func NewMainModel(ctx context.Context, db Database) MainModel {
 	}
 
 	if dayID > 0 {
+		if _, err := db.MaterializeRecurringGoals(ctx, time.Now().Format(util.DateLayout)); err != nil {
+			util.LogError("materialize recurring goals", err)
+		}
+		// A day planned ahead keeps its sprints and starts like a new day.
+		started, err := db.StartPlannedDay(ctx, dayID)
+		if err != nil {
+			util.LogError("start planned day", err)
+		}
 		m.state = StateDashboard
 		m.dashboard = NewDashboardModel(ctx, db, dayID, ResolveTheme("default")) // Load existing day
+		if started {
+			m.dashboard = m.dashboard.openCarryOverReview(true)
+		}
 	} else {
+		templates, err := db.GetDayTemplates(ctx)
+		if err != nil {
+			util.LogError("load day templates", err)
+		}
+		m.dayTemplates = templates
+		m.templateIdx = -1
+		today := time.Now()
+		for i, tpl := range templates {
+			if tpl.Matches(today) {
+				m.templateIdx = i
+				break
+			}
+		}
+		if m.templateIdx >= 0 && templates[m.templateIdx].Auto {
+			next, ok := m.applyDayTemplate(templates[m.templateIdx])
+			if ok {
+				return next
+			}
+			m = m.dayTemplateFailed(templates[m.templateIdx], next.err)
+		}
+		meetings, err := LoadMeetings(ctx, db, today.Format(util.DateLayout))
+		if err != nil {
+			util.LogError("load calendar file", err)
+		}
+		m.meetings = meetings
+		m.suggested = SuggestSprintCount(today.Format(util.DateLayout), meetings, today)
 		m.state = StateInitializing
 		ti := textinput.New()
 		ti.Placeholder = "1-8"
+		if m.suggested > 0 {
+			ti.Placeholder = strconv.Itoa(m.suggested)
+		}
 		ti.Focus()
 		ti.CharLimit = 1
 		ti.Width = 10
@@ -116,8 +169,37 @@ func (m MainModel) updateInitializing(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.Type {
+		case tea.KeyUp, tea.KeyShiftTab:
+			if len(m.dayTemplates) > 0 {
+				m.templateIdx--
+				if m.templateIdx < -1 {
+					m.templateIdx = len(m.dayTemplates) - 1
+				}
+			}
+			return m, nil
+		case tea.KeyDown, tea.KeyTab:
+			if len(m.dayTemplates) > 0 {
+				m.templateIdx++
+				if m.templateIdx >= len(m.dayTemplates) {
+					m.templateIdx = -1
+				}
+			}
+			return m, nil
 		case tea.KeyEnter:
 			val := m.textInput.Value()
+			if strings.TrimSpace(val) == "" && m.templateIdx >= 0 && m.templateIdx < len(m.dayTemplates) {
+				tpl := m.dayTemplates[m.templateIdx]
+				next, ok := m.applyDayTemplate(tpl)
+				if !ok {
+					return m.dayTemplateFailed(tpl, next.err), nil
+				}
+				next.dashboard.width = m.width
+				next.dashboard.height = m.height
+				return next, next.dashboard.Init()
+			}
+			if strings.TrimSpace(val) == "" && m.suggested > 0 {
+				val = strconv.Itoa(m.suggested)
+			}
 			numSprints, err := strconv.Atoi(val)
 			if err != nil || numSprints < 1 || numSprints > 8 {
 				m.err = fmt.Errorf("please enter a valid number between 1 and 8")
@@ -138,6 +220,7 @@ func (m MainModel) updateInitializing(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Transition state
 			m.state = StateDashboard
 			m.dashboard = NewDashboardModel(m.ctx, m.db, m.db.CheckCurrentDay(m.ctx), ResolveTheme("default")) // Load the new day
+			m.dashboard = m.dashboard.openCarryOverReview(true)
 			m.dashboard.width = m.width
 			m.dashboard.height = m.height
 			return m, m.dashboard.Init()
@@ -148,6 +231,33 @@ func (m MainModel) updateInitializing(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// applyDayTemplate bootstraps today from a day template in the default
+// workspace and switches to the dashboard. It reports false and records the
+// error when the template could not be applied.
+func (m MainModel) applyDayTemplate(tpl database.DayTemplate) (MainModel, bool) {
+	wsID, err := m.db.EnsureDefaultWorkspace(m.ctx)
+	if err == nil {
+		err = m.db.ApplyDayTemplate(m.ctx, tpl, wsID)
+	}
+	if err != nil {
+		m.err = err
+		return m, false
+	}
+	m.state = StateDashboard
+	m.dashboard = NewDashboardModel(m.ctx, m.db, m.db.CheckCurrentDay(m.ctx), ResolveTheme("default"))
+	m.dashboard = m.dashboard.openCarryOverReview(true)
+	return m, true
+}
+
+// dayTemplateFailed logs why a day template could not be applied and keeps
+// the sprint prompt open with the reason.
+func (m MainModel) dayTemplateFailed(tpl database.DayTemplate, err error) MainModel {
+	util.LogError("apply day template", err)
+	m.err = nil
+	m.notice = fmt.Sprintf("Day template %q could not be applied: %v", tpl.Name, err)
+	return m
+}
+
 func (m MainModel) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\nPress Ctrl+C to quit.", m.err)
@@ -155,12 +265,43 @@ func (m MainModel) View() string {
 
 	switch m.state {
 	case StateInitializing:
-		return fmt.Sprintf(
+		view := fmt.Sprintf(
 			"\n  %s\n\n  %s\n\n  %s\n",
 			"Salutations. Define your temporeal capacity.",
 			"How many sprints will you execute today? (1-8)",
 			m.textInput.View(),
 		)
+		if m.notice != "" {
+			view += "\n  " + m.notice + "\n"
+		}
+		if m.suggested > 0 {
+			hint := fmt.Sprintf("Suggested: %d sprint(s) fit before %s", m.suggested, formatClock(config.PlannedDayEnd))
+			if len(m.meetings) > 0 {
+				hint += fmt.Sprintf(" around %d meeting(s)", len(m.meetings))
+			}
+			view += "\n  " + hint + "; press Enter to accept.\n"
+			for _, meeting := range m.meetings {
+				view += "    " + FormatMeeting(meeting) + "\n"
+			}
+		}
+		if len(m.dayTemplates) == 0 {
+			return view
+		}
+		var b strings.Builder
+		b.WriteString(view)
+		b.WriteString("\n  Or leave empty and press Enter to use a day template (↑/↓ to choose):\n\n")
+		for i, tpl := range m.dayTemplates {
+			cursor := "  "
+			if i == m.templateIdx {
+				cursor = "> "
+			}
+			line := fmt.Sprintf("  %s%s (%d sprints)", cursor, tpl.Name, tpl.Sprints)
+			if len(tpl.Weekdays) > 0 {
+				line += " [" + strings.Join(tpl.Weekdays, ",") + "]"
+			}
+			b.WriteString(line + "\n")
+		}
+		return b.String()
 	case StateDashboard:
 		return m.dashboard.View()
 	}
@@ -2,10 +2,14 @@ package tui
 
 import (
 	"context"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -68,6 +72,53 @@ func TestMainModelUpdateInitializingValidInput(t *testing.T) {
 	}
 }
 
+func TestMainModelInitializingSuggestsSprintsAroundMeetings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	db := setupModelDB(t)
+	ctx := context.Background()
+	today := time.Now()
+	noon := time.Date(today.Year(), today.Month(), today.Day(), 12, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "work.ics")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:lunch\r\nSUMMARY:Lunch talk\r\nDTSTART:" + noon.UTC().Format(util.ICalTimeLayout) +
+		"\r\nDURATION:PT1H\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := SetCalendarFile(ctx, db, path); err != nil {
+		t.Fatalf("SetCalendarFile failed: %v", err)
+	}
+
+	m := NewMainModel(ctx, db)
+	if len(m.meetings) != 1 {
+		t.Fatalf("expected today's meeting, got %+v", m.meetings)
+	}
+	if want := SuggestSprintCount(today.Format(util.DateLayout), m.meetings, time.Now()); m.suggested != want {
+		t.Fatalf("expected %d suggested sprints, got %d", want, m.suggested)
+	}
+	if m.suggested == 0 {
+		if strings.Contains(m.View(), "Suggested") {
+			t.Fatalf("expected no suggestion when no sprint fits")
+		}
+		return
+	}
+	if view := m.View(); !strings.Contains(view, "around 1 meeting(s)") || !strings.Contains(view, "Lunch talk") {
+		t.Fatalf("expected the suggestion and meeting in the view:\n%s", view)
+	}
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated := model.(MainModel)
+	if updated.err != nil || updated.state != StateDashboard {
+		t.Fatalf("expected Enter to accept the suggestion: %v", updated.err)
+	}
+	wsID, err := db.EnsureDefaultWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("EnsureDefaultWorkspace failed: %v", err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), wsID)
+	if err != nil || len(sprints) != m.suggested {
+		t.Fatalf("expected %d sprints, got %d (%v)", m.suggested, len(sprints), err)
+	}
+}
+
 func TestNewMainModelWithExistingDay(t *testing.T) {
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 	db := setupModelDB(t)
@@ -116,3 +167,95 @@ func TestMainModelUpdateCtrlC(t *testing.T) {
 		t.Fatalf("expected quit cmd")
 	}
 }
+
+func TestMainModelInitializingDayTemplate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	db := setupModelDB(t)
+	ctx := context.Background()
+	if err := db.SaveDayTemplate(ctx, database.DayTemplate{Name: "Light", Sprints: 2, Plans: []database.DaySprintPlan{{Number: 2, Label: "Admin"}}}); err != nil {
+		t.Fatalf("SaveDayTemplate failed: %v", err)
+	}
+	m := NewMainModel(ctx, db)
+	if m.state != StateInitializing || m.templateIdx != -1 {
+		t.Fatalf("expected no template preselected, got state %v idx %d", m.state, m.templateIdx)
+	}
+	if !strings.Contains(m.View(), "Light (2 sprints)") {
+		t.Fatalf("expected template listed in view")
+	}
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = model.(MainModel)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(MainModel)
+	if m.err != nil || m.state != StateDashboard {
+		t.Fatalf("expected dashboard after applying template, got state %v err %v", m.state, m.err)
+	}
+	sprints, err := db.GetSprints(ctx, db.CheckCurrentDay(ctx), m.dashboard.workspaces[m.dashboard.activeWorkspaceIdx].ID)
+	if err != nil {
+		t.Fatalf("GetSprints failed: %v", err)
+	}
+	if len(sprints) != 2 || sprints[1].Label == nil || *sprints[1].Label != "Admin" {
+		t.Fatalf("expected labelled sprints from template, got %+v", sprints)
+	}
+}
+
+func TestNewMainModelAutoDayTemplate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	db := setupModelDB(t)
+	ctx := context.Background()
+	today := strings.ToLower(time.Now().Weekday().String()[:3])
+	if err := db.SaveDayTemplate(ctx, database.DayTemplate{Name: "Auto", Weekdays: []string{today}, Sprints: 3, Auto: true}); err != nil {
+		t.Fatalf("SaveDayTemplate failed: %v", err)
+	}
+	m := NewMainModel(ctx, db)
+	if m.state != StateDashboard {
+		t.Fatalf("expected auto template to skip the sprint prompt")
+	}
+	if len(m.dashboard.sprints) == 0 {
+		t.Fatalf("expected sprints loaded")
+	}
+}
+
+func TestNewMainModelAutoDayTemplateFailure(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	db := setupModelDB(t)
+	ctx := context.Background()
+	today := strings.ToLower(time.Now().Weekday().String()[:3])
+	if err := db.SaveDayTemplate(ctx, database.DayTemplate{Name: "Auto", Weekdays: []string{today}, Sprints: 3, Auto: true}); err != nil {
+		t.Fatalf("SaveDayTemplate failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, `CREATE TRIGGER refuse_day BEFORE INSERT ON days
+		BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	m := NewMainModel(ctx, db)
+	if m.state != StateInitializing || m.err != nil {
+		t.Fatalf("expected the sprint prompt after a failed template, got state %v err %v", m.state, m.err)
+	}
+	if view := m.View(); !strings.Contains(view, `Day template "Auto" could not be applied`) {
+		t.Fatalf("expected failure notice on the prompt, got %q", view)
+	}
+}
+
+func TestMainModelInitializingDayTemplateFailure(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	db := setupModelDB(t)
+	ctx := context.Background()
+	if err := db.SaveDayTemplate(ctx, database.DayTemplate{Name: "Light", Sprints: 2}); err != nil {
+		t.Fatalf("SaveDayTemplate failed: %v", err)
+	}
+	if _, err := db.DB.ExecContext(ctx, `CREATE TRIGGER refuse_day BEFORE INSERT ON days
+		BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("create trigger failed: %v", err)
+	}
+	m := NewMainModel(ctx, db)
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = model.(MainModel)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(MainModel)
+	if m.state != StateInitializing || m.err != nil {
+		t.Fatalf("expected the sprint prompt after a failed template, got state %v err %v", m.state, m.err)
+	}
+	if view := m.View(); !strings.Contains(view, `Day template "Light" could not be applied`) || strings.Contains(view, "Press Ctrl+C to quit") {
+		t.Fatalf("expected failure notice on the prompt, got %q", view)
+	}
+}
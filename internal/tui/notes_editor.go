@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/akyairhashvil/SSPT/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notesEditedMsg reports that the external editor for a goal's notes exited.
+type notesEditedMsg struct {
+	GoalID   int64
+	Path     string
+	Original string
+	Cleanup  string // private temp dir to remove, if one was created
+	Err      error
+}
+
+// editorCommand builds the editor invocation for a file from $VISUAL or
+// $EDITOR, falling back to vi; tests replace it.
+var editorCommand = func(path string) *exec.Cmd {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+func (m DashboardModel) handleGoalNotesEdit(key string) (DashboardModel, tea.Cmd, bool) {
+	if key != "E" {
+		return m, nil, false
+	}
+	if !m.validSprintIndex(m.view.focusedColIdx) || len(m.sprints[m.view.focusedColIdx].Goals) <= m.view.focusedGoalIdx {
+		return m, nil, false
+	}
+	target := m.sprints[m.view.focusedColIdx].Goals[m.view.focusedGoalIdx]
+	goal, err := m.db.GetGoalByID(m.ctx, target.ID)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error loading notes: %v", err))
+		return m, nil, true
+	}
+	notes := util.Deref(goal.Notes)
+	path, cleanup, err := writeEditorFile(fmt.Sprintf("goal-%d-*.md", goal.ID), notes, m.db.EncryptionStatus().DatabaseEncrypted)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error preparing notes: %v", err))
+		return m, nil, true
+	}
+	cmd := tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return notesEditedMsg{GoalID: goal.ID, Path: path, Original: notes, Cleanup: cleanup, Err: err}
+	})
+	return m, cmd, true
+}
+
+// writeEditorFile writes content to a temp file, named after pattern, that
+// only the current user can read. For encrypted databases the file goes into
+// a private directory, preferring $XDG_RUNTIME_DIR so the plaintext stays off
+// persistent storage.
+func writeEditorFile(pattern string, content string, encrypted bool) (string, string, error) {
+	dir, cleanup := "", ""
+	if encrypted {
+		var err error
+		dir, err = os.MkdirTemp(os.Getenv("XDG_RUNTIME_DIR"), "sspt-notes-")
+		if err != nil {
+			return "", "", err
+		}
+		cleanup = dir
+	}
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		removeEditorFile("", cleanup)
+		return "", "", err
+	}
+	path := f.Name()
+	if err := f.Chmod(0o600); err == nil {
+		_, err = f.WriteString(content)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		removeEditorFile(path, cleanup)
+		return "", "", err
+	}
+	return path, cleanup, nil
+}
+
+func removeEditorFile(path, cleanup string) {
+	if path != "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			util.LogError("remove editor file", err)
+		}
+	}
+	if cleanup != "" {
+		if err := os.RemoveAll(cleanup); err != nil {
+			util.LogError("remove editor dir", err)
+		}
+	}
+}
+
+// readEditorFile reads back an edited file without the trailing newlines
+// most editors append.
+func readEditorFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+func (m DashboardModel) handleNotesEdited(msg notesEditedMsg) (DashboardModel, tea.Cmd) {
+	defer removeEditorFile(msg.Path, msg.Cleanup)
+	if msg.Err != nil {
+		m.setStatusError(fmt.Sprintf("Editor failed: %v", msg.Err))
+		return m, nil
+	}
+	notes, err := readEditorFile(msg.Path)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error reading notes: %v", err))
+		return m, nil
+	}
+	if notes == strings.TrimRight(msg.Original, "\n") {
+		m.Message = "Notes unchanged"
+		return m, nil
+	}
+	if err := m.db.UpdateGoalNotes(m.ctx, msg.GoalID, notes); err != nil {
+		m.setStatusError(fmt.Sprintf("Error saving notes: %v", err))
+		return m, nil
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	m.Message = "Notes saved"
+	return m, nil
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestGoalNotesEditFlow(t *testing.T) {
+	m, idA, _, _, sprintIdx := setupTwoGoalsInSprint(t)
+	var editedPath string
+	orig := editorCommand
+	editorCommand = func(path string) *exec.Cmd {
+		editedPath = path
+		return exec.Command("true")
+	}
+	t.Cleanup(func() { editorCommand = orig })
+	if err := m.db.UpdateGoalNotes(m.ctx, idA, "old notes"); err != nil {
+		t.Fatalf("UpdateGoalNotes failed: %v", err)
+	}
+
+	m.view.focusedColIdx = sprintIdx
+	for i, g := range m.sprints[sprintIdx].Goals {
+		if g.ID == idA {
+			m.view.focusedGoalIdx = i
+		}
+	}
+	m, cmd, handled := m.handleGoalNotesEdit("E")
+	if !handled || cmd == nil {
+		t.Fatalf("expected editor command")
+	}
+	data, err := os.ReadFile(editedPath)
+	if err != nil {
+		t.Fatalf("expected notes file: %v", err)
+	}
+	if string(data) != "old notes" {
+		t.Fatalf("expected current notes in file, got %q", data)
+	}
+	info, err := os.Stat(editedPath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected private notes file, got %v", info.Mode().Perm())
+	}
+
+	if err := os.WriteFile(editedPath, []byte("## New\n- **done**\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	next, _ := m.Update(notesEditedMsg{GoalID: idA, Path: editedPath, Original: "old notes"})
+	m = next.(DashboardModel)
+	if m.Message != "Notes saved" {
+		t.Fatalf("expected notes saved message, got %q (status %q)", m.Message, m.statusMessage)
+	}
+	if _, err := os.Stat(editedPath); !os.IsNotExist(err) {
+		t.Fatalf("expected notes file removed, got %v", err)
+	}
+	goal, err := m.db.GetGoalByID(m.ctx, idA)
+	if err != nil {
+		t.Fatalf("GetGoalByID failed: %v", err)
+	}
+	if goal.Notes == nil || *goal.Notes != "## New\n- **done**" {
+		t.Fatalf("unexpected notes: %v", goal.Notes)
+	}
+
+	m.showDetails = true
+	m.width = 80
+	pane := m.renderJournalPane()
+	if !strings.Contains(pane, "New") || !strings.Contains(pane, "• ") || strings.Contains(pane, "**") {
+		t.Fatalf("expected rendered markdown in detail pane, got %q", pane)
+	}
+}
+
+func TestWriteEditorFileEncrypted(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	path, cleanup, err := writeEditorFile("goal-7-*.md", "secret", true)
+	if err != nil {
+		t.Fatalf("writeEditorFile failed: %v", err)
+	}
+	if cleanup == "" || !strings.HasPrefix(path, cleanup) {
+		t.Fatalf("expected file inside private dir, got %q (dir %q)", path, cleanup)
+	}
+	info, err := os.Stat(cleanup)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Fatalf("expected private dir, got %v", info.Mode().Perm())
+	}
+	removeEditorFile(path, cleanup)
+	if _, err := os.Stat(cleanup); !os.IsNotExist(err) {
+		t.Fatalf("expected private dir removed, got %v", err)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	theme := ResolveTheme("default")
+	out := renderMarkdown("# Title\n- [ ] todo\n- [x] done\n1. first\n> quote\n```\n**raw**\n```\nSee `a*b*c` and [site](https://x.example)", 40, theme)
+	for _, want := range []string{"Title", "☐ todo", "☑ done", "1. first", "│ ", "**raw**", "a*b*c", "site", "(https://x.example)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rendered markdown:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "# Title") || strings.Contains(out, "```") {
+		t.Fatalf("expected markdown syntax removed:\n%s", out)
+	}
+}
@@ -76,6 +76,9 @@ func (m DashboardModel) renderHeader() string {
 				idx = 0
 			}
 			timerContent = fmt.Sprintf("[%s | %s] Select Sprint & Press 's' to Start", m.workspaces[idx].Name, m.day.Date)
+			if isFutureDay(m.day.Date) {
+				timerContent = fmt.Sprintf("[%s | %s] Planned day: move goals here with 'm'", m.workspaces[idx].Name, m.day.Date)
+			}
 		} else {
 			timerContent = "No workspaces found."
 		}
@@ -129,7 +132,15 @@ func (m DashboardModel) renderHeader() string {
 	if headerWidth < 1 {
 		headerWidth = 1
 	}
-	return headerFrame.Width(headerWidth).Render(timerColor.Render(timerContent))
+	header := timerColor.Render(timerContent)
+	if len(m.meetings) > 0 {
+		blocked := make([]string, len(m.meetings))
+		for i, meeting := range m.meetings {
+			blocked[i] = FormatMeeting(meeting)
+		}
+		header += "\n" + m.theme.Break.Render(truncateLabel("Blocked: "+strings.Join(blocked, " · "), headerWidth))
+	}
+	return headerFrame.Width(headerWidth).Render(header)
 }
 
 func (m DashboardModel) renderFooter() string {
@@ -154,8 +165,90 @@ func (m DashboardModel) renderFooter() string {
 		footerContent = m.theme.Dim.Render("[Enter] Apply Theme | [Esc] Cancel")
 	} else if m.modal.Is(ModalDependency) {
 		footerContent = m.theme.Dim.Render("[Space] Toggle | [Enter] Save | [Esc] Cancel")
+	} else if m.modal.Is(ModalJournalBrowser) {
+		footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+			m.theme.Dim.Render("[↑/↓] Select | [Enter] Jump to goal | [Ctrl+D] Jump to day | [Ctrl+E] Export markdown | [Esc] Close")
+	} else if state, ok := m.modal.PlanDayState(); ok {
+		verb := "Plan"
+		if state.Backfill {
+			verb = "Backfill"
+		}
+		footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+			m.theme.Dim.Render(fmt.Sprintf("%s %s: sprint count 1-8 | [Enter] Create | [Esc] Cancel", verb, state.Date))
+	} else if state, ok := m.modal.CorrectionState(); ok {
+		hint := fmt.Sprintf("Goal #%d completed at HH:MM or YYYY-MM-DD HH:MM", state.GoalID)
+		if state.SprintID != 0 {
+			hint = fmt.Sprintf("Sprint %d on %s: HH:MM-HH:MM [focused minutes]", state.SprintNumber, m.day.Date)
+		}
+		footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+			m.theme.Dim.Render(hint+" | [Enter] Save correction | [Esc] Cancel")
+	} else if m.modal.Is(ModalWorkflow) {
+		footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+			m.theme.Dim.Render("Statuses from pending to completed, comma separated | [Enter] Save | [Esc] Cancel")
+	} else if m.modal.Is(ModalAutoPlan) {
+		footerContent = m.theme.Dim.Render("[↑/↓] Select | [←/→] Earlier/later | [1-8] Sprint | [b] Backlog | [+/-] Capacity | [Enter] Apply | [Esc] Cancel")
+	} else if state, ok := m.modal.WeekState(); ok {
+		if state.Carry != nil {
+			footerContent = m.theme.Dim.Render("[Arrows] Choose sprint | [Space] Drop here | [Esc] Cancel move")
+		} else {
+			footerContent = m.theme.Dim.Render("[Arrows] Select | [Tab] Goal | [Space] Pick up | [Enter] Open day | [p] Plan/backfill day | [i] Export .ics | [[/]] Week | [Esc] Close")
+		}
+	} else if state, ok := m.modal.AnalyticsState(); ok {
+		switch state.Editing {
+		case analyticsInputRange:
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+				m.theme.Dim.Render("Range as YYYY-MM-DD YYYY-MM-DD | [Enter] Show | [Esc] Back")
+		case analyticsInputTag:
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+				m.theme.Dim.Render("Forecast goals with this tag | [Enter] Forecast | [Esc] Back")
+		default:
+			if state.ShowTime {
+				footerContent = m.theme.Dim.Render("[1] 7 days | [2] 30 days | [3] 90 days | [c] Custom range | [g] Group by | [x] Export md/csv | [w] Workspace | [Space] Show/hide | [r] Analytics | [Esc] Close")
+			} else {
+				footerContent = m.theme.Dim.Render("[1] 7 days | [2] 30 days | [3] 90 days | [c] Custom range | [r] Time report | [t] Forecast tag | [m] Heatmap metric | [w] Workspace | [Space] Show/hide | [Esc] Close")
+			}
+		}
+	} else if state, ok := m.modal.CSVImportState(); ok {
+		switch state.Step {
+		case csvImportPath:
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+				m.theme.Dim.Render("CSV file path | [Enter] Open | [Esc] Cancel")
+		case csvImportMapping:
+			footerContent = m.theme.Dim.Render("[↑/↓] Field | [←/→] Column | [Backspace] Unmap | [Enter] Preview | [Esc] Back")
+		default:
+			footerContent = m.theme.Dim.Render("[↑/↓] Scroll | [Enter] Import new rows | [Esc] Back to mapping")
+		}
+	} else if m.modal.Is(ModalCalendarFile) {
+		footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+			m.theme.Dim.Render(".ics file path, empty to stop reading meetings | [Enter] Save | [Esc] Cancel")
+	} else if m.modal.Is(ModalDepGraph) {
+		footerContent = m.theme.Dim.Render("[↑/↓] Select | [Enter] Jump to goal | [w] Goal/workspace | [Esc] Close")
+	} else if state, ok := m.modal.RetroState(); ok {
+		prompt := "[Ctrl+O] Don't ask again"
+		if !m.retroPromptEnabled() {
+			prompt = "[Ctrl+O] Ask after each sprint"
+		}
+		if state.Field == retroFieldFocus || state.Field == retroFieldEnergy {
+			footerContent = m.theme.Dim.Render("[1-5] Rate | [Tab] Next | [Ctrl+S] Save | " + prompt + " | [Esc] Skip")
+		} else {
+			footerContent = m.theme.Dim.Render("[Enter] Next/Save | [Tab] Next | [Ctrl+S] Save | " + prompt + " | [Esc] Skip")
+		}
+	} else if state, ok := m.modal.LinksState(); ok {
+		if state.Editing {
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View())
+		} else {
+			footerContent = m.theme.Dim.Render("[Enter] Open | [a] Add | [e] Edit | [d] Remove | [Esc] Close")
+		}
+	} else if state, ok := m.modal.CarryOverState(); ok {
+		if state.Deferring {
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View())
+		} else {
+			footerContent = m.theme.Dim.Render("[1-8] Sprint | [b] Backlog | [f] Defer | [a] Archive | [d] Delete | [s] Skip | [Enter] Choose | [Esc] Stop")
+		}
+	} else if m.modal.Is(ModalTemplate) {
+		footerContent = m.theme.Dim.Render("[Enter] Create From Template | [Esc] Cancel")
 	} else if m.modal.Is(ModalRecurrence) {
-		footerContent = m.theme.Dim.Render("[Tab] Next | [Space] Toggle | [Enter] Save | [Esc] Cancel")
+		footerContent = m.theme.Dim.Render("[Tab] Next | [Space] Toggle | [+/-] Interval | [p] Missed | [n] Sprint | [Enter] Save | [Esc] Cancel")
 	} else if m.modal.Is(ModalGoalDelete) {
 		footerContent = m.theme.Focused.Render("Delete task? [d] Delete | [a] Archive | [Esc] Cancel")
 	} else if m.security.confirmingClearDB {
@@ -175,9 +268,20 @@ func (m DashboardModel) renderFooter() string {
 		footerContent = m.theme.Dim.Render("[Enter] Next | [Esc] Cancel")
 	} else if m.modal.Is(ModalJournaling) {
 		// Only render journaling input in the journal pane, avoid duplicate
-		footerContent = m.theme.Dim.Render("[Enter] to Save Log | [Esc] Cancel")
-	} else if m.modal.Is(ModalGoalMove) {
-		footerContent = m.theme.Focused.Render("MOVE TO: [0] Backlog | [1-8] Sprint # | [Esc] Cancel")
+		footerContent = m.theme.Dim.Render("[Enter] to Save Log | [Ctrl+E] Multi-line in $EDITOR | [Esc] Cancel")
+	} else if state, ok := m.modal.JournalSelectState(); ok {
+		if state.ConfirmDelete {
+			footerContent = m.theme.Break.Render("Delete this journal entry? [y] Yes | any other key cancels")
+		} else {
+			footerContent = m.theme.Dim.Render("[↑/↓] Select | [e] Edit | [E] Edit in $EDITOR | [d] Delete | [Esc] Close")
+		}
+	} else if state, ok := m.modal.GoalMoveState(); ok {
+		if state.Dating {
+			footerContent = m.theme.Input.Render(m.inputs.textInput.View()) + "\n" +
+				m.theme.Dim.Render("Move to date: YYYY-MM-DD or +days | [Enter] Move | [Esc] Cancel")
+		} else {
+			footerContent = m.theme.Focused.Render("MOVE TO: [0] Backlog | [1-8] Sprint # | [t] Tomorrow | [d] Date | [Esc] Cancel")
+		}
 	} else {
 		baseHelp := normalModeRegistry.HelpForView(m.viewMode)
 		var timerHelp string
@@ -194,6 +298,9 @@ func (m DashboardModel) renderFooter() string {
 				fullHelp = strings.TrimPrefix(timerHelp, "|")
 			}
 		}
+		if m.showFullHelp {
+			fullHelp += "|" + helpLessToken
+		}
 		rawFooter = fullHelp
 		footerContent = m.theme.Dim.Render(fullHelp)
 	}
@@ -213,6 +320,7 @@ func (m DashboardModel) renderFooter() string {
 			tokens := strings.Split(rawFooter, "|")
 			const sep = " | "
 			sepWidth := ansi.StringWidth(sep)
+			var kept []string
 			var widths []int
 			sumWidths := 0
 			var lines []string
@@ -224,6 +332,7 @@ func (m DashboardModel) renderFooter() string {
 					continue
 				}
 				w := ansi.StringWidth(token)
+				kept = append(kept, token)
 				widths = append(widths, w)
 				sumWidths += w
 			}
@@ -235,42 +344,40 @@ func (m DashboardModel) renderFooter() string {
 				if linesTarget < 1 {
 					linesTarget = 1
 				}
-				sumRemaining := sumWidths
-				tokensRemaining := len(widths)
-				linesRemaining := linesTarget
-				idx := 0
-				for _, token := range tokens {
-					token = strings.TrimSpace(token)
-					if token == "" {
-						continue
-					}
-					tokenWidth := widths[idx]
-					remainingTotal := sumRemaining + sepWidth*(tokensRemaining-1)
-					idealMax := int(math.Ceil(float64(remainingTotal) / float64(linesRemaining)))
-					if idealMax > innerWidth {
-						idealMax = innerWidth
-					}
-					if currentWidth == 0 {
-						currentTokens = append(currentTokens, token)
-						currentWidth = tokenWidth
-					} else {
-						candidateWidth := currentWidth + sepWidth + tokenWidth
-						if candidateWidth <= idealMax || linesRemaining == 1 {
+				if !m.showFullHelp && linesTarget > config.FooterHelpLines {
+					lines = fitHelpTokens(kept, innerWidth, config.FooterHelpLines, sep, helpMoreToken)
+				} else {
+					sumRemaining := sumWidths
+					tokensRemaining := len(widths)
+					linesRemaining := linesTarget
+					for idx, token := range kept {
+						tokenWidth := widths[idx]
+						remainingTotal := sumRemaining + sepWidth*(tokensRemaining-1)
+						idealMax := int(math.Ceil(float64(remainingTotal) / float64(linesRemaining)))
+						if idealMax > innerWidth {
+							idealMax = innerWidth
+						}
+						if currentWidth == 0 {
 							currentTokens = append(currentTokens, token)
-							currentWidth = candidateWidth
-						} else {
-							lines = append(lines, strings.Join(currentTokens, sep))
-							linesRemaining--
-							currentTokens = []string{token}
 							currentWidth = tokenWidth
+						} else {
+							candidateWidth := currentWidth + sepWidth + tokenWidth
+							if candidateWidth <= idealMax || linesRemaining == 1 {
+								currentTokens = append(currentTokens, token)
+								currentWidth = candidateWidth
+							} else {
+								lines = append(lines, strings.Join(currentTokens, sep))
+								linesRemaining--
+								currentTokens = []string{token}
+								currentWidth = tokenWidth
+							}
 						}
+						sumRemaining -= tokenWidth
+						tokensRemaining--
+					}
+					if len(currentTokens) > 0 {
+						lines = append(lines, strings.Join(currentTokens, sep))
 					}
-					sumRemaining -= tokenWidth
-					tokensRemaining--
-					idx++
-				}
-				if len(currentTokens) > 0 {
-					lines = append(lines, strings.Join(currentTokens, sep))
 				}
 				for _, line := range lines {
 					footerHelpLines = append(footerHelpLines, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, m.theme.Dim.Render(line)))
@@ -279,7 +386,7 @@ func (m DashboardModel) renderFooter() string {
 			}
 		} else if !m.modal.Is(ModalGoalDelete) && !m.security.confirmingClearDB && !m.security.changingPassphrase &&
 			(m.modal.Is(ModalGoalCreate) || m.modal.Is(ModalGoalEdit) || m.modal.Is(ModalWorkspaceCreate) || m.modal.Is(ModalWorkspaceInit) ||
-				m.modal.Is(ModalTagging) || m.modal.Is(ModalTheme) || m.modal.Is(ModalDependency) || m.modal.Is(ModalRecurrence)) {
+				m.modal.Is(ModalTagging) || m.modal.Is(ModalTheme) || m.modal.Is(ModalDependency) || m.modal.Is(ModalRecurrence) || m.modal.Is(ModalTemplate) || m.modal.Is(ModalCarryOver) || m.modal.Is(ModalLinks) || m.modal.Is(ModalJournalBrowser) || m.modal.Is(ModalRetro) || m.modal.Is(ModalDepGraph) || m.modal.Is(ModalAutoPlan) || m.modal.Is(ModalWorkflow) || m.modal.Is(ModalWeek) || m.modal.Is(ModalPlanDay) || m.modal.Is(ModalCorrection) || m.modal.Is(ModalAnalytics) || m.modal.Is(ModalCSVImport) || m.modal.Is(ModalCalendarFile)) {
 			content = footerContent
 		} else if m.security.changingPassphrase {
 			content = lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, footerContent)
@@ -294,6 +401,42 @@ func (m DashboardModel) renderFooter() string {
 	return footer
 }
 
+const (
+	helpMoreToken = "[?]More"
+	helpLessToken = "[?]Less"
+)
+
+// fitHelpTokens packs help tokens into at most maxLines lines of width,
+// dropping the ones that do not fit and ending with the more token.
+func fitHelpTokens(tokens []string, width, maxLines int, sep, more string) []string {
+	var lines []string
+	var line string
+	for _, token := range tokens {
+		candidate := token
+		if line != "" {
+			candidate = line + sep + token
+		}
+		last := len(lines) == maxLines-1
+		budget := width
+		if last {
+			budget -= ansi.StringWidth(sep + more)
+		}
+		if ansi.StringWidth(candidate) <= budget || line == "" {
+			line = candidate
+			continue
+		}
+		if last {
+			break
+		}
+		lines = append(lines, line)
+		line = token
+	}
+	if line == "" {
+		return append(lines, more)
+	}
+	return append(lines, line+sep+more)
+}
+
 func (m DashboardModel) buildBoardLayout() boardLayout {
 	// Determine visible columns based on ViewMode
 	var scrollableIndices []int
@@ -305,8 +448,15 @@ func (m DashboardModel) buildBoardLayout() boardLayout {
 		showCompleted = m.workspaces[m.activeWorkspaceIdx].ShowCompleted
 		showArchived = m.workspaces[m.activeWorkspaceIdx].ShowArchived
 	}
+	kanban := m.kanbanMode()
 	for i := 0; i < len(m.sprints); i++ {
 		sprint := m.sprints[i]
+		if kanban || sprint.Column != "" {
+			if kanban && sprint.Column != "" {
+				scrollableIndices = append(scrollableIndices, i)
+			}
+			continue
+		}
 		if sprint.Status == models.StatusCompleted && sprint.SprintNumber > 0 {
 			continue
 		}
@@ -385,6 +535,10 @@ func (m DashboardModel) renderBoard(height int, layout boardLayout) string {
 		BorderForeground(m.theme.Border).
 		BorderStyle(lipgloss.ThickBorder())
 
+	var slots map[int64]sprintSlot
+	if len(m.meetings) > 0 {
+		slots = m.sprintSlots()
+	}
 	var renderedCols []string
 	if height > 4 { // Only render if we have minimal space
 		for _, realIdx := range layout.visibleIndices {
@@ -396,14 +550,19 @@ func (m DashboardModel) renderBoard(height int, layout boardLayout) string {
 
 			var title string
 			switch sprint.SprintNumber {
+			case kanbanColumnNumber:
+				title = m.kanbanTitle(sprint)
 			case -1:
 				title = "Completed"
 			case 0:
 				title = "Backlog"
+				if m.forecast != nil {
+					title += " ~" + m.forecast.Range()
+				}
 			case -2:
 				title = "Archived"
 			default:
-				title = fmt.Sprintf("Sprint %d", sprint.SprintNumber)
+				title = FormatSprintTitle(sprint.SprintNumber, sprint.Label)
 			}
 
 			if m.timer.ActiveSprint != nil && sprint.ID == m.timer.ActiveSprint.ID {
@@ -412,7 +571,19 @@ func (m DashboardModel) renderBoard(height int, layout boardLayout) string {
 				title = "⏸ " + title
 			}
 
-			header := m.theme.Header.Width(layout.colContentWidth).Render(title)
+			headerStyle := m.theme.Header
+			if limit, full := m.kanbanWIPFull(sprint.Column); full && cardCount(sprint) > limit {
+				headerStyle = headerStyle.Foreground(m.theme.TagUrgent.GetForeground())
+			}
+			header := headerStyle.Width(layout.colContentWidth).Render(title)
+			if slot, ok := slots[sprint.ID]; ok && sprint.SprintNumber > 0 {
+				switch {
+				case slot.Meeting != nil:
+					header += "\n" + m.theme.TagBlocked.Render(truncateLabel("⚠ "+FormatMeeting(*slot.Meeting), layout.colContentWidth))
+				case sprint.Status != models.StatusCompleted && sprint.Status != models.StatusInterrupted:
+					header += "\n" + m.theme.Dim.Render(slot.Start.Local().Format("15:04")+"-"+slot.End.Local().Format("15:04"))
+				}
+			}
 			headerHeight := lipgloss.Height(header)
 
 			// Render Goals
@@ -495,6 +666,9 @@ func (m DashboardModel) renderBoard(height int, layout boardLayout) string {
 					if g.RecurrenceRule != nil && strings.TrimSpace(*g.RecurrenceRule) != "" {
 						icons = append(icons, "↻")
 					}
+					if g.Links != nil && len(util.JSONToLinks(*g.Links)) > 0 {
+						icons = append(icons, "🔗")
+					}
 					if g.TaskActive {
 						icons = append(icons, "⏱")
 					}
@@ -530,7 +704,13 @@ func (m DashboardModel) renderBoard(height int, layout boardLayout) string {
 					if contentWidth < 1 {
 						contentWidth = 1
 					}
-					combined := base.Render(rawLine) + tagView
+					statusView := ""
+					switch g.Status {
+					case models.GoalStatusPending, models.GoalStatusCompleted, models.GoalStatusBlocked, models.GoalStatusArchived, "":
+					default:
+						statusView = " " + m.theme.StatusStyle(g.Status).Render("["+g.Status.Label()+"]")
+					}
+					combined := base.Render(rawLine) + statusView + tagView
 					wrapped := ansi.Wrap(combined, contentWidth, "")
 					goalLines := strings.Split(wrapped, "\n")
 					if len(goalLines) == 0 {
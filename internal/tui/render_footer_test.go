@@ -3,6 +3,9 @@ package tui
 import (
 	"strings"
 	"testing"
+
+	"github.com/akyairhashvil/SSPT/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestRenderFooterStatusMessage(t *testing.T) {
@@ -41,3 +44,21 @@ func TestRenderFooterDefaultHelp(t *testing.T) {
 		t.Fatalf("expected footer to include default help")
 	}
 }
+
+func TestRenderFooterHelpExpands(t *testing.T) {
+	m := setupTestDashboard(t)
+	m.width = 60
+	footer := m.renderFooter()
+	if !strings.Contains(footer, helpMoreToken) || strings.Contains(footer, "[ctrl+r]Report") {
+		t.Fatalf("expected footer help cut to fit with a more hint, got:\n%s", footer)
+	}
+	if lines := strings.Count(footer, "\n") - 1; lines > config.FooterHelpLines {
+		t.Fatalf("expected at most %d help lines, got %d", config.FooterHelpLines, lines)
+	}
+
+	m, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	footer = m.renderFooter()
+	if !strings.Contains(footer, "[ctrl+r]Report") || !strings.Contains(footer, helpLessToken) {
+		t.Fatalf("expected full footer help, got:\n%s", footer)
+	}
+}
@@ -2,9 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/config"
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/akyairhashvil/SSPT/internal/util"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
 )
@@ -97,6 +102,9 @@ func (m DashboardModel) renderJournalPane() string {
 				}
 			}
 		}
+		if len(m.workspaces) > 0 {
+			analyticsContent.WriteString(m.renderFocusTrend(m.workspaces[m.activeWorkspaceIdx].ID))
+		}
 		analyticsFrame := Frames.Floating.Padding(0, 1)
 		analyticsExtraWidth := lipgloss.Width(analyticsFrame.Render(""))
 		analyticsWidth := m.width - analyticsExtraWidth
@@ -140,7 +148,17 @@ func (m DashboardModel) renderJournalPane() string {
 	} else if state, ok := m.modal.RecurrenceState(); ok {
 		var recContent strings.Builder
 		recContent.WriteString(m.theme.Focused.Render("Recurrence") + "\n")
-		recContent.WriteString(m.theme.Dim.Render("Tab next step | Space toggle | Enter save") + "\n\n")
+		recContent.WriteString(m.theme.Dim.Render("Tab next step | Space toggle | +/- interval | p missed policy | n sprint | Enter save") + "\n")
+		if state.Mode != "none" {
+			units := map[string]string{"daily": "day", "weekly": "week", "monthly": "month"}
+			interval := recurrenceInterval(state)
+			unit := units[state.Mode]
+			if interval > 1 {
+				unit += "s"
+			}
+			recContent.WriteString(m.theme.Dim.Render(fmt.Sprintf("Every %d %s | Missed: %s | Into: %s | %s", interval, unit, recurrenceMissed(state), recurrenceSprintLabel(state), recurrencePreview(state))) + "\n")
+		}
+		recContent.WriteString("\n")
 
 		if state.Focus == "mode" {
 			recContent.WriteString(m.theme.Focused.Render("Frequency") + "\n")
@@ -284,6 +302,115 @@ func (m DashboardModel) renderJournalPane() string {
 			depWidth = 1
 		}
 		journalPane = depFrame.Width(depWidth).Render(depContent.String())
+	} else if state, ok := m.modal.JournalBrowserState(); ok {
+		journalPane = m.renderJournalBrowser(state)
+	} else if state, ok := m.modal.RetroState(); ok {
+		journalPane = m.renderRetro(state)
+	} else if state, ok := m.modal.DepGraphState(); ok {
+		journalPane = m.renderDepGraph(state)
+	} else if state, ok := m.modal.WeekState(); ok {
+		journalPane = m.renderWeek(state)
+	} else if state, ok := m.modal.AnalyticsState(); ok {
+		journalPane = m.renderAnalytics(state)
+	} else if state, ok := m.modal.CSVImportState(); ok {
+		journalPane = m.renderCSVImport(state)
+	} else if state, ok := m.modal.CalendarFileState(); ok {
+		journalPane = m.renderCalendarFile(state)
+	} else if state, ok := m.modal.AutoPlanState(); ok {
+		journalPane = m.renderAutoPlan(state)
+	} else if state, ok := m.modal.LinksState(); ok {
+		var linksContent strings.Builder
+		linksContent.WriteString(m.theme.Focused.Render("Links: "+state.Description) + "\n\n")
+		if len(state.Links) == 0 {
+			linksContent.WriteString(m.theme.Dim.Render("No links yet") + "\n")
+		}
+		for i, link := range state.Links {
+			cursor := "  "
+			if i == state.Cursor {
+				cursor = "> "
+			}
+			if state.Editing && i == state.EditIdx {
+				linksContent.WriteString(cursor + m.theme.Dim.Render(link+" (editing)") + "\n")
+				continue
+			}
+			linksContent.WriteString(cursor + link + "\n")
+		}
+		if state.Editing {
+			prompt := "Add a URL or file path, Enter to save"
+			if state.EditIdx >= 0 {
+				prompt = "Edit the link, Enter to save (empty removes it)"
+			}
+			linksContent.WriteString("\n" + m.theme.Dim.Render(prompt) + "\n")
+		}
+		linksFrame := Frames.Modal.Padding(0, 1)
+		linksExtraWidth := lipgloss.Width(linksFrame.Render(""))
+		linksWidth := m.width - linksExtraWidth
+		if linksWidth < 1 {
+			linksWidth = 1
+		}
+		journalPane = linksFrame.Width(linksWidth).Render(linksContent.String())
+	} else if state, ok := m.modal.CarryOverState(); ok && state.Index < len(state.Goals) {
+		goal := state.Goals[state.Index]
+		var carryContent strings.Builder
+		carryContent.WriteString(m.theme.Focused.Render(fmt.Sprintf("Carry-over review %d/%d", state.Index+1, len(state.Goals))) + "\n")
+		carryContent.WriteString(goal.Description + "\n")
+		var details []string
+		if goal.CarryOverCount > 0 {
+			details = append(details, fmt.Sprintf("carried over %d time(s)", goal.CarryOverCount))
+		}
+		if goal.DeferredUntil != nil {
+			details = append(details, "deferred until "+*goal.DeferredUntil)
+		}
+		if len(details) > 0 {
+			carryContent.WriteString(m.theme.Dim.Render(strings.Join(details, " | ")) + "\n")
+		}
+		carryContent.WriteString("\n")
+		if state.Deferring {
+			carryContent.WriteString(m.theme.Dim.Render("Defer until which day? Enter to confirm") + "\n")
+		} else {
+			for i, opt := range m.carryOverOptions() {
+				cursor := "  "
+				if i == state.Cursor {
+					cursor = "> "
+				}
+				carryContent.WriteString(cursor + opt.Label + "\n")
+			}
+		}
+		carryFrame := Frames.Modal.Padding(0, 1)
+		carryExtraWidth := lipgloss.Width(carryFrame.Render(""))
+		carryWidth := m.width - carryExtraWidth
+		if carryWidth < 1 {
+			carryWidth = 1
+		}
+		journalPane = carryFrame.Width(carryWidth).Render(carryContent.String())
+	} else if state, ok := m.modal.TemplateState(); ok {
+		var tplContent strings.Builder
+		tplContent.WriteString(m.theme.Focused.Render("Templates") + "\n")
+		tplContent.WriteString(m.theme.Dim.Render("Use ↑/↓ to select, Enter to create") + "\n\n")
+		if len(state.Templates) == 0 {
+			tplContent.WriteString(m.theme.Dim.Render("  (no matching templates)\n"))
+		}
+		for i, tpl := range state.Templates {
+			cursor := "  "
+			if i == state.Cursor {
+				cursor = "> "
+			}
+			line := fmt.Sprintf("%s%s", cursor, tpl.Name)
+			if n := tpl.Goal.Count() - 1; n > 0 {
+				line += m.theme.Dim.Render(fmt.Sprintf(" (%d subtasks)", n))
+			}
+			tplContent.WriteString(line + "\n")
+		}
+		if len(state.Templates) > 0 && state.Cursor < len(state.Templates) {
+			tplContent.WriteString("\n" + m.theme.Dim.Render(state.Templates[state.Cursor].Goal.Description))
+		}
+		tplFrame := Frames.Modal.Padding(0, 1)
+		tplExtraWidth := lipgloss.Width(tplFrame.Render(""))
+		tplWidth := m.width - tplExtraWidth
+		if tplWidth < 1 {
+			tplWidth = 1
+		}
+		journalPane = tplFrame.Width(tplWidth).Render(tplContent.String())
 	} else if state, ok := m.modal.ThemeState(); ok {
 		var themeContent strings.Builder
 		themeContent.WriteString(m.theme.Focused.Render("Themes") + "\n")
@@ -348,7 +475,7 @@ func (m DashboardModel) renderJournalPane() string {
 			for i, g := range m.search.Results {
 				status := g.Status
 				if status == "" {
-					status = "pending"
+					status = models.GoalStatusPending
 				}
 				prefix := "  "
 				style := m.theme.Goal
@@ -356,7 +483,7 @@ func (m DashboardModel) renderJournalPane() string {
 					prefix = "> "
 					style = m.theme.Focused
 				}
-				line := fmt.Sprintf("%s %s", m.theme.Dim.Render(string(status)), g.Description)
+				line := fmt.Sprintf("%s %s", m.theme.StatusStyle(status).Render(status.Label()), g.Description)
 				searchContent.WriteString(prefix + style.Render(line) + "\n")
 			}
 		}
@@ -367,14 +494,28 @@ func (m DashboardModel) renderJournalPane() string {
 			journalWidth = 1
 		}
 		journalPane = journalFrame.Width(journalWidth).Render(searchContent.String())
+	} else if goal, ok := m.focusedGoal(); ok && m.showDetails && !m.modal.Is(ModalJournaling) && !m.modal.Is(ModalJournalSelect) {
+		journalPane = m.renderGoalDetails(goal)
 	} else if len(m.journalEntries) > 0 || m.modal.Is(ModalJournaling) {
 		var journalContent strings.Builder
 		journalContent.WriteString(m.theme.Focused.Render("Journal") + "\n\n")
 		start := len(m.journalEntries) - 3
+		end := len(m.journalEntries)
+		selectState, selecting := m.modal.JournalSelectState()
+		if selecting {
+			start = selectState.Cursor - 2
+			if start < 0 {
+				start = 0
+			}
+			end = start + 5
+			if end > len(m.journalEntries) {
+				end = len(m.journalEntries)
+			}
+		}
 		if start < 0 {
 			start = 0
 		}
-		for i := start; i < len(m.journalEntries); i++ {
+		for i := start; i < end; i++ {
 			entry := m.journalEntries[i]
 			var labels []string
 			if entry.SprintID != nil {
@@ -395,7 +536,14 @@ func (m DashboardModel) renderJournalPane() string {
 			line := fmt.Sprintf("%s %s%s",
 				m.theme.Dim.Render(entry.CreatedAt.Format("15:04")),
 				m.theme.Highlight.Render(labelStr),
-				entry.Content)
+				formatJournalContent(entry.Content, 6))
+			if selecting {
+				if i == selectState.Cursor {
+					line = m.theme.Focused.Render("> ") + formatJournalContent(line, 2)
+				} else {
+					line = "  " + formatJournalContent(line, 2)
+				}
+			}
 			journalContent.WriteString(line + "\n")
 		}
 		if m.modal.Is(ModalJournaling) {
@@ -412,3 +560,684 @@ func (m DashboardModel) renderJournalPane() string {
 
 	return journalPane
 }
+
+// renderGoalDetails shows the focused goal's metadata, links and notes, with
+// the notes rendered as markdown.
+func (m DashboardModel) renderGoalDetails(goal GoalView) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var content strings.Builder
+	content.WriteString(m.theme.Focused.Render(fmt.Sprintf("#%d %s", goal.ID, goal.Description)) + "\n")
+	priority := goal.Priority
+	if priority == 0 {
+		priority = 3
+	}
+	details := []string{fmt.Sprintf("P%d", priority)}
+	if goal.Effort != nil && *goal.Effort != "" {
+		details = append(details, "effort "+*goal.Effort)
+	}
+	if goal.Tags != nil {
+		for _, t := range util.JSONToTags(*goal.Tags) {
+			details = append(details, "#"+t)
+		}
+	}
+	if goal.DueDate != nil {
+		details = append(details, "due "+*goal.DueDate)
+	}
+	content.WriteString(m.theme.StatusStyle(goal.Status).Render(goal.Status.Label()) + m.theme.Dim.Render(" | "+strings.Join(details, " | ")) + "\n")
+	if goal.Links != nil {
+		for _, link := range util.JSONToLinks(*goal.Links) {
+			content.WriteString(m.theme.Dim.Render("🔗 "+truncateLabel(link, width-3)) + "\n")
+		}
+	}
+	content.WriteString("\n")
+	if notes := strings.TrimSpace(util.Deref(goal.Notes)); notes != "" {
+		content.WriteString(renderMarkdown(notes, width, m.theme))
+	} else {
+		content.WriteString(m.theme.Dim.Render("No notes. Press E to write some."))
+	}
+	return frame.Width(width).Render(content.String())
+}
+
+func (m DashboardModel) renderJournalBrowser(state *JournalBrowserState) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var content strings.Builder
+	content.WriteString(m.theme.Focused.Render(fmt.Sprintf("Journal Browser (%d entries)", len(state.Results))) + "\n\n")
+	if len(state.Results) == 0 {
+		content.WriteString(m.theme.Dim.Render("No matching entries") + "\n")
+	}
+	const window = 8
+	start := state.Cursor - window/2
+	if start > len(state.Results)-window {
+		start = len(state.Results) - window
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + window
+	if end > len(state.Results) {
+		end = len(state.Results)
+	}
+	for i := start; i < end; i++ {
+		r := state.Results[i]
+		cursor := "  "
+		if i == state.Cursor {
+			cursor = m.theme.Focused.Render("> ")
+		}
+		stamp := m.theme.Dim.Render(r.Date + " " + r.CreatedAt.Format("15:04"))
+		context := m.theme.Highlight.Render(journalRecordContext(r, len(m.workspaces) > 1))
+		firstLine := strings.SplitN(r.Content, "\n", 2)[0]
+		if strings.Contains(r.Content, "\n") {
+			firstLine += " …"
+		}
+		content.WriteString(truncateLabel(cursor+stamp+context+" "+firstLine, width) + "\n")
+	}
+	return frame.Width(width).Render(content.String())
+}
+
+func (m DashboardModel) renderRetro(state *RetroState) string {
+	var b strings.Builder
+	b.WriteString(m.theme.Focused.Render(fmt.Sprintf("Sprint %d retrospective", state.SprintNumber)) + "\n\n")
+	answers := [retroFieldCount]string{
+		retroRating(state.Retro.FocusQuality),
+		retroRating(state.Retro.Energy),
+		state.Retro.WentWell,
+		state.Retro.Blockers,
+	}
+	for i, question := range retroQuestions {
+		cursor := "  "
+		if i == state.Field {
+			cursor = "> "
+		}
+		answer := answers[i]
+		if i == state.Field && (i == retroFieldWentWell || i == retroFieldBlockers) {
+			answer = m.inputs.textInput.View()
+		}
+		b.WriteString(cursor + m.theme.Dim.Render(question+": ") + answer + "\n")
+	}
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// retroRating renders a 1-5 rating as filled dots, or a dash when unset.
+func retroRating(n int) string {
+	if n <= 0 {
+		return "-"
+	}
+	return strings.Repeat("●", n) + strings.Repeat("○", 5-n)
+}
+
+// renderFocusTrend lists the average retrospective focus and energy per day
+// over the last config.FocusTrendDays days.
+func (m DashboardModel) renderFocusTrend(workspaceID int64) string {
+	var b strings.Builder
+	b.WriteString("\n" + m.theme.Focused.Render(fmt.Sprintf("Focus Quality (last %d days)", config.FocusTrendDays)) + "\n\n")
+	points, err := m.db.GetFocusTrend(m.ctx, workspaceID, config.FocusTrendDays)
+	if err != nil {
+		b.WriteString(m.theme.Break.Render(fmt.Sprintf("  error loading retrospectives: %v\n", err)))
+		return b.String()
+	}
+	if len(points) == 0 {
+		b.WriteString(m.theme.Dim.Render("  (no retrospectives yet)\n"))
+		return b.String()
+	}
+	b.WriteString(m.theme.Dim.Render("Date        Focus       Energy      Sprints\n"))
+	for _, p := range points {
+		b.WriteString(fmt.Sprintf("%-10s  %s %.1f  %s %.1f  %d\n",
+			p.Date, retroRating(int(p.Focus+0.5)), p.Focus, retroRating(int(p.Energy+0.5)), p.Energy, p.Sprints))
+	}
+	return b.String()
+}
+
+// depGraphLabelWidth caps a node's description in the graph view.
+const depGraphLabelWidth = 24
+
+func (m DashboardModel) renderDepGraph(state *DepGraphState) string {
+	g := state.Graph
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var b strings.Builder
+	title := "Dependencies: workspace"
+	if goal, ok := g.Nodes[state.FocusID]; ok {
+		title = fmt.Sprintf("Dependencies: #%d %s", goal.ID, goal.Description)
+	}
+	b.WriteString(m.theme.Focused.Render(ansi.Truncate(title, width, "…")) + "\n")
+	if len(g.Critical) > 0 {
+		var chain []string
+		for _, id := range g.Critical {
+			chain = append(chain, fmt.Sprintf("#%d", id))
+		}
+		b.WriteString(m.theme.Dim.Render(fmt.Sprintf("Critical path (effort %d): %s", g.Weight, strings.Join(chain, " → "))) + "\n")
+	} else {
+		b.WriteString(m.theme.Dim.Render("All dependencies complete") + "\n")
+	}
+	b.WriteString(m.theme.Dim.Render("✓ done  ⛔ blocked  ★ critical path") + "\n")
+
+	cursorID := int64(0)
+	if order := g.Order(); state.Cursor < len(order) {
+		cursorID = order[state.Cursor]
+	}
+	for li, layer := range g.Layers {
+		if li > 0 {
+			b.WriteString(m.theme.Dim.Render("  │\n  ▼") + "\n")
+		}
+		b.WriteString(m.theme.Dim.Render(fmt.Sprintf("Layer %d", li+1)) + "\n")
+		line, lineWidth := "", 0
+		for _, id := range layer {
+			node := m.renderDepGraphNode(g, id, id == cursorID)
+			w := lipgloss.Width(node)
+			if lineWidth > 0 && lineWidth+2+w > width {
+				b.WriteString(line + "\n")
+				line, lineWidth = "", 0
+			}
+			if lineWidth > 0 {
+				line += "  "
+				lineWidth += 2
+			}
+			line += node
+			lineWidth += w
+		}
+		if line != "" {
+			b.WriteString(line + "\n")
+		}
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// renderDepGraphNode renders one goal as a bracketed graph node listing the
+// goals it waits on.
+func (m DashboardModel) renderDepGraphNode(g depGraph, id int64, selected bool) string {
+	goal := g.Nodes[id]
+	marker, style := "○", m.theme.Goal
+	switch {
+	case goal.Status == models.GoalStatusCompleted:
+		marker, style = "✓", m.theme.CompletedGoal
+	case g.Blocked[id] && g.IsCritical(id):
+		marker, style = "⛔★", m.theme.TagBlocked
+	case g.Blocked[id]:
+		marker, style = "⛔", m.theme.TagBlocked
+	case g.IsCritical(id):
+		marker, style = "★", m.theme.Focused
+	}
+	label := fmt.Sprintf("%s #%d %s", marker, id, ansi.Truncate(goal.Description, depGraphLabelWidth, "…"))
+	if len(g.Deps[id]) > 0 {
+		var deps []string
+		for _, dep := range g.Deps[id] {
+			deps = append(deps, fmt.Sprintf("%d", dep))
+		}
+		label += " ←" + strings.Join(deps, ",")
+	}
+	if selected {
+		return m.theme.Highlight.Render("[" + label + "]")
+	}
+	return style.Render(" " + label + " ")
+}
+
+// weekCellWidth is the width of one sprint cell in the week view.
+const weekCellWidth = 15
+
+// renderWeek lays the week out as one row per day and one cell per sprint,
+// showing the sprint status, completed/total goals and focused minutes.
+func (m DashboardModel) renderWeek(state *WeekState) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var b strings.Builder
+	title := "Week of " + state.Start
+	if state.Carry != nil {
+		title += fmt.Sprintf(" | moving #%d %s", state.Carry.ID, state.Carry.Description)
+	}
+	b.WriteString(m.theme.Focused.Render(ansi.Truncate(title, width, "…")) + "\n")
+	b.WriteString(m.theme.Dim.Render("○ pending  ▶ active  ⏸ paused  ✓ done  ✗ interrupted  * corrected") + "\n")
+
+	cols := weekColumns(state.Days)
+	header := fmt.Sprintf("%-10s", "")
+	for c := 0; c < cols; c++ {
+		header += fmt.Sprintf(" %-*s", weekCellWidth, fmt.Sprintf("Sprint %d", c+1))
+	}
+	b.WriteString(m.theme.Dim.Render(ansi.Truncate(header, width, "")) + "\n")
+	now := time.Now()
+	for r, day := range state.Days {
+		label := day.Date
+		if date, err := time.Parse(util.DateLayout, day.Date); err == nil {
+			label = date.Format("Mon 01-02")
+		}
+		labelStyle := m.theme.Dim
+		if day.DayID != 0 {
+			labelStyle = m.theme.Goal
+		}
+		if day.Date == m.day.Date {
+			labelStyle = m.theme.Focused
+		}
+		line := labelStyle.Render(fmt.Sprintf("%-10s", label))
+		for c := 0; c < cols; c++ {
+			text := "·"
+			if c < len(day.Sprints) {
+				s := day.Sprints[c]
+				icon := "○"
+				switch s.Status {
+				case models.StatusActive:
+					icon = "▶"
+				case models.StatusPaused:
+					icon = "⏸"
+				case models.StatusCompleted:
+					icon = "✓"
+				case models.StatusInterrupted:
+					icon = "✗"
+				}
+				text = fmt.Sprintf("%s %d/%d %dm", icon, s.Completed, s.Total, int(s.FocusedDuration(now).Minutes()))
+				if s.Corrected {
+					text += "*"
+				}
+			}
+			cell := fmt.Sprintf("%-*s", weekCellWidth, text)
+			if r == state.Row && c == state.Col {
+				line += " " + m.theme.Highlight.Render("["+ansi.Truncate(cell, weekCellWidth-2, "")+"]")
+			} else {
+				line += " " + m.theme.Goal.Render(cell)
+			}
+		}
+		b.WriteString(ansi.Truncate(line, width, "") + "\n")
+	}
+
+	b.WriteString("\n")
+	if len(state.Goals) == 0 {
+		b.WriteString(m.theme.Dim.Render("No open goals in this sprint") + "\n")
+	}
+	for i, g := range state.Goals {
+		text := ansi.Truncate(fmt.Sprintf("#%d %s", g.ID, g.Description), width-2, "…")
+		if i == state.GoalIdx {
+			b.WriteString(m.theme.Highlight.Render("> "+text) + "\n")
+		} else {
+			b.WriteString(m.theme.Goal.Render("  "+text) + "\n")
+		}
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// renderAutoPlan shows the proposed plan as a diff per sprint: goals being
+// added are marked +, goals left in the backlog are listed last.
+func (m DashboardModel) renderAutoPlan(state *AutoPlanState) string {
+	p := state.Plan
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var b strings.Builder
+	b.WriteString(m.theme.Focused.Render(fmt.Sprintf("Auto-plan: %d of %d backlog goal(s), capacity %d per sprint", p.Planned(), len(p.Items), p.Capacity)) + "\n")
+	line := func(i int) string {
+		item := p.Items[i]
+		text := item.Goal.Description
+		if item.Goal.Effort != nil && *item.Goal.Effort != "" {
+			text += " @" + *item.Goal.Effort
+		}
+		text = fmt.Sprintf("#%d %s", item.Goal.ID, text)
+		style := m.theme.Goal
+		if item.Target >= 0 {
+			text = "+ " + text
+		} else {
+			text = "  " + text
+			if item.Reason != "" {
+				text += " (" + item.Reason + ")"
+			}
+			style = m.theme.Dim
+		}
+		if conflicts := p.Conflicts(i); len(conflicts) > 0 {
+			var ids []string
+			for _, id := range conflicts {
+				ids = append(ids, fmt.Sprintf("#%d", id))
+			}
+			text += " ⚠ before " + strings.Join(ids, ", ")
+			style = m.theme.TagBlocked
+		}
+		cursor := "  "
+		if i == state.Cursor {
+			cursor = "> "
+			style = m.theme.Focused
+		}
+		return cursor + style.Render(ansi.Truncate(text, width-2, "…"))
+	}
+	for si, s := range p.Sprints {
+		header := FormatSprintTitle(s.Number, s.Label)
+		load := p.Load(si)
+		usage := fmt.Sprintf("  %d/%d", load, p.Capacity)
+		if s.Blocked != "" {
+			usage += "  ⛔ " + s.Blocked
+		}
+		if load > p.Capacity {
+			b.WriteString("\n" + m.theme.Header.Render(header) + m.theme.Break.Render(usage) + "\n")
+		} else {
+			b.WriteString("\n" + m.theme.Header.Render(header) + m.theme.Dim.Render(usage) + "\n")
+		}
+		for i, item := range p.Items {
+			if item.Target == si {
+				b.WriteString(line(i) + "\n")
+			}
+		}
+	}
+	var rest []string
+	for i, item := range p.Items {
+		if item.Target < 0 {
+			rest = append(rest, line(i))
+		}
+	}
+	if len(rest) > 0 {
+		b.WriteString("\n" + m.theme.Header.Render("Backlog") + "\n" + strings.Join(rest, "\n") + "\n")
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// sparkLevels are the bar heights used by sparkline.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values relative to max, one rune per value. Negative
+// values are gaps.
+func sparkline(values []float64, max float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			b.WriteRune(' ')
+		case max <= 0:
+			b.WriteRune(sparkLevels[0])
+		default:
+			level := int(v / max * float64(len(sparkLevels)-1))
+			if level >= len(sparkLevels) {
+				level = len(sparkLevels) - 1
+			}
+			b.WriteRune(sparkLevels[level])
+		}
+	}
+	return b.String()
+}
+
+// formatCycleTime shows durations of a day or more in days.
+func formatCycleTime(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	}
+	return FormatDuration(d)
+}
+
+// formatRate formats a share as a percentage, or "–" when undefined.
+func formatRate(rate float64) string {
+	if rate < 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+// renderAnalytics shows the totals of the period, day-by-day trends of focus
+// time and completion rate, and a row per week.
+func (m DashboardModel) renderAnalytics(state *AnalyticsState) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	if state.ShowTime {
+		return frame.Width(width).Render(m.renderTimeReport(state, width))
+	}
+	a := state.Data
+	var b strings.Builder
+	title := fmt.Sprintf("Analytics: %s → %s", state.From, state.To)
+	if state.Days > 0 {
+		title = fmt.Sprintf("Analytics: last %d days (%s → %s)", state.Days, state.From, state.To)
+	}
+	b.WriteString(m.theme.Focused.Render(ansi.Truncate(title, width, "…")) + "\n\n")
+	if len(a.Days) == 0 {
+		b.WriteString(m.theme.Dim.Render("No data") + "\n")
+		return frame.Width(width).Render(b.String())
+	}
+
+	totals := a.Totals()
+	sprintRate := -1.0
+	if totals.SprintsPlanned > 0 {
+		sprintRate = float64(totals.SprintsCompleted) / float64(totals.SprintsPlanned)
+	}
+	avgFocus := totals.Focus / time.Duration(len(a.Days))
+	summary := []string{
+		fmt.Sprintf("Focus %s (avg %s/day)", FormatDuration(totals.Focus), FormatDuration(avgFocus)),
+		fmt.Sprintf("Sprints %d/%d completed (%s)", totals.SprintsCompleted, totals.SprintsPlanned, formatRate(sprintRate)),
+		fmt.Sprintf("Goals %d/%d completed (%s)", totals.GoalsCompleted, totals.GoalsTotal, formatRate(totals.CompletionRate())),
+		fmt.Sprintf("Cycle time %s avg over %d goal(s), task timer %s", formatCycleTime(a.CycleTime), a.CycleSamples, FormatDuration(a.TaskTime)),
+		fmt.Sprintf("Carry-over rate %s", formatRate(a.CarryOverRate())),
+		fmt.Sprintf("Streak %d day(s), longest %d", a.CurrentStreak, a.LongestStreak),
+	}
+	for _, line := range summary {
+		b.WriteString(ansi.Truncate(line, width, "…") + "\n")
+	}
+	if a.CorrectedSprints > 0 {
+		b.WriteString(m.theme.Dim.Render(fmt.Sprintf("Includes %d corrected sprint(s)", a.CorrectedSprints)) + "\n")
+	}
+
+	// Trends show the most recent days that fit.
+	const trendLabel = 12
+	days := a.Days
+	if room := width - trendLabel; room > 0 && len(days) > room {
+		days = days[len(days)-room:]
+	}
+	focus := make([]float64, len(days))
+	rates := make([]float64, len(days))
+	maxFocus := 0.0
+	for i, day := range days {
+		focus[i] = day.Focus.Minutes()
+		if focus[i] > maxFocus {
+			maxFocus = focus[i]
+		}
+		rates[i] = day.CompletionRate()
+	}
+	b.WriteString("\n" + m.theme.Header.Render("Trends") + "\n")
+	b.WriteString(fmt.Sprintf("%-*s%s\n", trendLabel, "Focus/day", m.theme.Highlight.Render(sparkline(focus, maxFocus))))
+	b.WriteString(fmt.Sprintf("%-*s%s\n", trendLabel, "Done rate", m.theme.Highlight.Render(sparkline(rates, 1))))
+
+	b.WriteString("\n" + m.theme.Header.Render("Forecast") + "\n")
+	b.WriteString(m.renderForecast(state.Forecast, state.ForecastTag, width))
+
+	b.WriteString("\n" + m.theme.Header.Render(fmt.Sprintf("Heatmap: %s, last year", state.Metric)) + "\n")
+	var shown []string
+	for i, ws := range m.workspaces {
+		mark := "[x]"
+		if state.Hidden[ws.ID] {
+			mark = "[ ]"
+		}
+		label := mark + " " + ws.Name
+		if i == state.WorkspaceIdx {
+			label = m.theme.Focused.Render(label)
+		}
+		shown = append(shown, label)
+	}
+	b.WriteString(strings.Join(shown, "  ") + "\n")
+	b.WriteString(RenderHeatmap(state.Heatmap, state.Metric, m.theme, width))
+
+	b.WriteString("\n" + m.theme.Header.Render("Weeks") + "\n")
+	b.WriteString(m.theme.Dim.Render(fmt.Sprintf("%-10s  %-9s  %-7s  %-6s  %s", "Week of", "Focus", "Sprints", "Done", "Carry")) + "\n")
+	var weeks []string
+	byWeek := make(map[string]*database.Analytics)
+	for _, day := range a.Days {
+		start := weekStart(day.Date)
+		week, ok := byWeek[start]
+		if !ok {
+			week = &database.Analytics{}
+			byWeek[start] = week
+			weeks = append(weeks, start)
+		}
+		week.Days = append(week.Days, day)
+	}
+	for _, start := range weeks {
+		week := byWeek[start]
+		t := week.Totals()
+		line := fmt.Sprintf("%-10s  %-9s  %3d/%-3d  %-6s  %s", start, FormatDuration(t.Focus), t.SprintsCompleted, t.SprintsPlanned,
+			formatRate(t.CompletionRate()), formatRate(week.CarryOverRate()))
+		b.WriteString(ansi.Truncate(line, width, "") + "\n")
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// renderForecast describes the backlog forecast and its percentile dates.
+func (m DashboardModel) renderForecast(f Forecast, tag string, width int) string {
+	scope := "backlog"
+	if tag != "" {
+		scope = "backlog #" + tag
+	}
+	var lines []string
+	lines = append(lines, fmt.Sprintf("%d goal(s), %d point(s) left in the %s", f.Goals, f.Points, scope))
+	switch {
+	case f.Points == 0:
+		lines = append(lines, "Nothing left to forecast")
+	case !f.Known:
+		lines = append(lines, m.theme.Dim.Render(fmt.Sprintf("No goals completed in the last %d days to forecast from", config.ForecastHistoryDays)))
+	default:
+		lines = append(lines, fmt.Sprintf("Velocity %.1f point(s)/sprint at %d sprint(s)/day over the last %d days",
+			f.PerSprint, f.SprintsPerDay, config.ForecastHistoryDays))
+		var dates []string
+		for i, p := range forecastPercentiles {
+			dates = append(dates, fmt.Sprintf("%.0f%% by %s", p*100, forecastDate(f.Days[i])))
+		}
+		lines = append(lines, m.theme.Highlight.Render(strings.Join(dates, "  ")))
+	}
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(ansi.Truncate(line, width, "…") + "\n")
+	}
+	return b.String()
+}
+
+// renderTimeReport lists the task timer time of the analytics range grouped
+// by the selected grouping, with each row's share of the total.
+func (m DashboardModel) renderTimeReport(state *AnalyticsState, width int) string {
+	r := state.Time
+	var b strings.Builder
+	title := fmt.Sprintf("Time report by %s: %s → %s", state.TimeGroup, state.From, state.To)
+	b.WriteString(m.theme.Focused.Render(ansi.Truncate(title, width, "…")) + "\n")
+	var shown []string
+	for i, ws := range m.workspaces {
+		mark := "[x]"
+		if state.Hidden[ws.ID] {
+			mark = "[ ]"
+		}
+		label := mark + " " + ws.Name
+		if i == state.WorkspaceIdx {
+			label = m.theme.Focused.Render(label)
+		}
+		shown = append(shown, label)
+	}
+	b.WriteString(strings.Join(shown, "  ") + "\n\n")
+	if len(r.Entries) == 0 {
+		b.WriteString(m.theme.Dim.Render("No time tracked: start a task timer with [T] on a goal") + "\n")
+		return b.String()
+	}
+	total := r.Total()
+	b.WriteString(fmt.Sprintf("Total %s over %d goal(s)\n\n", FormatDuration(total), len(r.Entries)))
+	const numbers = 24
+	keyWidth := width - numbers
+	if keyWidth < 8 {
+		keyWidth = 8
+	}
+	b.WriteString(m.theme.Dim.Render(fmt.Sprintf("%-*s %5s %9s %6s", keyWidth, strings.ToUpper(state.TimeGroup.String()[:1])+state.TimeGroup.String()[1:], "Goals", "Time", "Share")) + "\n")
+	for _, row := range r.Group(state.TimeGroup) {
+		share := float64(row.Duration) / float64(total)
+		key := ansi.Truncate(row.Key, keyWidth, "…")
+		b.WriteString(fmt.Sprintf("%s%s %5d %9s %6s\n", key, strings.Repeat(" ", keyWidth-ansi.StringWidth(key)), row.Goals, FormatDuration(row.Duration), formatRate(share)))
+	}
+	return b.String()
+}
+
+// renderCSVImport shows the column mapping of a CSV import with a sample
+// value per field, or the preview of its rows.
+func (m DashboardModel) renderCSVImport(state *CSVImportState) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var b strings.Builder
+	switch state.Step {
+	case csvImportPath:
+		b.WriteString(m.theme.Focused.Render("Import CSV into the backlog") + "\n\n")
+		b.WriteString(m.theme.Dim.Render("Enter the path of a CSV file with a header row. Columns are mapped to goal fields in the next step.") + "\n")
+	case csvImportMapping:
+		c := state.Import
+		b.WriteString(m.theme.Focused.Render(ansi.Truncate(fmt.Sprintf("Map columns: %s (%d rows)", filepath.Base(state.Path), len(c.Rows)), width, "…")) + "\n\n")
+		var sample []string
+		if len(c.Rows) > 0 {
+			sample = c.Rows[0]
+		}
+		for i, field := range CSVImportFields {
+			cursor := "  "
+			if i == state.FieldIdx {
+				cursor = "> "
+			}
+			line := fmt.Sprintf("%s%-12s ← %s", cursor, field, c.ColumnName(field))
+			if value := c.value(sample, field); value != "" {
+				line += m.theme.Dim.Render("  e.g. " + value)
+			}
+			line = ansi.Truncate(line, width, "…")
+			if i == state.FieldIdx {
+				line = m.theme.Focused.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+	case csvImportPreview:
+		counts := CountCSVRows(state.Preview)
+		b.WriteString(m.theme.Focused.Render(fmt.Sprintf("Preview: %d new, %d duplicate, %d invalid",
+			counts[CSVRowNew], counts[CSVRowDuplicate], counts[CSVRowInvalid])) + "\n\n")
+		rows := state.Preview[state.Offset:]
+		if limit := m.height / 2; limit > 0 && len(rows) > limit {
+			rows = rows[:limit]
+		}
+		for _, p := range rows {
+			line := fmt.Sprintf("%4d %-9s %s", p.Line, p.Status, p.Seed.Description)
+			if p.Reason != "" {
+				line += " (" + p.Reason + ")"
+			}
+			line = ansi.Truncate(line, width, "…")
+			if p.Status != CSVRowNew {
+				line = m.theme.Dim.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return frame.Width(width).Render(b.String())
+}
+
+// renderCalendarFile explains the calendar file and lists the meetings it
+// blocks on the shown day.
+func (m DashboardModel) renderCalendarFile(state *CalendarFileState) string {
+	frame := Frames.Modal.Padding(0, 1)
+	width := m.width - lipgloss.Width(frame.Render(""))
+	if width < 1 {
+		width = 1
+	}
+	var b strings.Builder
+	b.WriteString(m.theme.Focused.Render("Calendar file") + "\n\n")
+	b.WriteString(m.theme.Dim.Render("Meetings in a local .ics file, e.g. exported from your calendar, block time on the board: sprints are planned around them and warn when they would overlap one. The file is re-read on every refresh.") + "\n\n")
+	if state.Path == "" {
+		b.WriteString(m.theme.Dim.Render("No calendar file set") + "\n")
+	} else if len(m.meetings) == 0 {
+		b.WriteString(m.theme.Dim.Render(ansi.Truncate("No meetings on "+m.day.Date+" in "+state.Path, width, "…")) + "\n")
+	} else {
+		b.WriteString(m.theme.Header.Render("Meetings on "+m.day.Date) + "\n")
+		for _, meeting := range m.meetings {
+			b.WriteString(ansi.Truncate("  "+FormatMeeting(meeting), width, "…") + "\n")
+		}
+	}
+	return frame.Width(width).Render(b.String())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
 	"github.com/akyairhashvil/SSPT/internal/util"
@@ -53,6 +55,11 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 
 	totalCompleted := 0
 	totalGoals := 0
+	workflow, err := workspaceWorkflow(ctx, db, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	statusCounts := make(map[models.GoalStatus]int)
 
 	// Fetch ALL goals to build complete context
 	allGoals, err := db.GetAllGoals(ctx)
@@ -119,6 +126,7 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 
 		for _, g := range flatGoals {
 			totalGoals++
+			statusCounts[g.Status]++
 			check := "[ ]"
 			if g.Status == models.GoalStatusCompleted {
 				check = "[x]"
@@ -128,7 +136,16 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 			if g.Level > 0 {
 				indent = "    " // Markdown indent
 			}
-			if err := write(fmt.Sprintf("%s- %s %s%s\n", indent, check, g.Description, formatElapsed(g.TaskElapsedSec))); err != nil {
+			status := ""
+			if label := reportStatusLabel(g.Status); label != "" {
+				status = " _(" + label + ")_"
+			}
+			if err := write(fmt.Sprintf("%s- %s %s%s%s\n", indent, check, g.Description, status, formatElapsed(g.TaskElapsedSec))); err != nil {
+				return "", err
+			}
+		}
+		if s.Retro != nil {
+			if err := write(formatRetroReport(*s.Retro)); err != nil {
 				return "", err
 			}
 		}
@@ -158,10 +175,34 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 	if err := write(fmt.Sprintf("- **Completion Rate:** %.1f%%\n", completionRate)); err != nil {
 		return "", err
 	}
+	if totalGoals > 0 {
+		if err := write(fmt.Sprintf("- **By Status:** %s\n", formatStatusCounts(workflow, statusCounts))); err != nil {
+			return "", err
+		}
+	}
 	if err := write("\n"); err != nil {
 		return "", err
 	}
 
+	// Goals that keep slipping from day to day
+	chronic, err := db.GetChronicCarryOvers(ctx, workspaceID, 2)
+	if err != nil {
+		return "", err
+	}
+	if len(chronic) > 0 {
+		if err := write("## Chronic Slippage\n\n"); err != nil {
+			return "", err
+		}
+		for _, g := range chronic {
+			if err := write(fmt.Sprintf("- %s (carried over %d times)\n", g.Description, g.CarryOverCount)); err != nil {
+				return "", err
+			}
+		}
+		if err := write("\n"); err != nil {
+			return "", err
+		}
+	}
+
 	// Journal
 	entries, err := db.GetJournalEntries(ctx, dayID, workspaceID)
 	if err != nil {
@@ -173,7 +214,7 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 		}
 		for _, e := range entries {
 			timeStr := e.CreatedAt.Format("15:04")
-			if err := write(fmt.Sprintf("- **%s**: %s\n", timeStr, e.Content)); err != nil {
+			if err := write(fmt.Sprintf("- **%s**: %s\n", timeStr, formatJournalContent(e.Content, 2))); err != nil {
 				return "", err
 			}
 		}
@@ -181,3 +222,68 @@ func GenerateReport(ctx context.Context, db Database, dayID int64, workspaceID i
 
 	return absPath, nil
 }
+
+// formatRetroReport renders a sprint retrospective as report lines.
+func formatRetroReport(retro models.SprintRetro) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n**Retro:** focus %d/5, energy %d/5\n", retro.FocusQuality, retro.Energy)
+	if retro.WentWell != "" {
+		fmt.Fprintf(&b, "- Went well: %s\n", retro.WentWell)
+	}
+	if retro.Blockers != "" {
+		fmt.Fprintf(&b, "- Blocked: %s\n", retro.Blockers)
+	}
+	return b.String()
+}
+
+// workspaceWorkflow returns the goal status workflow of workspaceID.
+func workspaceWorkflow(ctx context.Context, db Database, workspaceID int64) (models.Workflow, error) {
+	workspaces, err := db.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		if ws.ID == workspaceID && len(ws.Workflow) > 0 {
+			return ws.Workflow, nil
+		}
+	}
+	return models.DefaultWorkflow, nil
+}
+
+// reportStatusLabel names the statuses between pending and completed; the
+// checkbox already shows those two.
+func reportStatusLabel(status models.GoalStatus) string {
+	switch status {
+	case "", models.GoalStatusPending, models.GoalStatusCompleted:
+		return ""
+	}
+	return status.Label()
+}
+
+// formatStatusCounts lists goal counts in workflow order, followed by
+// statuses outside the workflow such as blocked.
+func formatStatusCounts(workflow models.Workflow, counts map[models.GoalStatus]int) string {
+	var parts []string
+	seen := make(map[models.GoalStatus]bool)
+	add := func(status models.GoalStatus) {
+		if seen[status] || counts[status] == 0 {
+			return
+		}
+		seen[status] = true
+		parts = append(parts, fmt.Sprintf("%s %d", status.Label(), counts[status]))
+	}
+	for _, status := range workflow {
+		add(status)
+	}
+	var rest []string
+	for status := range counts {
+		if !seen[status] {
+			rest = append(rest, string(status))
+		}
+	}
+	sort.Strings(rest)
+	for _, status := range rest {
+		add(models.GoalStatus(status))
+	}
+	return strings.Join(parts, ", ")
+}
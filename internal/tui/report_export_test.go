@@ -92,6 +92,9 @@ func TestGenerateReportCreatesFile(t *testing.T) {
 	if !strings.Contains(content, "Write tests") {
 		t.Fatalf("expected goal in report")
 	}
+	if !strings.Contains(content, "**By Status:** pending 1, completed 1") {
+		t.Fatalf("expected status breakdown in report, got: %s", content)
+	}
 }
 
 func TestGeneratePDFReportCreatesFile(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
 	"github.com/akyairhashvil/SSPT/internal/util"
@@ -30,6 +31,11 @@ func GeneratePDFReport(ctx context.Context, db Database, dayID int64, workspaceI
 	pdf.SetFont("Arial", "", 12)
 
 	totalCompleted := 0
+	workflow, err := workspaceWorkflow(ctx, db, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	statusCounts := make(map[models.GoalStatus]int)
 
 	// Fetch ALL goals to build complete context
 	allGoals, err := db.GetAllGoals(ctx)
@@ -91,18 +97,27 @@ func GeneratePDFReport(ctx context.Context, db Database, dayID int64, workspaceI
 		}
 
 		for _, g := range flatGoals {
+			statusCounts[g.Status]++
 			status := "[ ]"
 			if g.Status == models.GoalStatusCompleted {
 				status = "[x]"
 				totalCompleted++
 			}
+			label := ""
+			if l := reportStatusLabel(g.Status); l != "" {
+				label = " (" + l + ")"
+			}
 			indent := ""
 			for k := 0; k < g.Level; k++ {
 				indent += "    "
 			}
-			pdf.Cell(0, 8, fmt.Sprintf("%s  %s %s%s", indent, status, g.Description, formatElapsed(g.TaskElapsedSec)))
+			pdf.Cell(0, 8, fmt.Sprintf("%s  %s %s%s%s", indent, status, g.Description, label, formatElapsed(g.TaskElapsedSec)))
 			pdf.Ln(6)
 		}
+		if s.Retro != nil {
+			pdf.SetFont("Arial", "I", 11)
+			pdf.MultiCell(0, 6, strings.TrimSpace(strings.ReplaceAll(formatRetroReport(*s.Retro), "**", "")), "", "", false)
+		}
 		pdf.Ln(4)
 	}
 
@@ -111,6 +126,11 @@ func GeneratePDFReport(ctx context.Context, db Database, dayID int64, workspaceI
 	pdf.SetFont("Arial", "B", 12)
 	pdf.Cell(0, 10, fmt.Sprintf("Total Goals Completed: %d", totalCompleted))
 	pdf.Ln(10)
+	if len(statusCounts) > 0 {
+		pdf.SetFont("Arial", "", 12)
+		pdf.Cell(0, 8, "By status: "+formatStatusCounts(workflow, statusCounts))
+		pdf.Ln(10)
+	}
 
 	// Journaling
 	entries, err := db.GetJournalEntries(ctx, dayID, workspaceID)
@@ -1,6 +1,9 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/akyairhashvil/SSPT/internal/models"
+	"github.com/charmbracelet/lipgloss"
+)
 
 type Theme struct {
 	Name          string
@@ -20,6 +23,9 @@ type Theme struct {
 	TagFocus      lipgloss.Style
 	TagLater      lipgloss.Style
 	TagDefault    lipgloss.Style
+	StatusActive  lipgloss.Style // in_progress goals
+	StatusReview  lipgloss.Style
+	StatusCustom  lipgloss.Style // workflow statuses without a color of their own
 	Focused       lipgloss.Style
 	Dim           lipgloss.Style
 	Highlight     lipgloss.Style
@@ -44,6 +50,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true),
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("6")),
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true),
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("63")),
@@ -66,6 +75,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true),  // Blue
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),            // Grey
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("120")),            // Green
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("117")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true), // Pink
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("60")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("62")),
@@ -88,6 +100,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Bold(true),
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("86")),
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("51")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("221")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("177")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("201")).Bold(true),
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("201")),
@@ -110,6 +125,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("66")).Bold(true),
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("110")),
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("172")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("135")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("166")).Bold(true),
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
@@ -132,6 +150,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("28")).Bold(true),
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("238")),
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("25")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("130")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("94")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("16")).Bold(true),
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("242")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("238")),
@@ -154,6 +175,9 @@ var Themes = map[string]Theme{
 		TagFocus:      lipgloss.NewStyle().Foreground(lipgloss.Color("28")).Bold(true),
 		TagLater:      lipgloss.NewStyle().Foreground(lipgloss.Color("242")),
 		TagDefault:    lipgloss.NewStyle().Foreground(lipgloss.Color("23")),
+		StatusActive:  lipgloss.NewStyle().Foreground(lipgloss.Color("24")).Bold(true),
+		StatusReview:  lipgloss.NewStyle().Foreground(lipgloss.Color("130")).Bold(true),
+		StatusCustom:  lipgloss.NewStyle().Foreground(lipgloss.Color("62")),
 		Focused:       lipgloss.NewStyle().Foreground(lipgloss.Color("22")).Bold(true),
 		Dim:           lipgloss.NewStyle().Foreground(lipgloss.Color("242")),
 		Highlight:     lipgloss.NewStyle().Foreground(lipgloss.Color("30")),
@@ -182,6 +206,24 @@ func NewFrameStyles() FrameStyles {
 
 var Frames = NewFrameStyles()
 
+// StatusStyle returns the style used to show a goal status.
+func (t Theme) StatusStyle(status models.GoalStatus) lipgloss.Style {
+	switch status {
+	case models.GoalStatusPending, models.GoalStatusArchived:
+		return t.Dim
+	case models.GoalStatusInProgress:
+		return t.StatusActive
+	case models.GoalStatusReview:
+		return t.StatusReview
+	case models.GoalStatusCompleted:
+		return t.CompletedGoal.UnsetStrikethrough()
+	case models.GoalStatusBlocked:
+		return t.TagBlocked
+	default:
+		return t.StatusCustom
+	}
+}
+
 func ResolveTheme(name string) Theme {
 	if t, ok := Themes[name]; ok {
 		return t
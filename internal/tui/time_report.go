@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akyairhashvil/SSPT/internal/database"
+	"github.com/akyairhashvil/SSPT/internal/util"
+)
+
+func formatHours(d time.Duration) string {
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
+
+// markdownCell escapes a value for a markdown table cell.
+func markdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// FormatTimeReportMarkdown renders a time report grouped by g as a markdown
+// table. Grouped by goal, each row also lists the goal's workspace, tags
+// and priority.
+func FormatTimeReportMarkdown(r database.TimeReport, g database.TimeGroup) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Time Report: %s to %s\n\n", r.From, r.To)
+	fmt.Fprintf(&b, "Total: %s (%s h) over %d goal(s), by %s\n\n", FormatDuration(r.Total()), formatHours(r.Total()), len(r.Entries), g)
+	if len(r.Entries) == 0 {
+		b.WriteString("*No time tracked.*\n")
+		return b.String()
+	}
+	if g == database.TimeByGoal {
+		b.WriteString("| Goal | Workspace | Tags | Priority | Time | Hours |\n")
+		b.WriteString("|---|---|---|---|---|---:|\n")
+		for _, e := range r.Entries {
+			tags := make([]string, len(e.Tags))
+			for i, tag := range e.Tags {
+				tags[i] = "#" + tag
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | P%d | %s | %s |\n",
+				markdownCell(e.Description), markdownCell(e.Workspace), strings.Join(tags, " "), e.Priority, FormatDuration(e.Duration), formatHours(e.Duration))
+		}
+		return b.String()
+	}
+	title := strings.ToUpper(g.String()[:1]) + g.String()[1:]
+	fmt.Fprintf(&b, "| %s | Goals | Time | Hours |\n", title)
+	b.WriteString("|---|---:|---|---:|\n")
+	for _, row := range r.Group(g) {
+		fmt.Fprintf(&b, "| %s | %d | %s | %s |\n", markdownCell(row.Key), row.Goals, FormatDuration(row.Duration), formatHours(row.Duration))
+	}
+	return b.String()
+}
+
+// WriteTimeReportCSV writes a time report grouped by g as CSV with a header
+// row, for timesheets.
+func WriteTimeReportCSV(w io.Writer, r database.TimeReport, g database.TimeGroup) error {
+	out := csv.NewWriter(w)
+	if g == database.TimeByGoal {
+		if err := out.Write([]string{"goal_id", "goal", "workspace", "tags", "priority", "hours", "seconds"}); err != nil {
+			return err
+		}
+		for _, e := range r.Entries {
+			record := []string{
+				strconv.FormatInt(e.GoalID, 10), e.Description, e.Workspace, strings.Join(e.Tags, " "),
+				strconv.Itoa(e.Priority), formatHours(e.Duration), strconv.Itoa(int(e.Duration.Seconds())),
+			}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := out.Write([]string{g.String(), "goals", "hours", "seconds"}); err != nil {
+			return err
+		}
+		for _, row := range r.Group(g) {
+			record := []string{row.Key, strconv.Itoa(row.Goals), formatHours(row.Duration), strconv.Itoa(int(row.Duration.Seconds()))}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ExportTimeReport writes a time report grouped by g as markdown and CSV to
+// the reports directory and returns the markdown path.
+func ExportTimeReport(r database.TimeReport, g database.TimeGroup) (string, error) {
+	reportRoot := util.ReportsDir("sspt")
+	if err := os.MkdirAll(reportRoot, 0o755); err != nil {
+		return "", err
+	}
+	base := filepath.Join(reportRoot, fmt.Sprintf("time_%s_%s_%s", r.From, r.To, g))
+	if err := os.WriteFile(base+".md", []byte(FormatTimeReportMarkdown(r, g)), 0o644); err != nil {
+		return "", err
+	}
+	f, err := os.Create(base + ".csv")
+	if err != nil {
+		return "", err
+	}
+	if err := WriteTimeReportCSV(f, r, g); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return filepath.Abs(base + ".md")
+}
@@ -91,6 +91,8 @@ func buildNormalModeRegistry() *HandlerRegistry {
 	register("down", wrapKeyHandler(DashboardModel.handleArrowKeys), "", 0)
 	register("j", wrapKeyHandler(DashboardModel.handleArrowKeys), "", 0)
 	register("G", wrapKeyHandler(DashboardModel.handleScrolling), "Graph", 0)
+	register("i", wrapKeyHandler(DashboardModel.handleGoalDetails), "Details", 0)
+	register("?", wrapKeyHandler(DashboardModel.handleHelpToggle), "", 0)
 
 	// Goal operations.
 	register("n", DashboardModel.handleGoalCreate, "New", 0)
@@ -104,19 +106,35 @@ func buildNormalModeRegistry() *HandlerRegistry {
 	register("P", DashboardModel.handleGoalPriority, "Priority", 0)
 	register("J", DashboardModel.handleGoalJournalStart, "Journal", 0)
 	register("ctrl+j", DashboardModel.handleGoalJournalStart, "", 0)
+	register("V", DashboardModel.handleJournalSelect, "Edit log", 0)
+	register("H", DashboardModel.handleJournalBrowser, "Journal log", 0)
 	register("A", DashboardModel.handleGoalArchive, "Archive", 0)
 	register("u", DashboardModel.handleGoalArchive, "Unarchive", 0)
 	register("D", DashboardModel.handleGoalDependencyPicker, "Deps", 0)
+	register("g", DashboardModel.handleDependencyGraph, "Dep graph", 0)
 	register("R", DashboardModel.handleGoalRecurrencePicker, "Repeat", 0)
 	register(" ", DashboardModel.handleGoalStatusToggle, "", 0)
+	register("]", DashboardModel.handleGoalStatusStep, "Next status", 0)
+	register("[", DashboardModel.handleGoalStatusStep, "Prev status", 0)
+	register("shift+right", DashboardModel.handleGoalStatusStep, "Card right", 0)
+	register("shift+left", DashboardModel.handleGoalStatusStep, "Card left", 0)
 	register("t", DashboardModel.handleGoalTagging, "Tag", 0)
+	register("o", DashboardModel.handleGoalLinks, "Links", 0)
+	register("E", DashboardModel.handleGoalNotesEdit, "Notes", 0)
+	register("X", DashboardModel.handleGoalCompleteAt, "Done at", 0)
+	register("O", DashboardModel.handleCarryOverReview, "Carry-over", 0)
+	register("r", DashboardModel.handleSprintRetro, "Retro", 0)
 
 	// Sprint operations.
 	register("s", DashboardModel.handleSprintPause, "", 10)
 	register("s", DashboardModel.handleSprintStart, "", 5)
 	register("x", DashboardModel.handleSprintReset, "", 0)
+	register("B", DashboardModel.handleSprintCorrect, "Backfill", 0)
+	register("S", DashboardModel.handleAutoPlan, "Auto-plan", 0)
 
 	// Workspace operations.
+	register("+", DashboardModel.handleKanbanWIPLimit, "", 10)
+	register("-", DashboardModel.handleKanbanWIPLimit, "", 10)
 	register("+", DashboardModel.handleWorkspaceSprintCount, "Sprint", 0)
 	register("-", DashboardModel.handleWorkspaceSprintCount, "Sprint", 0)
 	register("w", DashboardModel.handleWorkspaceSwitch, "Cycle", 0)
@@ -125,8 +143,14 @@ func buildNormalModeRegistry() *HandlerRegistry {
 	register("c", DashboardModel.handleWorkspaceVisibility, "Completed", 0)
 	register("a", DashboardModel.handleWorkspaceVisibility, "Archived", 0)
 	register("v", DashboardModel.handleWorkspaceViewMode, "View", 0)
+	register("K", DashboardModel.handleBoardMode, "Board", 0)
+	register("ctrl+w", DashboardModel.handleWeekView, "Week", 0)
+	register("M", DashboardModel.handleAnalyticsView, "Analytics", 0)
 	register("Y", DashboardModel.handleWorkspaceTheme, "Theme", 0)
+	register("F", DashboardModel.handleWorkspaceWorkflow, "Workflow", 0)
 	register("I", DashboardModel.handleWorkspaceSeedImport, "Import", 0)
+	register("U", DashboardModel.handleCSVImport, "CSV import", 0)
+	register("ctrl+o", DashboardModel.handleCalendarFile, "Calendar", 0)
 	register("ctrl+r", DashboardModel.handleWorkspaceReport, "Report", 0)
 
 	// Global controls.
@@ -134,6 +158,7 @@ func buildNormalModeRegistry() *HandlerRegistry {
 	register("ctrl+c", handleNormalQuit, "", 0)
 	register("L", handleNormalLock, "Lock", 0)
 	register("ctrl+e", handleNormalExport, "Export", 0)
+	register("ctrl+x", handleNormalCSVExport, "CSV export", 0)
 	register("/", handleNormalSearch, "Search", 0)
 	register("C", handleNormalClearDB, "Clear DB", 0)
 	register("p", handleNormalPassphrase, "Passphrase", 0)
@@ -177,6 +202,16 @@ func handleNormalExport(m DashboardModel, _ string) (DashboardModel, tea.Cmd, bo
 	return m, nil, true
 }
 
+func handleNormalCSVExport(m DashboardModel, _ string) (DashboardModel, tea.Cmd, bool) {
+	dir, err := ExportCSV(m.ctx, m.db)
+	if err != nil {
+		m.Message = fmt.Sprintf("CSV export failed: %v", err)
+	} else {
+		m.Message = fmt.Sprintf("CSV export saved: %s", dir)
+	}
+	return m, nil, true
+}
+
 func handleNormalSearch(m DashboardModel, _ string) (DashboardModel, tea.Cmd, bool) {
 	m.search.Active = true
 	m.search.ArchiveOnly = m.view.focusedColIdx < len(m.sprints) && m.sprints[m.view.focusedColIdx].SprintNumber == -2
@@ -220,6 +255,18 @@ func handleNormalPrevDay(m DashboardModel, _ string) (DashboardModel, tea.Cmd, b
 	prevID, _, err := m.db.GetAdjacentDay(m.ctx, m.day.ID, -1)
 	if err == nil {
 		m.refreshData(prevID)
+	} else if len(m.workspaces) > 0 {
+		// Before the first recorded day: offer to backfill the one before.
+		day, parseErr := time.Parse(util.DateLayout, m.day.Date)
+		if parseErr != nil {
+			day = time.Now()
+		}
+		prev := day.AddDate(0, 0, -1).Format(util.DateLayout)
+		if !isPastDay(prev) {
+			prev = time.Now().AddDate(0, 0, -1).Format(util.DateLayout)
+		}
+		m = m.openPlanDay(prev)
+		m.Message = fmt.Sprintf("No previous days recorded. Backfill %s?", prev)
 	} else {
 		m.Message = "No previous days recorded."
 	}
@@ -230,6 +277,18 @@ func handleNormalNextDay(m DashboardModel, _ string) (DashboardModel, tea.Cmd, b
 	nextID, _, err := m.db.GetAdjacentDay(m.ctx, m.day.ID, 1)
 	if err == nil {
 		m.refreshData(nextID)
+	} else if len(m.workspaces) > 0 {
+		// Past the last recorded day: offer to plan the next one.
+		day, parseErr := time.Parse(util.DateLayout, m.day.Date)
+		if parseErr != nil {
+			day = time.Now()
+		}
+		next := day.AddDate(0, 0, 1).Format(util.DateLayout)
+		if !isFutureDay(next) {
+			next = time.Now().AddDate(0, 0, 1).Format(util.DateLayout)
+		}
+		m = m.openPlanDay(next)
+		m.Message = fmt.Sprintf("No future days recorded. Plan %s?", next)
 	} else {
 		m.Message = "No future days recorded."
 	}
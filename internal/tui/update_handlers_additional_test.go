@@ -62,11 +62,18 @@ func TestHandleNormalModeDayNavigation(t *testing.T) {
 	if m.Message == "" {
 		t.Fatalf("expected previous day message")
 	}
+	if state, ok := m.modal.PlanDayState(); !ok || !state.Backfill {
+		t.Fatalf("expected backfill prompt before the first day")
+	}
+	m.modal.Close()
 	m.Message = ""
 	m, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
 	if m.Message == "" {
 		t.Fatalf("expected next day message")
 	}
+	if !m.modal.Is(ModalPlanDay) {
+		t.Fatalf("expected plan day prompt past the last day")
+	}
 }
 
 func TestHandleNormalModeDispatchesGoalCreate(t *testing.T) {
@@ -3,7 +3,9 @@ package tui
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/akyairhashvil/SSPT/internal/models"
 	"github.com/akyairhashvil/SSPT/internal/util"
@@ -70,6 +72,36 @@ func (m DashboardModel) handleMoveMode(msg tea.Msg) (DashboardModel, tea.Cmd) {
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyEsc {
 			m.modal.Close()
+			m.inputs.textInput.Reset()
+			return m, nil
+		}
+		if state, ok := m.modal.GoalMoveState(); ok && state.Dating {
+			if msg.Type != tea.KeyEnter {
+				var cmd tea.Cmd
+				m.inputs.textInput, cmd = m.inputs.textInput.Update(msg)
+				return m, cmd
+			}
+			date, err := parseDeferDate(m.inputs.textInput.Value(), time.Now())
+			if err != nil {
+				m.setStatusError(err.Error())
+				return m, nil
+			}
+			m.modal.Close()
+			m.inputs.textInput.Reset()
+			return m.moveFocusedGoalToDate(date), nil
+		}
+		switch msg.String() {
+		case "t":
+			m.modal.Close()
+			return m.moveFocusedGoalToDate(time.Now().AddDate(0, 0, 1).Format(util.DateLayout)), nil
+		case "d":
+			if state, ok := m.modal.GoalMoveState(); ok {
+				state.Dating = true
+				m.inputs.textInput.Reset()
+				m.inputs.textInput.Placeholder = "YYYY-MM-DD or +days"
+				m.inputs.textInput.SetValue(time.Now().AddDate(0, 0, 1).Format(util.DateLayout))
+				m.inputs.textInput.Focus()
+			}
 			return m, nil
 		}
 		if len(msg.String()) == 1 && strings.Contains("012345678", msg.String()) {
@@ -109,6 +141,27 @@ func (m DashboardModel) handleMoveMode(msg tea.Msg) (DashboardModel, tea.Cmd) {
 	return m, nil
 }
 
+// moveFocusedGoalToDate moves the focused goal into the first open sprint of
+// a future day, planning that day if needed.
+func (m DashboardModel) moveFocusedGoalToDate(date string) DashboardModel {
+	goal, ok := m.focusedGoal()
+	if !ok {
+		return m
+	}
+	number, err := m.db.MoveGoalToDate(m.ctx, goal.ID, m.workspaces[m.activeWorkspaceIdx].ID, date)
+	if err != nil {
+		m.setStatusError(fmt.Sprintf("Error moving goal: %v", err))
+		return m
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if m.view.focusedGoalIdx > 0 {
+		m.view.focusedGoalIdx--
+	}
+	m.Message = fmt.Sprintf("Moved #%d to %s sprint %d", goal.ID, date, number)
+	return m
+}
+
 func (m DashboardModel) handleGoalExpandCollapse(key string) (DashboardModel, tea.Cmd, bool) {
 	if key != "z" {
 		return m, nil, false
@@ -266,17 +319,20 @@ func (m DashboardModel) handleGoalRecurrencePicker(key string) (DashboardModel,
 			MonthDayOptions: m.modal.monthDayOptions,
 		}
 		if target.RecurrenceRule != nil {
-			rule := strings.ToLower(strings.TrimSpace(*target.RecurrenceRule))
-			switch {
-			case rule == "daily":
+			state.Rule = *target.RecurrenceRule
+		}
+		if rule, err := util.ParseRecurrence(state.Rule); err == nil {
+			state.Interval = rule.Interval
+			state.Missed = rule.Missed
+			state.Sprint = rule.Sprint
+			switch rule.Freq {
+			case util.FreqDaily:
 				state.Mode = "daily"
-			case strings.HasPrefix(rule, "weekly:"):
+			case util.FreqWeekly:
 				state.Mode = "weekly"
-				parts := strings.Split(strings.TrimPrefix(rule, "weekly:"), ",")
-				for _, p := range parts {
-					p = strings.TrimSpace(p)
-					if p != "" {
-						state.Selected[p] = true
+				for _, wd := range rule.Weekdays {
+					if idx := (int(wd) + 6) % 7; idx < len(state.WeekdayOptions) {
+						state.Selected[state.WeekdayOptions[idx]] = true
 					}
 				}
 				for i, d := range state.WeekdayOptions {
@@ -285,38 +341,20 @@ func (m DashboardModel) handleGoalRecurrencePicker(key string) (DashboardModel,
 						break
 					}
 				}
-			case strings.HasPrefix(rule, "monthly:"):
+			case util.FreqMonthly:
 				state.Mode = "monthly"
-				payload := strings.TrimPrefix(rule, "monthly:")
-				var months []string
-				var days []string
-				if strings.Contains(payload, "months=") || strings.Contains(payload, "days=") {
-					chunks := strings.Split(payload, ";")
-					for _, chunk := range chunks {
-						chunk = strings.TrimSpace(chunk)
-						switch {
-						case strings.HasPrefix(chunk, "months="):
-							months = strings.Split(strings.TrimPrefix(chunk, "months="), ",")
-						case strings.HasPrefix(chunk, "days="):
-							days = strings.Split(strings.TrimPrefix(chunk, "days="), ",")
-						}
-					}
-				} else if payload != "" {
-					months = strings.Split(payload, ",")
-				}
-				for _, mo := range months {
-					mo = strings.TrimSpace(mo)
-					if mo != "" {
-						state.Selected[mo] = true
+				for _, mo := range rule.Months {
+					if idx := int(mo) - 1; idx < len(state.MonthOptions) {
+						state.Selected[state.MonthOptions[idx]] = true
 					}
 				}
+				days := rule.MonthDays
 				if len(days) == 0 {
-					days = []string{"1"}
+					days = []int{1}
 				}
 				for _, d := range days {
-					d = strings.TrimSpace(d)
-					if d != "" {
-						state.Selected["day:"+d] = true
+					if d > 0 {
+						state.Selected["day:"+strconv.Itoa(d)] = true
 					}
 				}
 				for i, mo := range state.MonthOptions {
@@ -378,6 +416,71 @@ func (m DashboardModel) handleGoalTagging(key string) (DashboardModel, tea.Cmd,
 	return m, nil, false
 }
 
+// handleGoalStatusStep moves the focused goal one status forward ("]") or
+// back ("[") along the workspace workflow. On the Kanban board this moves
+// the card to the next column, within the column's WIP limit.
+func (m DashboardModel) handleGoalStatusStep(key string) (DashboardModel, tea.Cmd, bool) {
+	switch key {
+	case "]", "[":
+	case "shift+right":
+		key = "]"
+	case "shift+left":
+		key = "["
+	default:
+		return m, nil, false
+	}
+	goal, ok := m.focusedGoal()
+	if !ok {
+		return m, nil, true
+	}
+	switch goal.Status {
+	case models.GoalStatusBlocked:
+		m.Message = "Blocked by dependency. Complete dependencies first."
+		return m, nil, true
+	case models.GoalStatusArchived:
+		m.Message = "Unarchive the goal to change its status."
+		return m, nil, true
+	}
+	wf := m.workflow()
+	next, ok := wf.Next(goal.Status)
+	if key == "[" {
+		next, ok = wf.Prev(goal.Status)
+	}
+	if !ok {
+		m.Message = fmt.Sprintf("Goal is already %s", goal.Status.Label())
+		return m, nil, true
+	}
+	if next == models.GoalStatusCompleted {
+		for _, sub := range goal.Subtasks {
+			if sub.Status != models.GoalStatusCompleted {
+				m.Message = "Cannot complete task with pending subtasks!"
+				return m, nil, true
+			}
+		}
+	}
+	kanban := m.kanbanMode()
+	if kanban && goal.Level == 0 {
+		if limit, full := m.kanbanWIPFull(next); full {
+			m.Message = fmt.Sprintf("WIP limit reached for %s (%d)", next.Label(), limit)
+			return m, nil, true
+		}
+	}
+	if err := m.db.UpdateGoalStatus(m.ctx, goal.ID, next); err != nil {
+		m.setStatusError(fmt.Sprintf("Error updating goal status: %v", err))
+		return m, nil, true
+	}
+	m.invalidateGoalCache()
+	m.refreshData(m.day.ID)
+	if kanban {
+		m, _ = m.focusGoalOnBoard(goal.ID)
+		m.scrollToKanbanColumn()
+	}
+	if m.Message == "" {
+		m.Message = fmt.Sprintf("#%d → %s", goal.ID, next.Label())
+	}
+	return m, nil, true
+}
+
 func (m DashboardModel) handleGoalStatusToggle(key string) (DashboardModel, tea.Cmd, bool) {
 	if key != " " {
 		return m, nil, false
@@ -392,7 +495,7 @@ func (m DashboardModel) handleGoalStatusToggle(key string) (DashboardModel, tea.
 			return m, nil, true
 		}
 		canToggle := true
-		if goal.Status == models.GoalStatusPending {
+		if goal.Status != models.GoalStatusCompleted {
 			for _, sub := range goal.Subtasks {
 				if sub.Status != models.GoalStatusCompleted {
 					canToggle = false
@@ -402,7 +505,7 @@ func (m DashboardModel) handleGoalStatusToggle(key string) (DashboardModel, tea.
 		}
 		if canToggle {
 			newStatus := models.GoalStatusPending
-			if goal.Status == models.GoalStatusPending {
+			if goal.Status != models.GoalStatusCompleted {
 				newStatus = models.GoalStatusCompleted
 			}
 			if err := m.db.UpdateGoalStatus(m.ctx, goal.ID, newStatus); err != nil {
@@ -24,6 +24,25 @@ func (m DashboardModel) handleModalCancel(msg tea.KeyMsg) (DashboardModel, tea.C
 	if msg.Type != tea.KeyEsc {
 		return m, nil, false
 	}
+	if state, ok := m.modal.LinksState(); ok && state.Editing && len(state.Links) > 0 {
+		state.Editing = false
+		state.EditIdx = -1
+		m.inputs.textInput.Reset()
+		return m, nil, true
+	}
+	if state, ok := m.modal.AnalyticsState(); ok && state.Editing != analyticsInputNone {
+		state.Editing = analyticsInputNone
+		m.inputs.textInput.Reset()
+		return m, nil, true
+	}
+	if state, ok := m.modal.CSVImportState(); ok && state.Step != csvImportPath {
+		return m.backCSVImport(state), nil, true
+	}
+	if state, ok := m.modal.WeekState(); ok && state.Carry != nil {
+		state.Carry = nil
+		m.Message = "Move cancelled"
+		return m, nil, true
+	}
 	m.modal.Close()
 	m.security.confirmingClearDB = false
 	m.security.clearDBNeedsPass = false
@@ -61,6 +80,21 @@ func (m DashboardModel) handleModalConfirm(msg tea.KeyMsg) (DashboardModel, tea.
 		DashboardModel.handleModalConfirmTheme,
 		DashboardModel.handleModalConfirmDependencies,
 		DashboardModel.handleModalConfirmRecurrence,
+		DashboardModel.handleModalConfirmTemplate,
+		DashboardModel.handleModalConfirmCarryOver,
+		DashboardModel.handleModalConfirmLinks,
+		DashboardModel.handleModalConfirmJournalSelect,
+		DashboardModel.handleModalConfirmJournalBrowser,
+		DashboardModel.handleModalConfirmRetro,
+		DashboardModel.handleModalConfirmDepGraph,
+		DashboardModel.handleModalConfirmWeek,
+		DashboardModel.handleModalConfirmPlanDay,
+		DashboardModel.handleModalConfirmCorrection,
+		DashboardModel.handleModalConfirmAnalytics,
+		DashboardModel.handleModalConfirmCSVImport,
+		DashboardModel.handleModalConfirmCalendarFile,
+		DashboardModel.handleModalConfirmAutoPlan,
+		DashboardModel.handleModalConfirmWorkflow,
 		DashboardModel.handleModalConfirmGoalEdit,
 	}
 	for _, handler := range handlers {
@@ -82,6 +116,18 @@ func (m DashboardModel) handleModalInput(msg tea.Msg) (DashboardModel, tea.Cmd)
 		DashboardModel.handleModalInputTagging,
 		DashboardModel.handleModalInputSearch,
 		DashboardModel.handleModalInputJournaling,
+		DashboardModel.handleModalInputTemplate,
+		DashboardModel.handleModalInputCarryOver,
+		DashboardModel.handleModalInputLinks,
+		DashboardModel.handleModalInputJournalSelect,
+		DashboardModel.handleModalInputJournalBrowser,
+		DashboardModel.handleModalInputRetro,
+		DashboardModel.handleModalInputDepGraph,
+		DashboardModel.handleModalInputWeek,
+		DashboardModel.handleModalInputAnalytics,
+		DashboardModel.handleModalInputCSVImport,
+		DashboardModel.handleModalInputCalendarFile,
+		DashboardModel.handleModalInputAutoPlan,
 		DashboardModel.handleModalInputGoalText,
 	}
 	for _, handler := range handlers {
@@ -3,6 +3,9 @@ package tui
 import "github.com/akyairhashvil/SSPT/internal/models"
 
 func (m DashboardModel) handleTabFocus(key string) (DashboardModel, bool) {
+	if m.kanbanMode() {
+		return m.handleKanbanTabFocus(key)
+	}
 	switch key {
 	case "tab", "right", "l":
 		nextIdx := -1
@@ -32,6 +35,26 @@ func (m DashboardModel) handleTabFocus(key string) (DashboardModel, bool) {
 	return m, false
 }
 
+// handleKanbanTabFocus moves between the status columns of the Kanban board.
+func (m DashboardModel) handleKanbanTabFocus(key string) (DashboardModel, bool) {
+	step := 0
+	switch key {
+	case "tab", "right", "l":
+		step = 1
+	case "shift+tab", "left", "h":
+		step = -1
+	default:
+		return m, false
+	}
+	next := m.view.focusedColIdx + step
+	if !m.onBoard(next) {
+		return m, true
+	}
+	m.view.focusedColIdx, m.view.focusedGoalIdx = next, 0
+	m.scrollToKanbanColumn()
+	return m, true
+}
+
 func (m DashboardModel) handleArrowKeys(key string) (DashboardModel, bool) {
 	switch key {
 	case "up", "k":
@@ -48,11 +71,31 @@ func (m DashboardModel) handleArrowKeys(key string) (DashboardModel, bool) {
 	return m, false
 }
 
+func (m DashboardModel) handleGoalDetails(key string) (DashboardModel, bool) {
+	if key != "i" {
+		return m, false
+	}
+	m.showDetails = !m.showDetails
+	m.showAnalytics = false
+	return m, true
+}
+
+// handleHelpToggle expands the footer to every key binding, or shrinks it
+// back to the lines that fit.
+func (m DashboardModel) handleHelpToggle(key string) (DashboardModel, bool) {
+	if key != "?" {
+		return m, false
+	}
+	m.showFullHelp = !m.showFullHelp
+	return m, true
+}
+
 func (m DashboardModel) handleScrolling(key string) (DashboardModel, bool) {
 	if key != "G" {
 		return m, false
 	}
 	m.showAnalytics = !m.showAnalytics
+	m.showDetails = false
 	m.search.Active = false
 	if m.modal.Is(ModalJournaling) {
 		m.modal.Close()
@@ -12,15 +12,23 @@ func (m DashboardModel) handleSprintCompletion() (DashboardModel, bool) {
 	if !m.hasActiveSprint() {
 		return m, false
 	}
-	if err := m.db.CompleteSprint(m.ctx, m.timer.ActiveSprint.ID); err != nil {
+	completed := *m.timer.ActiveSprint
+	if err := m.db.CompleteSprint(m.ctx, completed.ID); err != nil {
 		m.setStatusError(fmt.Sprintf("Error completing sprint: %v", err))
 		return m, true
 	}
-	if err := m.db.MovePendingToBacklog(m.ctx, m.timer.ActiveSprint.ID); err != nil {
+	if err := m.db.MovePendingToBacklog(m.ctx, completed.ID); err != nil {
 		m.setStatusError(fmt.Sprintf("Error moving pending tasks: %v", err))
 	}
 	m.timer.ActiveSprint, m.timer.BreakActive, m.timer.BreakStart = nil, true, time.Now()
 	m.refreshData(m.day.ID)
+	if m.retroPromptEnabled() {
+		if m.modal.IsOpen() {
+			m.Message = fmt.Sprintf("Sprint %d complete, press r for a retrospective", completed.SprintNumber)
+		} else {
+			m = m.openRetro(completed.Sprint)
+		}
+	}
 	return m, true
 }
 
@@ -62,11 +70,22 @@ func (m DashboardModel) handleSprintStart(key string) (DashboardModel, tea.Cmd,
 	if target.SprintNumber <= 0 {
 		return m, nil, true
 	}
+	if isFutureDay(m.day.Date) {
+		m.Message = fmt.Sprintf("Sprint is planned for %s", m.day.Date)
+		return m, nil, true
+	}
+	if isPastDay(m.day.Date) && target.Status == models.StatusPending {
+		m.Message = "Past sprint: press B to record its times"
+		return m, nil, true
+	}
 	if !m.hasActiveSprint() && (target.Status == models.StatusPending || target.Status == models.StatusPaused) {
 		if err := m.db.StartSprint(m.ctx, target.ID); err != nil {
 			m.setStatusError(fmt.Sprintf("Error starting sprint: %v", err))
 		} else {
 			m.refreshData(m.day.ID)
+			if warning := m.meetingWarning(target.Sprint, time.Now()); warning != "" {
+				m.Message = warning
+			}
 			return m, tickCmd(), true
 		}
 		return m, nil, true
@@ -0,0 +1,291 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICalTimeLayout is the UTC date-time form used in iCalendar properties.
+const ICalTimeLayout = "20060102T150405Z"
+
+// ICalDateLayout is the form of iCalendar DATE values.
+const ICalDateLayout = "20060102"
+
+// icalLineOctets is the longest content line before folding (RFC 5545).
+const icalLineOctets = 75
+
+// ICalBuilder writes an iCalendar stream: CRLF line endings, long lines
+// folded and text values escaped.
+type ICalBuilder struct {
+	b strings.Builder
+}
+
+// Prop writes a property with a raw value, e.g. Prop("DTSTART;VALUE=DATE", "20240101").
+func (c *ICalBuilder) Prop(name, value string) {
+	line := name + ":" + value
+	for len(line) > icalLineOctets {
+		cut := icalLineOctets
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		c.b.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	c.b.WriteString(line + "\r\n")
+}
+
+// Text writes a property with an escaped text value.
+func (c *ICalBuilder) Text(name, value string) {
+	c.Prop(name, ICalEscape(value))
+}
+
+// Time writes a property with a UTC date-time value.
+func (c *ICalBuilder) Time(name string, t time.Time) {
+	c.Prop(name, t.UTC().Format(ICalTimeLayout))
+}
+
+// String returns the stream written so far.
+func (c *ICalBuilder) String() string {
+	return c.b.String()
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICalEscape escapes a TEXT value.
+func ICalEscape(value string) string {
+	return icalEscaper.Replace(value)
+}
+
+// ICalEvent is a busy timed VEVENT read from an iCalendar file. Recurring
+// events keep their rule and the starts excluded from it; ICalEventsOn
+// expands them into single occurrences.
+type ICalEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	Rule    *Recurrence
+	Except  []time.Time
+}
+
+// ParseICal reads the events of an iCalendar stream that block time.
+// Cancelled, transparent (free) and all-day events are skipped, as are
+// events without an end or duration and events whose times cannot be read.
+// Properties of components nested in an event, such as alarms, are ignored.
+// Modified occurrences of a recurring event replace the occurrence they were
+// moved from. A recurrence rule that cannot be parsed leaves only the first
+// occurrence.
+func ParseICal(r io.Reader) ([]ICalEvent, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+
+	var events []ICalEvent
+	moved := make(map[string][]time.Time)
+	var event *ICalEvent
+	var skip bool
+	var nested int
+	var duration time.Duration
+	for _, line := range lines {
+		name, params, value := splitICalLine(line)
+		if name == "BEGIN" && strings.EqualFold(value, "VEVENT") {
+			event, skip, nested, duration = &ICalEvent{}, false, 0, 0
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		if nested > 0 || name == "BEGIN" {
+			switch name {
+			case "BEGIN":
+				nested++
+			case "END":
+				nested--
+			}
+			continue
+		}
+		switch name {
+		case "END":
+			if strings.EqualFold(value, "VEVENT") {
+				if event.End.IsZero() && duration > 0 {
+					event.End = event.Start.Add(duration)
+				}
+				if !skip && !event.Start.IsZero() && event.End.After(event.Start) {
+					events = append(events, *event)
+				}
+				event = nil
+			}
+		case "UID":
+			event.UID = value
+		case "SUMMARY":
+			event.Summary = icalUnescaper.Replace(value)
+		case "DTSTART", "DTEND", "RECURRENCE-ID", "EXDATE":
+			for _, item := range strings.Split(value, ",") {
+				t, allDay, err := parseICalTime(item, params)
+				if err != nil {
+					// A bad exception date only loses that exception.
+					skip = skip || name != "EXDATE"
+					continue
+				}
+				switch name {
+				case "DTSTART":
+					event.Start, skip = t, skip || allDay
+				case "DTEND":
+					event.End = t
+				case "RECURRENCE-ID":
+					moved[event.UID] = append(moved[event.UID], t)
+				default:
+					event.Except = append(event.Except, t)
+				}
+			}
+		case "DURATION":
+			d, err := parseICalDuration(value)
+			if err != nil {
+				skip = true
+				continue
+			}
+			duration = d
+		case "RRULE":
+			if rule, err := ParseRRULE(value); err == nil {
+				event.Rule = &rule
+			}
+		case "STATUS":
+			skip = skip || strings.EqualFold(value, "CANCELLED")
+		case "TRANSP":
+			skip = skip || strings.EqualFold(value, "TRANSPARENT")
+		}
+	}
+	for i := range events {
+		if events[i].Rule != nil {
+			events[i].Except = append(events[i].Except, moved[events[i].UID]...)
+		}
+	}
+	return events, nil
+}
+
+// ICalEventsOn returns the occurrences of events that overlap the local day
+// of day, sorted by start.
+func ICalEventsOn(events []ICalEvent, day time.Time) []ICalEvent {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	var out []ICalEvent
+	for _, e := range events {
+		length := e.End.Sub(e.Start)
+		starts := []time.Time{e.Start}
+		if e.Rule != nil {
+			starts = nil
+			loc := e.Start.Location()
+			// Occurrences are dated in the event's zone, which may be a day
+			// off the local one.
+			for _, date := range e.Rule.Between(e.Start, dayStart.In(loc).AddDate(0, 0, -2), dayEnd.In(loc).AddDate(0, 0, 1)) {
+				starts = append(starts, time.Date(date.Year(), date.Month(), date.Day(), e.Start.Hour(), e.Start.Minute(), e.Start.Second(), 0, loc))
+			}
+		}
+	occurrences:
+		for _, start := range starts {
+			for _, except := range e.Except {
+				if except.Equal(start) {
+					continue occurrences
+				}
+			}
+			if end := start.Add(length); start.Before(dayEnd) && end.After(dayStart) {
+				out = append(out, ICalEvent{UID: e.UID, Summary: e.Summary, Start: start, End: end})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+var icalUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+// splitICalLine splits a content line into its upper-case name, parameters
+// and value. Colons inside quoted parameter values do not end the name.
+func splitICalLine(line string) (string, map[string]string, string) {
+	quoted := false
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ':' && !quoted:
+			parts := strings.Split(line[:i], ";")
+			params := make(map[string]string)
+			for _, p := range parts[1:] {
+				if key, value, ok := strings.Cut(p, "="); ok {
+					params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+				}
+			}
+			return strings.ToUpper(parts[0]), params, line[i+1:]
+		}
+	}
+	return "", nil, ""
+}
+
+// parseICalTime parses a DATE or DATE-TIME value. Floating times and zones
+// unknown to the system are read as local time.
+func parseICalTime(value string, params map[string]string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(ICalDateLayout) {
+		t, err := time.ParseInLocation(ICalDateLayout, value, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.ParseInLocation(ICalTimeLayout, value, time.UTC)
+		return t, false, err
+	}
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(strings.TrimSuffix(ICalTimeLayout, "Z"), value, loc)
+	return t, false, err
+}
+
+// parseICalDuration parses a DURATION value such as PT1H30M or P1D.
+func parseICalDuration(value string) (time.Duration, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(strings.ToUpper(value), "+"), "P")
+	if rest == strings.ToUpper(value) || rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	var total time.Duration
+	inTime := false
+	num := ""
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+			inTime = true
+		case c >= '0' && c <= '9':
+			num += string(c)
+		default:
+			unit, ok := units[c]
+			n, err := strconv.Atoi(num)
+			if !ok || err != nil || (c == 'M' && !inTime) {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			total += time.Duration(n) * unit
+			num = ""
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return total, nil
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalBuilderFoldsAndEscapes(t *testing.T) {
+	var c ICalBuilder
+	c.Text("SUMMARY", "Plan; review, ship\\done\nnext")
+	c.Time("DTSTART", time.Date(2024, 3, 1, 10, 30, 0, 0, time.FixedZone("X", 3600)))
+	c.Text("DESCRIPTION", strings.Repeat("é", 60))
+	got := c.String()
+	lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+	if lines[0] != `SUMMARY:Plan\; review\, ship\\done\nnext` {
+		t.Fatalf("unexpected escaping %q", lines[0])
+	}
+	if lines[1] != "DTSTART:20240301T093000Z" {
+		t.Fatalf("unexpected time %q", lines[1])
+	}
+	var unfolded string
+	for i, line := range lines[2:] {
+		if len(line) > 75 {
+			t.Fatalf("line %d longer than 75 octets: %d", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Fatalf("expected continuation line, got %q", line)
+			}
+			line = line[1:]
+		}
+		unfolded += line
+	}
+	if unfolded != "DESCRIPTION:"+strings.Repeat("é", 60) {
+		t.Fatalf("folding broke the value: %q", unfolded)
+	}
+}
+
+func TestParseICalEventsOn(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"SUMMARY:Stand",
+		"  up",
+		"DTSTART;TZID=UTC:20240304T090000",
+		"DURATION:PT15M",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT5M",
+		"DURATION:PT2H",
+		"REPEAT:1",
+		"END:VALARM",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"EXDATE;TZID=UTC:20240308T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"RECURRENCE-ID;TZID=UTC:20240306T090000",
+		"SUMMARY:Stand up (moved)",
+		"DTSTART:20240306T130000Z",
+		"DTEND:20240306T131500Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:review",
+		"SUMMARY:Review\\, planning",
+		"DTSTART:20240306T100000Z",
+		"DTEND:20240306T110000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:offsite",
+		"SUMMARY:Offsite",
+		"DTSTART;VALUE=DATE:20240306",
+		"DTEND;VALUE=DATE:20240307",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled",
+		"SUMMARY:Cancelled",
+		"STATUS:CANCELLED",
+		"DTSTART:20240306T150000Z",
+		"DTEND:20240306T160000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	events, err := ParseICal(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ParseICal failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 busy events, got %+v", events)
+	}
+	if length := events[0].End.Sub(events[0].Start); length != 15*time.Minute {
+		t.Fatalf("expected the alarm duration to be ignored, got %s", length)
+	}
+	summaries := func(day time.Time) []string {
+		var out []string
+		for _, e := range ICalEventsOn(events, day) {
+			out = append(out, e.Start.UTC().Format("15:04")+" "+e.Summary)
+		}
+		return out
+	}
+	if got := summaries(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)); strings.Join(got, "|") != "09:00 Stand up" {
+		t.Fatalf("unexpected Monday %v", got)
+	}
+	if got := summaries(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)); strings.Join(got, "|") != "10:00 Review, planning|13:00 Stand up (moved)" {
+		t.Fatalf("unexpected Wednesday %v", got)
+	}
+	if got := summaries(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Fatalf("expected the excluded Friday to be free, got %v", got)
+	}
+	if got := summaries(time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)); strings.Join(got, "|") != "09:00 Stand up" {
+		t.Fatalf("unexpected next Monday %v", got)
+	}
+	events, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:tomorrow\nDTEND:20240306T110000Z\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nSUMMARY:Sync\nDTSTART:20240306T100000Z\nDTEND:20240306T110000Z\nEND:VEVENT\n"))
+	if err != nil || len(events) != 1 || events[0].Summary != "Sync" {
+		t.Fatalf("expected only the malformed event to be skipped, got %+v, %v", events, err)
+	}
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+var linkRegex = regexp.MustCompile(`<([^<>]+)>`)
+
+// linkTargetRegex matches what a <...> marker must hold to be a link: a URL
+// with a scheme (https://, file://, mailto:) or a path starting with /, ~/,
+// ./ or ../.
+var linkTargetRegex = regexp.MustCompile(`^(?:[a-zA-Z][a-zA-Z0-9+.-]*://\S|mailto:\S|~/|\.{1,2}/|/)`)
+
+var spaceRunRegex = regexp.MustCompile(`[ \t]{2,}`)
+
+// ExtractLinks removes <...> link markers from text and returns the remaining
+// text along with the links in order of appearance. Markers that hold neither
+// a URL nor a path are left as text.
+func ExtractLinks(text string) (string, []string) {
+	var links []string
+	rest := linkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		link := strings.TrimSpace(linkRegex.FindStringSubmatch(match)[1])
+		if !linkTargetRegex.MatchString(link) {
+			return match
+		}
+		links = append(links, link)
+		return ""
+	})
+	if len(links) > 0 {
+		rest = spaceRunRegex.ReplaceAllString(rest, " ")
+	}
+	return strings.TrimSpace(rest), links
+}
+
+// JSONToLinks converts a JSON array string of links into a slice.
+func JSONToLinks(jsonStr string) []string {
+	var links []string
+	if jsonStr == "" || jsonStr == "null" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &links); err != nil {
+		return nil
+	}
+	return links
+}
+
+// OpenLink opens a URL or file path with $BROWSER when set, otherwise with the
+// platform opener (xdg-open, open or start). It does not wait for the opener
+// to exit.
+func OpenLink(target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("empty link")
+	}
+	if strings.HasPrefix(target, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			target = home + target[1:]
+		}
+	}
+	var cmd *exec.Cmd
+	if browser := strings.TrimSpace(os.Getenv("BROWSER")); browser != "" {
+		fields := strings.Fields(browser)
+		cmd = exec.Command(fields[0], append(fields[1:], target)...)
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", target)
+		case "windows":
+			cmd = exec.Command("cmd", "/c", "start", "", target)
+		default:
+			cmd = exec.Command("xdg-open", target)
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("open %s: %w", target, err)
+	}
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	rest, links := ExtractLinks("Check <https://a.example> and < /tmp/x y.txt > <>")
+	if rest != "Check and <>" {
+		t.Fatalf("ExtractLinks() rest = %q", rest)
+	}
+	want := []string{"https://a.example", "/tmp/x y.txt"}
+	if !reflect.DeepEqual(links, want) {
+		t.Fatalf("ExtractLinks() links = %v, want %v", links, want)
+	}
+	if got := JSONToLinks(`["a","b"]`); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("JSONToLinks() = %v", got)
+	}
+}
+
+func TestExtractLinksKeepsPlainAngleBrackets(t *testing.T) {
+	rest, links := ExtractLinks("Compare a <b> c with <~/notes/plan.md> and <mailto:me@example.com>")
+	if rest != "Compare a <b> c with and" {
+		t.Fatalf("ExtractLinks() rest = %q", rest)
+	}
+	want := []string{"~/notes/plan.md", "mailto:me@example.com"}
+	if !reflect.DeepEqual(links, want) {
+		t.Fatalf("ExtractLinks() links = %v, want %v", links, want)
+	}
+	if rest, links := ExtractLinks("Keep  spacing <x>"); rest != "Keep  spacing <x>" || links != nil {
+		t.Fatalf("expected text without links unchanged, got %q %v", rest, links)
+	}
+}
@@ -0,0 +1,864 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the calendar date format used for days, due dates and recurrence bounds.
+const DateLayout = "2006-01-02"
+
+// RecurrenceFreq is the base period of a recurrence rule.
+type RecurrenceFreq string
+
+const (
+	FreqDaily   RecurrenceFreq = "daily"
+	FreqWeekly  RecurrenceFreq = "weekly"
+	FreqMonthly RecurrenceFreq = "monthly"
+	FreqYearly  RecurrenceFreq = "yearly"
+)
+
+// MissedPolicy decides what happens to occurrences that passed without the
+// app being opened.
+type MissedPolicy string
+
+const (
+	// MissedCatchUp creates one instance for every missed occurrence.
+	MissedCatchUp MissedPolicy = "catchup"
+	// MissedSkip drops missed occurrences and only creates today's.
+	MissedSkip MissedPolicy = "skip"
+	// MissedCollapse creates a single instance for the latest missed occurrence.
+	MissedCollapse MissedPolicy = "collapse"
+)
+
+// maxRecurrencePeriods bounds the search for the next occurrence so that
+// rules which can never match (e.g. "monthly:months=feb;days=30") terminate.
+const maxRecurrencePeriods = 20000
+
+// NthWeekday selects a weekday within a month, or within the year for yearly
+// rules without months. N counts from the start of the period (1 = first) or,
+// when negative, from the end (-1 = last). N == 0 means every such weekday in
+// the period.
+type NthWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Recurrence is a parsed recurrence rule. It covers the subset of RFC 5545
+// RRULE used by SSPT: DAILY/WEEKLY/MONTHLY/YEARLY frequencies, INTERVAL,
+// BYDAY (with ordinals for monthly/yearly), BYMONTH, BYMONTHDAY, BYSETPOS,
+// UNTIL and COUNT.
+type Recurrence struct {
+	Freq        RecurrenceFreq
+	Interval    int
+	Weekdays    []time.Weekday
+	NthWeekdays []NthWeekday
+	Months      []time.Month
+	MonthDays   []int
+	SetPos      []int
+	Start       time.Time
+	Until       time.Time
+	Count       int
+	// Missed and Sprint are SSPT extensions with no RRULE equivalent: the
+	// missed-occurrence policy and the sprint number that new instances are
+	// placed in (0 = backlog).
+	Missed MissedPolicy
+	Sprint int
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+var monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+// ParseRecurrence parses a stored recurrence rule. Both the native format
+// ("daily", "weekly:mon,tue;interval=2", "monthly:months=jan;days=1,-1",
+// "monthly:weekdays=-1fri;until=2026-12-31") and RFC 5545 RRULE strings
+// ("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO") are accepted.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return Recurrence{}, fmt.Errorf("empty recurrence rule")
+	}
+	upper := strings.ToUpper(rule)
+	if strings.HasPrefix(upper, "RRULE:") || strings.HasPrefix(upper, "FREQ=") || strings.HasPrefix(upper, "DTSTART") {
+		return ParseRRULE(rule)
+	}
+
+	lower := strings.ToLower(rule)
+	head, body := lower, ""
+	if idx := strings.IndexAny(lower, ":;"); idx >= 0 {
+		head, body = lower[:idx], lower[idx+1:]
+	}
+	r := Recurrence{Freq: RecurrenceFreq(strings.TrimSpace(head)), Interval: 1}
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return Recurrence{}, fmt.Errorf("unknown recurrence frequency %q", head)
+	}
+
+	for _, field := range strings.Split(body, ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(field, "=")
+		if !hasValue {
+			// Positional lists: weekdays for weekly rules, months for the
+			// legacy "monthly:jan,feb" form.
+			value = key
+			switch r.Freq {
+			case FreqWeekly:
+				key = "days"
+			case FreqMonthly, FreqYearly:
+				key = "months"
+			default:
+				return Recurrence{}, fmt.Errorf("unexpected recurrence field %q", field)
+			}
+		}
+		if err := r.setField(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return Recurrence{}, err
+		}
+	}
+	return r, r.validate()
+}
+
+func (r *Recurrence) setField(key, value string) error {
+	var err error
+	switch key {
+	case "interval":
+		r.Interval, err = strconv.Atoi(value)
+		if err != nil || r.Interval < 1 {
+			return fmt.Errorf("invalid recurrence interval %q", value)
+		}
+	case "count":
+		r.Count, err = strconv.Atoi(value)
+		if err != nil || r.Count < 1 {
+			return fmt.Errorf("invalid recurrence count %q", value)
+		}
+	case "start":
+		r.Start, err = time.ParseInLocation(DateLayout, value, time.UTC)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence start %q", value)
+		}
+	case "until":
+		r.Until, err = time.ParseInLocation(DateLayout, value, time.UTC)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence until %q", value)
+		}
+	case "months":
+		for _, item := range splitList(value) {
+			mo, ok := parseMonthName(item)
+			if !ok {
+				return fmt.Errorf("invalid recurrence month %q", item)
+			}
+			r.Months = append(r.Months, mo)
+		}
+	case "days":
+		if r.Freq == FreqWeekly || r.Freq == FreqDaily {
+			for _, item := range splitList(value) {
+				wd, ok := parseWeekdayName(item)
+				if !ok {
+					return fmt.Errorf("invalid recurrence weekday %q", item)
+				}
+				r.Weekdays = append(r.Weekdays, wd)
+			}
+			return nil
+		}
+		for _, item := range splitList(value) {
+			if item == "last" {
+				r.MonthDays = append(r.MonthDays, -1)
+				continue
+			}
+			day, err := strconv.Atoi(item)
+			if err != nil || day == 0 || day < -31 || day > 31 {
+				return fmt.Errorf("invalid recurrence month day %q", item)
+			}
+			r.MonthDays = append(r.MonthDays, day)
+		}
+	case "weekdays":
+		for _, item := range splitList(value) {
+			nth, err := parseNthWeekday(item)
+			if err != nil {
+				return err
+			}
+			r.NthWeekdays = append(r.NthWeekdays, nth)
+		}
+	case "missed":
+		switch MissedPolicy(value) {
+		case MissedCatchUp, MissedSkip, MissedCollapse:
+			r.Missed = MissedPolicy(value)
+		default:
+			return fmt.Errorf("invalid missed policy %q", value)
+		}
+	case "sprint":
+		r.Sprint, err = strconv.Atoi(value)
+		if err != nil || r.Sprint < 0 {
+			return fmt.Errorf("invalid recurrence sprint %q", value)
+		}
+	case "setpos":
+		for _, item := range splitList(value) {
+			pos, err := strconv.Atoi(item)
+			if err != nil || pos == 0 {
+				return fmt.Errorf("invalid recurrence setpos %q", item)
+			}
+			r.SetPos = append(r.SetPos, pos)
+		}
+	default:
+		return fmt.Errorf("unknown recurrence field %q", key)
+	}
+	return nil
+}
+
+func (r Recurrence) validate() error {
+	if r.Interval < 1 {
+		return fmt.Errorf("invalid recurrence interval %d", r.Interval)
+	}
+	if len(r.NthWeekdays) > 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		return fmt.Errorf("nth weekdays require a monthly or yearly rule")
+	}
+	if len(r.MonthDays) > 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		return fmt.Errorf("month days require a monthly or yearly rule")
+	}
+	// Ordinals beyond 5 only make sense counted over a whole year.
+	if r.Freq == FreqMonthly || len(r.Months) > 0 {
+		for _, nth := range r.NthWeekdays {
+			if nth.N < -5 || nth.N > 5 {
+				return fmt.Errorf("invalid recurrence weekday ordinal %d within a month", nth.N)
+			}
+		}
+	}
+	return nil
+}
+
+// String renders the rule in the native storage format. Rules produced by the
+// recurrence modal ("daily", "weekly:mon,tue", "monthly:months=jan;days=1")
+// round-trip unchanged.
+func (r Recurrence) String() string {
+	var fields []string
+	switch r.Freq {
+	case FreqWeekly:
+		if len(r.Weekdays) > 0 {
+			fields = append(fields, joinWeekdays(r.Weekdays))
+		}
+	case FreqDaily:
+		if len(r.Weekdays) > 0 {
+			fields = append(fields, "days="+joinWeekdays(r.Weekdays))
+		}
+	}
+	if len(r.Months) > 0 {
+		fields = append(fields, "months="+joinMonths(r.Months))
+	}
+	if len(r.MonthDays) > 0 {
+		days := make([]string, 0, len(r.MonthDays))
+		for _, d := range r.MonthDays {
+			days = append(days, strconv.Itoa(d))
+		}
+		fields = append(fields, "days="+strings.Join(days, ","))
+	}
+	if len(r.NthWeekdays) > 0 {
+		items := make([]string, 0, len(r.NthWeekdays))
+		for _, nth := range r.NthWeekdays {
+			item := weekdayNames[nth.Weekday]
+			if nth.N != 0 {
+				item = strconv.Itoa(nth.N) + item
+			}
+			items = append(items, item)
+		}
+		fields = append(fields, "weekdays="+strings.Join(items, ","))
+	}
+	if len(r.SetPos) > 0 {
+		items := make([]string, 0, len(r.SetPos))
+		for _, pos := range r.SetPos {
+			items = append(items, strconv.Itoa(pos))
+		}
+		fields = append(fields, "setpos="+strings.Join(items, ","))
+	}
+	if r.Interval > 1 {
+		fields = append(fields, "interval="+strconv.Itoa(r.Interval))
+	}
+	if !r.Start.IsZero() {
+		fields = append(fields, "start="+r.Start.Format(DateLayout))
+	}
+	if !r.Until.IsZero() {
+		fields = append(fields, "until="+r.Until.Format(DateLayout))
+	}
+	if r.Count > 0 {
+		fields = append(fields, "count="+strconv.Itoa(r.Count))
+	}
+	if r.Missed != "" {
+		fields = append(fields, "missed="+string(r.Missed))
+	}
+	if r.Sprint > 0 {
+		fields = append(fields, "sprint="+strconv.Itoa(r.Sprint))
+	}
+	if len(fields) == 0 {
+		return string(r.Freq)
+	}
+	return string(r.Freq) + ":" + strings.Join(fields, ";")
+}
+
+// RRULE renders the rule as an RFC 5545 RRULE property value, prefixed with
+// "RRULE:". The start date is not part of RRULE; callers emit it as DTSTART.
+func (r Recurrence) RRULE() string {
+	parts := []string{"FREQ=" + strings.ToUpper(string(r.Freq))}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	var byDay []string
+	for _, wd := range r.Weekdays {
+		byDay = append(byDay, rruleWeekdays[wd])
+	}
+	for _, nth := range r.NthWeekdays {
+		item := rruleWeekdays[nth.Weekday]
+		if nth.N != 0 {
+			item = strconv.Itoa(nth.N) + item
+		}
+		byDay = append(byDay, item)
+	}
+	if len(byDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(byDay, ","))
+	}
+	if len(r.Months) > 0 {
+		items := make([]string, 0, len(r.Months))
+		for _, mo := range r.Months {
+			items = append(items, strconv.Itoa(int(mo)))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(items, ","))
+	}
+	if len(r.MonthDays) > 0 {
+		items := make([]string, 0, len(r.MonthDays))
+		for _, d := range r.MonthDays {
+			items = append(items, strconv.Itoa(d))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(items, ","))
+	}
+	if len(r.SetPos) > 0 {
+		items := make([]string, 0, len(r.SetPos))
+		for _, pos := range r.SetPos {
+			items = append(items, strconv.Itoa(pos))
+		}
+		parts = append(parts, "BYSETPOS="+strings.Join(items, ","))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return "RRULE:" + strings.Join(parts, ";")
+}
+
+// ParseRRULE parses an RFC 5545 recurrence. The input may be a bare RRULE
+// value ("FREQ=DAILY"), a property line ("RRULE:FREQ=DAILY") or a DTSTART
+// line followed by an RRULE line.
+func ParseRRULE(value string) (Recurrence, error) {
+	var r Recurrence
+	var ruleLine string
+	for _, line := range strings.FieldsFunc(value, func(c rune) bool { return c == '\n' || c == '\r' }) {
+		line = strings.TrimSpace(line)
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "DTSTART"):
+			idx := strings.LastIndex(line, ":")
+			if idx < 0 || len(line[idx+1:]) < 8 {
+				return Recurrence{}, fmt.Errorf("invalid DTSTART %q", line)
+			}
+			start, err := time.ParseInLocation("20060102", line[idx+1:idx+9], time.UTC)
+			if err != nil {
+				return Recurrence{}, fmt.Errorf("invalid DTSTART %q", line)
+			}
+			r.Start = start
+		case strings.HasPrefix(upper, "RRULE:"):
+			ruleLine = line[len("RRULE:"):]
+		case strings.HasPrefix(upper, "FREQ="):
+			ruleLine = line
+		}
+	}
+	if ruleLine == "" {
+		return Recurrence{}, fmt.Errorf("missing RRULE in %q", value)
+	}
+
+	r.Interval = 1
+	for _, part := range strings.Split(ruleLine, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		switch key {
+		case "FREQ":
+			r.Freq = RecurrenceFreq(strings.ToLower(val))
+			switch r.Freq {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+			default:
+				return Recurrence{}, fmt.Errorf("unsupported RRULE frequency %q", val)
+			}
+		case "INTERVAL", "COUNT", "BYSETPOS":
+			if err := r.setField(strings.ToLower(strings.TrimPrefix(key, "BY")), val); err != nil {
+				return Recurrence{}, err
+			}
+		case "BYMONTHDAY":
+			for _, item := range splitList(val) {
+				day, err := strconv.Atoi(item)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return Recurrence{}, fmt.Errorf("invalid BYMONTHDAY %q", item)
+				}
+				r.MonthDays = append(r.MonthDays, day)
+			}
+		case "BYMONTH":
+			for _, item := range splitList(val) {
+				mo, err := strconv.Atoi(item)
+				if err != nil || mo < 1 || mo > 12 {
+					return Recurrence{}, fmt.Errorf("invalid BYMONTH %q", item)
+				}
+				r.Months = append(r.Months, time.Month(mo))
+			}
+		case "BYDAY":
+			for _, item := range splitList(val) {
+				nth, err := parseNthWeekday(item)
+				if err != nil {
+					return Recurrence{}, err
+				}
+				r.NthWeekdays = append(r.NthWeekdays, nth)
+			}
+		case "UNTIL":
+			if len(val) < 8 {
+				return Recurrence{}, fmt.Errorf("invalid UNTIL %q", val)
+			}
+			until, err := time.ParseInLocation("20060102", val[:8], time.UTC)
+			if err != nil {
+				return Recurrence{}, fmt.Errorf("invalid UNTIL %q", val)
+			}
+			r.Until = until
+		case "WKST":
+			// Weeks always start on Monday in SSPT.
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported RRULE part %q", key)
+		}
+	}
+	if r.Freq == "" {
+		return Recurrence{}, fmt.Errorf("RRULE missing FREQ")
+	}
+	// Plain weekdays in DAILY/WEEKLY rules are filters, not monthly positions.
+	if r.Freq == FreqDaily || r.Freq == FreqWeekly {
+		for _, nth := range r.NthWeekdays {
+			if nth.N != 0 {
+				return Recurrence{}, fmt.Errorf("ordinal BYDAY requires MONTHLY or YEARLY")
+			}
+			r.Weekdays = append(r.Weekdays, nth.Weekday)
+		}
+		r.NthWeekdays = nil
+	}
+	return r, r.validate()
+}
+
+// NormalizeRecurrence parses a rule in either format and returns its native
+// form. An empty rule normalizes to "".
+func NormalizeRecurrence(rule string) (string, error) {
+	if strings.TrimSpace(rule) == "" {
+		return "", nil
+	}
+	r, err := ParseRecurrence(rule)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// Policy returns the rule's missed-occurrence policy, defaulting to
+// MissedCollapse so that a long absence produces a single instance.
+func (r Recurrence) Policy() MissedPolicy {
+	if r.Missed == "" {
+		return MissedCollapse
+	}
+	return r.Missed
+}
+
+// NeedsAnchor reports whether the rule's occurrences depend on a fixed series
+// start: intervals greater than one and occurrence counts.
+func (r Recurrence) NeedsAnchor() bool {
+	return r.Interval > 1 || r.Count > 0
+}
+
+// Next returns the first occurrence strictly after the given date. The series
+// is anchored at r.Start when set and at anchor otherwise; the anchor is
+// always the first period of the series. It returns false when the series has
+// ended through UNTIL or COUNT.
+func (r Recurrence) Next(anchor, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	afterDate := civilDate(after)
+	r.each(anchor, func(day time.Time) bool {
+		if day.After(afterDate) {
+			next = day
+			found = true
+			return false
+		}
+		return true
+	})
+	if !found {
+		return time.Time{}, false
+	}
+	return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, after.Location()), true
+}
+
+// Between returns the occurrences strictly after the first date and on or
+// before the second, anchored like Next.
+func (r Recurrence) Between(anchor, after, through time.Time) []time.Time {
+	var out []time.Time
+	afterDate := civilDate(after)
+	throughDate := civilDate(through)
+	r.each(anchor, func(day time.Time) bool {
+		if day.After(throughDate) {
+			return false
+		}
+		if day.After(afterDate) {
+			out = append(out, time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, through.Location()))
+		}
+		return true
+	})
+	return out
+}
+
+// each walks occurrences in order, starting at the series anchor, until fn
+// returns false or the series ends.
+func (r Recurrence) each(anchor time.Time, fn func(day time.Time) bool) {
+	start := civilDate(anchor)
+	if !r.Start.IsZero() {
+		start = civilDate(r.Start)
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	emitted := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, day := range r.periodOccurrences(start, period*interval) {
+			if day.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && day.After(civilDate(r.Until)) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted > r.Count {
+				return
+			}
+			if !fn(day) {
+				return
+			}
+		}
+	}
+}
+
+// periodOccurrences lists the sorted occurrences within the period that is
+// offset periods after the one containing start.
+func (r Recurrence) periodOccurrences(start time.Time, offset int) []time.Time {
+	var days []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		day := start.AddDate(0, 0, offset)
+		if r.matchesFilters(day) {
+			days = append(days, day)
+		}
+	case FreqWeekly:
+		monday := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)).AddDate(0, 0, 7*offset)
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{start.Weekday()}
+		}
+		for i := 0; i < 7; i++ {
+			day := monday.AddDate(0, 0, i)
+			if containsWeekday(weekdays, day.Weekday()) && r.matchesMonth(day.Month()) {
+				days = append(days, day)
+			}
+		}
+	case FreqMonthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		if r.matchesMonth(first.Month()) {
+			days = r.monthOccurrences(first.Year(), first.Month(), start.Day())
+		}
+	case FreqYearly:
+		year := start.Year() + offset
+		months := r.Months
+		if len(months) == 0 {
+			if len(r.MonthDays) > 0 || len(r.NthWeekdays) > 0 {
+				days = r.yearOccurrences(year)
+				break
+			}
+			months = []time.Month{start.Month()}
+		}
+		for mo := time.January; mo <= time.December; mo++ {
+			if containsMonth(months, mo) {
+				days = append(days, r.monthOccurrences(year, mo, start.Day())...)
+			}
+		}
+	}
+	return applySetPos(days, r.SetPos)
+}
+
+// yearOccurrences resolves BYMONTHDAY and BYDAY over a whole year, for yearly
+// rules without BYMONTH. Month days match in every month and weekday ordinals
+// count within the year, as in RFC 5545 ("20MO" is the 20th Monday).
+func (r Recurrence) yearOccurrences(year int) []time.Time {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	length := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	byWeekday := map[int]bool{}
+	for _, nth := range r.NthWeekdays {
+		var matches []int
+		for d := 1; d <= length; d++ {
+			if first.AddDate(0, 0, d-1).Weekday() == nth.Weekday {
+				matches = append(matches, d)
+			}
+		}
+		switch {
+		case nth.N == 0:
+			for _, d := range matches {
+				byWeekday[d] = true
+			}
+		case nth.N > 0 && nth.N <= len(matches):
+			byWeekday[matches[nth.N-1]] = true
+		case nth.N < 0 && -nth.N <= len(matches):
+			byWeekday[matches[len(matches)+nth.N]] = true
+		}
+	}
+
+	var days []time.Time
+	for d := 1; d <= length; d++ {
+		day := first.AddDate(0, 0, d-1)
+		if len(r.NthWeekdays) > 0 && !byWeekday[d] {
+			continue
+		}
+		if len(r.MonthDays) > 0 && !matchesMonthDay(r.MonthDays, day) {
+			continue
+		}
+		if len(r.Weekdays) == 0 || containsWeekday(r.Weekdays, day.Weekday()) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// matchesMonthDay reports whether day is one of the month days, counting
+// negative days from the end of its month.
+func matchesMonthDay(monthDays []int, day time.Time) bool {
+	length := daysIn(day.Year(), day.Month())
+	for _, d := range monthDays {
+		if d < 0 {
+			d = length + d + 1
+		}
+		if d == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// monthOccurrences resolves BYMONTHDAY and BYDAY within a single month.
+// When both are present they intersect, as in RFC 5545 ("Friday the 13th").
+func (r Recurrence) monthOccurrences(year int, month time.Month, defaultDay int) []time.Time {
+	length := daysIn(year, month)
+	byMonthDay := map[int]bool{}
+	for _, d := range r.MonthDays {
+		if d < 0 {
+			d = length + d + 1
+		}
+		if d >= 1 && d <= length {
+			byMonthDay[d] = true
+		}
+	}
+	byWeekday := map[int]bool{}
+	for _, nth := range r.NthWeekdays {
+		var matches []int
+		for d := 1; d <= length; d++ {
+			if time.Date(year, month, d, 0, 0, 0, 0, time.UTC).Weekday() == nth.Weekday {
+				matches = append(matches, d)
+			}
+		}
+		switch {
+		case nth.N == 0:
+			for _, d := range matches {
+				byWeekday[d] = true
+			}
+		case nth.N > 0 && nth.N <= len(matches):
+			byWeekday[matches[nth.N-1]] = true
+		case nth.N < 0 && -nth.N <= len(matches):
+			byWeekday[matches[len(matches)+nth.N]] = true
+		}
+	}
+
+	var selected []int
+	switch {
+	case len(r.MonthDays) > 0 && len(r.NthWeekdays) > 0:
+		for d := range byMonthDay {
+			if byWeekday[d] {
+				selected = append(selected, d)
+			}
+		}
+	case len(r.MonthDays) > 0:
+		for d := range byMonthDay {
+			selected = append(selected, d)
+		}
+	case len(r.NthWeekdays) > 0:
+		for d := range byWeekday {
+			selected = append(selected, d)
+		}
+	case defaultDay <= length:
+		selected = append(selected, defaultDay)
+	}
+	sort.Ints(selected)
+
+	days := make([]time.Time, 0, len(selected))
+	for _, d := range selected {
+		day := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+		if len(r.Weekdays) == 0 || containsWeekday(r.Weekdays, day.Weekday()) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func (r Recurrence) matchesFilters(day time.Time) bool {
+	if len(r.Weekdays) > 0 && !containsWeekday(r.Weekdays, day.Weekday()) {
+		return false
+	}
+	return r.matchesMonth(day.Month())
+}
+
+func (r Recurrence) matchesMonth(month time.Month) bool {
+	return len(r.Months) == 0 || containsMonth(r.Months, month)
+}
+
+func applySetPos(days []time.Time, positions []int) []time.Time {
+	if len(positions) == 0 || len(days) == 0 {
+		return days
+	}
+	picked := map[int]bool{}
+	for _, pos := range positions {
+		idx := pos - 1
+		if pos < 0 {
+			idx = len(days) + pos
+		}
+		if idx >= 0 && idx < len(days) {
+			picked[idx] = true
+		}
+	}
+	var out []time.Time
+	for i, day := range days {
+		if picked[i] {
+			out = append(out, day)
+		}
+	}
+	return out
+}
+
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func splitList(value string) []string {
+	var out []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ParseWeekday accepts a weekday abbreviation such as "mon" or "MO".
+func ParseWeekday(value string) (time.Weekday, bool) {
+	return parseWeekdayName(value)
+}
+
+func parseWeekdayName(value string) (time.Weekday, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, name := range weekdayNames {
+		if value == name || value == strings.ToLower(rruleWeekdays[i]) {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+func parseMonthName(value string) (time.Month, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, name := range monthNames {
+		if value == name {
+			return time.Month(i + 1), true
+		}
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= 12 {
+		return time.Month(n), true
+	}
+	return 0, false
+}
+
+// parseNthWeekday accepts "fri", "-1fri", "2mon", "lastfri" and RRULE forms
+// such as "-1FR".
+func parseNthWeekday(value string) (NthWeekday, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(value, "last") {
+		value = "-1" + strings.TrimPrefix(value, "last")
+	}
+	idx := strings.IndexFunc(value, func(c rune) bool { return c >= 'a' && c <= 'z' })
+	if idx < 0 {
+		return NthWeekday{}, fmt.Errorf("invalid recurrence weekday %q", value)
+	}
+	wd, ok := parseWeekdayName(value[idx:])
+	if !ok {
+		return NthWeekday{}, fmt.Errorf("invalid recurrence weekday %q", value)
+	}
+	nth := NthWeekday{Weekday: wd}
+	if idx > 0 {
+		n, err := strconv.Atoi(strings.TrimPrefix(value[:idx], "+"))
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return NthWeekday{}, fmt.Errorf("invalid recurrence weekday ordinal %q", value)
+		}
+		nth.N = n
+	}
+	return nth, nil
+}
+
+func joinWeekdays(days []time.Weekday) string {
+	items := make([]string, 0, len(days))
+	for _, wd := range days {
+		items = append(items, weekdayNames[wd])
+	}
+	return strings.Join(items, ",")
+}
+
+func joinMonths(months []time.Month) string {
+	items := make([]string, 0, len(months))
+	for _, mo := range months {
+		items = append(items, monthNames[mo-1])
+	}
+	return strings.Join(items, ",")
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonth(months []time.Month, mo time.Month) bool {
+	for _, m := range months {
+		if m == mo {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func date(t *testing.T, value string) time.Time {
+	t.Helper()
+	d, err := time.Parse(DateLayout, value)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+	return d
+}
+
+func TestParseRecurrenceLegacyRoundTrip(t *testing.T) {
+	for _, rule := range []string{
+		"daily",
+		"weekly:mon,tue",
+		"monthly:months=jan,feb;days=1,15",
+	} {
+		r, err := ParseRecurrence(rule)
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q) failed: %v", rule, err)
+		}
+		if got := r.String(); got != rule {
+			t.Fatalf("String() = %q, want %q", got, rule)
+		}
+	}
+	r, err := ParseRecurrence("monthly:jan,feb")
+	if err != nil {
+		t.Fatalf("ParseRecurrence legacy months failed: %v", err)
+	}
+	if len(r.Months) != 2 || r.Months[0] != time.January {
+		t.Fatalf("expected legacy months parsed, got %v", r.Months)
+	}
+}
+
+func TestParseRecurrenceRejectsUnknown(t *testing.T) {
+	for _, rule := range []string{"hourly", "weekly:funday", "daily:interval=0", "weekly:weekdays=-1fri"} {
+		if _, err := ParseRecurrence(rule); err == nil {
+			t.Fatalf("expected error for %q", rule)
+		}
+	}
+}
+
+func TestRecurrenceNextWeeklyInterval(t *testing.T) {
+	r, err := ParseRecurrence("weekly:mon,fri;interval=2")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	anchor := date(t, "2026-10-05") // Monday
+	cases := map[string]string{
+		"2026-10-05": "2026-10-09",
+		"2026-10-09": "2026-10-19",
+		"2026-10-12": "2026-10-19",
+		"2026-10-19": "2026-10-23",
+	}
+	for after, want := range cases {
+		got, ok := r.Next(anchor, date(t, after))
+		if !ok || got.Format(DateLayout) != want {
+			t.Fatalf("Next(after %s) = %s %v, want %s", after, got.Format(DateLayout), ok, want)
+		}
+	}
+}
+
+func TestRecurrenceNextMonthlyPositions(t *testing.T) {
+	lastFriday, err := ParseRecurrence("monthly:weekdays=-1fri")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	got, ok := lastFriday.Next(date(t, "2026-10-01"), date(t, "2026-10-18"))
+	if !ok || got.Format(DateLayout) != "2026-10-30" {
+		t.Fatalf("last friday = %s, want 2026-10-30", got.Format(DateLayout))
+	}
+
+	lastDay, err := ParseRecurrence("monthly:days=last")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	got, _ = lastDay.Next(date(t, "2026-01-31"), date(t, "2026-01-31"))
+	if got.Format(DateLayout) != "2026-02-28" {
+		t.Fatalf("last day = %s, want 2026-02-28", got.Format(DateLayout))
+	}
+
+	lastWorkday, err := ParseRRULE("RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+	if err != nil {
+		t.Fatalf("ParseRRULE failed: %v", err)
+	}
+	got, _ = lastWorkday.Next(date(t, "2026-10-01"), date(t, "2026-10-01"))
+	if got.Format(DateLayout) != "2026-10-30" {
+		t.Fatalf("last workday = %s, want 2026-10-30", got.Format(DateLayout))
+	}
+}
+
+func TestRecurrenceYearlyWithoutMonths(t *testing.T) {
+	twentiethMonday, err := ParseRRULE("RRULE:FREQ=YEARLY;BYDAY=20MO")
+	if err != nil {
+		t.Fatalf("ParseRRULE failed: %v", err)
+	}
+	anchor := date(t, "2026-01-01")
+	cases := map[string]string{
+		"2026-01-01": "2026-05-18",
+		"2026-05-18": "2027-05-17",
+	}
+	for after, want := range cases {
+		got, ok := twentiethMonday.Next(anchor, date(t, after))
+		if !ok || got.Format(DateLayout) != want {
+			t.Fatalf("20th Monday after %s = %s %v, want %s", after, got.Format(DateLayout), ok, want)
+		}
+	}
+
+	fifteenth, err := ParseRRULE("RRULE:FREQ=YEARLY;BYMONTHDAY=15")
+	if err != nil {
+		t.Fatalf("ParseRRULE failed: %v", err)
+	}
+	if got, _ := fifteenth.Next(anchor, date(t, "2026-10-18")); got.Format(DateLayout) != "2026-11-15" {
+		t.Fatalf("yearly month day = %s, want 2026-11-15", got.Format(DateLayout))
+	}
+
+	// Without month days or weekdays the start month and day are kept.
+	birthday, err := ParseRRULE("RRULE:FREQ=YEARLY")
+	if err != nil {
+		t.Fatalf("ParseRRULE failed: %v", err)
+	}
+	if got, _ := birthday.Next(date(t, "2026-03-09"), date(t, "2026-10-18")); got.Format(DateLayout) != "2027-03-09" {
+		t.Fatalf("plain yearly = %s, want 2027-03-09", got.Format(DateLayout))
+	}
+
+	for _, rule := range []string{"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=20MO", "RRULE:FREQ=MONTHLY;BYDAY=6MO"} {
+		if _, err := ParseRRULE(rule); err == nil {
+			t.Fatalf("expected %q to be rejected", rule)
+		}
+	}
+}
+
+func TestRecurrenceUntilAndCount(t *testing.T) {
+	r, err := ParseRecurrence("daily:start=2026-10-01;count=3")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	if got, ok := r.Next(time.Time{}, date(t, "2026-10-02")); !ok || got.Format(DateLayout) != "2026-10-03" {
+		t.Fatalf("expected third occurrence 2026-10-03, got %s %v", got.Format(DateLayout), ok)
+	}
+	if _, ok := r.Next(time.Time{}, date(t, "2026-10-03")); ok {
+		t.Fatalf("expected series to end after count")
+	}
+
+	r, err = ParseRecurrence("weekly:mon;until=2026-10-12")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	if _, ok := r.Next(date(t, "2026-10-05"), date(t, "2026-10-12")); ok {
+		t.Fatalf("expected series to end at until")
+	}
+}
+
+func TestRRULERoundTrip(t *testing.T) {
+	r, err := ParseRecurrence("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20261231T000000Z")
+	if err != nil {
+		t.Fatalf("ParseRecurrence RRULE failed: %v", err)
+	}
+	if got := r.String(); got != "weekly:mon,wed;interval=2;until=2026-12-31" {
+		t.Fatalf("String() = %q", got)
+	}
+	if got := r.RRULE(); got != "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20261231" {
+		t.Fatalf("RRULE() = %q", got)
+	}
+
+	r, err = ParseRRULE("DTSTART;VALUE=DATE:20260105\nRRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=4")
+	if err != nil {
+		t.Fatalf("ParseRRULE with DTSTART failed: %v", err)
+	}
+	if r.Start.Format(DateLayout) != "2026-01-05" || r.Count != 4 {
+		t.Fatalf("unexpected DTSTART/COUNT: %+v", r)
+	}
+	if got := r.String(); got != "monthly:weekdays=-1fri;start=2026-01-05;count=4" {
+		t.Fatalf("String() = %q", got)
+	}
+	if _, err := ParseRRULE("RRULE:FREQ=HOURLY"); err == nil {
+		t.Fatalf("expected unsupported frequency error")
+	}
+}
+
+func TestRecurrenceExtensionsAndBetween(t *testing.T) {
+	r, err := ParseRecurrence("daily:missed=catchup;sprint=2")
+	if err != nil {
+		t.Fatalf("ParseRecurrence failed: %v", err)
+	}
+	if r.Policy() != MissedCatchUp || r.Sprint != 2 {
+		t.Fatalf("unexpected extensions: %+v", r)
+	}
+	if got := r.String(); got != "daily:missed=catchup;sprint=2" {
+		t.Fatalf("String() = %q", got)
+	}
+	if got := r.RRULE(); got != "RRULE:FREQ=DAILY" {
+		t.Fatalf("RRULE() = %q", got)
+	}
+	if _, err := ParseRecurrence("daily:missed=later"); err == nil {
+		t.Fatalf("expected invalid policy error")
+	}
+	if def, _ := ParseRecurrence("daily"); def.Policy() != MissedCollapse {
+		t.Fatalf("expected collapse by default")
+	}
+
+	got := r.Between(date(t, "2026-10-01"), date(t, "2026-10-03"), date(t, "2026-10-06"))
+	if len(got) != 3 || got[0].Format(DateLayout) != "2026-10-04" || got[2].Format(DateLayout) != "2026-10-06" {
+		t.Fatalf("Between() = %v", got)
+	}
+}
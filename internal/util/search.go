@@ -2,6 +2,7 @@ package util
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -48,3 +49,63 @@ func ParseSearchQuery(query string) SearchQuery {
 
 	return sq
 }
+
+// JournalQuery represents the parsed filters of a journal browser query.
+type JournalQuery struct {
+	Workspace []string
+	Tags      []string
+	GoalID    int64
+	Sprint    int
+	From      string
+	To        string
+	Text      []string
+}
+
+var (
+	journalTagRegex    = regexp.MustCompile(`(?:tag:|#)(\w+)`)
+	journalGoalRegex   = regexp.MustCompile(`goal:#?(\d+)`)
+	journalSprintRegex = regexp.MustCompile(`sprint:(\d+)`)
+	journalFromRegex   = regexp.MustCompile(`from:(\d{4}-\d{2}-\d{2})`)
+	journalToRegex     = regexp.MustCompile(`to:(\d{4}-\d{2}-\d{2})`)
+	journalDayRegex    = regexp.MustCompile(`date:(\d{4}-\d{2}-\d{2})`)
+)
+
+// ParseJournalQuery breaks a journal browser query into filters:
+// workspace:slug, tag:name or #name, goal:ID, sprint:N, from:DATE, to:DATE
+// and date:DATE; the remaining words match the entry text.
+func ParseJournalQuery(query string) JournalQuery {
+	jq := JournalQuery{}
+	extract := func(re *regexp.Regexp) []string {
+		matches := re.FindAllStringSubmatch(query, -1)
+		var values []string
+		for _, match := range matches {
+			values = append(values, match[1])
+		}
+		query = re.ReplaceAllString(query, "")
+		return values
+	}
+	last := func(values []string) string {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[len(values)-1]
+	}
+
+	jq.Workspace = extract(workspaceRegex)
+	if id := last(extract(journalGoalRegex)); id != "" {
+		jq.GoalID, _ = strconv.ParseInt(id, 10, 64)
+	}
+	for _, tag := range extract(journalTagRegex) {
+		jq.Tags = append(jq.Tags, strings.ToLower(tag))
+	}
+	if n := last(extract(journalSprintRegex)); n != "" {
+		jq.Sprint, _ = strconv.Atoi(n)
+	}
+	jq.From = last(extract(journalFromRegex))
+	jq.To = last(extract(journalToRegex))
+	if day := last(extract(journalDayRegex)); day != "" {
+		jq.From, jq.To = day, day
+	}
+	jq.Text = strings.Fields(query)
+	return jq
+}
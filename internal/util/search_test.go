@@ -25,3 +25,22 @@ func TestParseSearchQuery(t *testing.T) {
 		t.Fatalf("Text = %v, want %v", got.Text, []string{"some", "words"})
 	}
 }
+
+func TestParseJournalQuery(t *testing.T) {
+	q := ParseJournalQuery("workspace:work #Retro tag:ship goal:#12 sprint:2 from:2026-10-01 to:2026-10-15 shipped it")
+	if len(q.Workspace) != 1 || q.Workspace[0] != "work" {
+		t.Fatalf("unexpected workspace: %v", q.Workspace)
+	}
+	if len(q.Tags) != 2 || q.Tags[0] != "retro" || q.Tags[1] != "ship" {
+		t.Fatalf("unexpected tags: %v", q.Tags)
+	}
+	if q.GoalID != 12 || q.Sprint != 2 || q.From != "2026-10-01" || q.To != "2026-10-15" {
+		t.Fatalf("unexpected filters: %+v", q)
+	}
+	if len(q.Text) != 2 || q.Text[0] != "shipped" {
+		t.Fatalf("unexpected text: %v", q.Text)
+	}
+	if day := ParseJournalQuery("date:2026-10-03"); day.From != "2026-10-03" || day.To != "2026-10-03" {
+		t.Fatalf("expected date: to set both bounds, got %+v", day)
+	}
+}